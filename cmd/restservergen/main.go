@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Restservergen generates server stubs of a REST API from its OpenAPI spec:
+// a bedrock route constructor, request struct and handler interface for every
+// operation along with the Go types of its schemas.
+//
+// Usage:
+//
+//	restservergen -spec <file or URL> [-package name] [-o file]
+//
+// The spec is read from a file, in JSON or YAML, or fetched from an http or
+// https URL. The stubs are written to stdout unless -o is set. It is meant to
+// be run by go generate:
+//
+//	//go:generate go run github.com/z5labs/humus/cmd/restservergen -spec api.yaml -package petapi -o api.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/z5labs/humus/rest/servergen"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("restservergen: ")
+
+	spec := flag.String("spec", "", "OpenAPI spec file or URL")
+	pkg := flag.String("package", "api", "package name of the generated stubs")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	if *spec == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := readSpec(*spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := servergen.Generate(data, servergen.Package(*pkg))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		if _, err := os.Stdout.Write(src); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readSpec reads the spec from a file or fetches it from a URL.
+func readSpec(spec string) ([]byte, error) {
+	if !strings.HasPrefix(spec, "http://") && !strings.HasPrefix(spec, "https://") {
+		return os.ReadFile(spec)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spec, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch spec: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
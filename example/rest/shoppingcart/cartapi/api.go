@@ -0,0 +1,312 @@
+// Code generated by servergen. DO NOT EDIT.
+
+// Package cartapi implements the routes of the Shopping Cart API.
+package cartapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus/rest"
+)
+
+// Handler handles every operation of the Shopping Cart API.
+type Handler interface {
+	CreateCartHandler
+	GetCartHandler
+	DeleteCartHandler
+	AddCartItemHandler
+	UpdateCartItemHandler
+	RemoveCartItemHandler
+}
+
+// Options returns the options of a REST API serving every operation of the
+// Shopping Cart API with h. Errors returned by h are mapped to problems with reg.
+func Options(h Handler, reg rest.ErrorRegistry) []rest.Option {
+	return []rest.Option{
+		rest.Title("Shopping Cart API"),
+		rest.Version("1.0.0"),
+		rest.APIDescription("REST API for managing shopping carts on an e-commerce platform."),
+		rest.Handle(CreateCart(h, reg)),
+		rest.Handle(GetCart(h, reg)),
+		rest.Handle(DeleteCart(h, reg)),
+		rest.Handle(AddCartItem(h, reg)),
+		rest.Handle(UpdateCartItem(h, reg)),
+		rest.Handle(RemoveCartItem(h, reg)),
+	}
+}
+
+// CreateCartRequest is the request of CreateCart.
+type CreateCartRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+}
+
+// CreateCartHandler handles POST /carts.
+type CreateCartHandler interface {
+	// CreateCart handles POST /carts.
+	//
+	// Create a new shopping cart
+	CreateCart(ctx context.Context, req CreateCartRequest) (Cart, error)
+}
+
+// CreateCart returns a Route for POST /carts, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func CreateCart(h CreateCartHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.POST("/carts", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (Cart, error) {
+		return h.CreateCart(ctx, CreateCartRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = bedrockrest.OperationID("createCart", ep)
+	ep = bedrockrest.Summary("Create a new shopping cart", ep)
+	ep = bedrockrest.WriteJSON[Cart](http.StatusCreated, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+// GetCartRequest is the request of GetCart.
+type GetCartRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+	CartID     string
+}
+
+// GetCartHandler handles GET /carts/{cartId}.
+type GetCartHandler interface {
+	// GetCart handles GET /carts/{cartId}.
+	//
+	// Get a shopping cart and its items
+	GetCart(ctx context.Context, req GetCartRequest) (Cart, error)
+}
+
+// GetCart returns a Route for GET /carts/{cartId}, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func GetCart(h GetCartHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.GET("/carts/{cartId}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (Cart, error) {
+		return h.GetCart(ctx, GetCartRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+			CartID:      bedrockrest.ParamFrom(req, cartIDParam),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = cartIDParam.Read(ep)
+	ep = bedrockrest.OperationID("getCart", ep)
+	ep = bedrockrest.Summary("Get a shopping cart and its items", ep)
+	ep = bedrockrest.WriteJSON[Cart](http.StatusOK, ep)
+	ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+// DeleteCartRequest is the request of DeleteCart.
+type DeleteCartRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+	CartID     string
+}
+
+// DeleteCartHandler handles DELETE /carts/{cartId}.
+type DeleteCartHandler interface {
+	// DeleteCart handles DELETE /carts/{cartId}.
+	//
+	// Delete a shopping cart and all its items
+	DeleteCart(ctx context.Context, req DeleteCartRequest) error
+}
+
+// DeleteCart returns a Route for DELETE /carts/{cartId}, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func DeleteCart(h DeleteCartHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.DELETE("/carts/{cartId}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (io.Reader, error) {
+		return http.NoBody, h.DeleteCart(ctx, DeleteCartRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+			CartID:      bedrockrest.ParamFrom(req, cartIDParam),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = cartIDParam.Read(ep)
+	ep = bedrockrest.OperationID("deleteCart", ep)
+	ep = bedrockrest.Summary("Delete a shopping cart and all its items", ep)
+	ep = bedrockrest.WriteBinary(http.StatusNoContent, "", ep)
+	ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+// AddCartItemRequest is the request of AddCartItem.
+type AddCartItemRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+	CartID     string
+	Body       AddItemRequest
+}
+
+// AddCartItemHandler handles POST /carts/{cartId}/items.
+type AddCartItemHandler interface {
+	// AddCartItem handles POST /carts/{cartId}/items.
+	//
+	// Add an item to a shopping cart
+	AddCartItem(ctx context.Context, req AddCartItemRequest) (CartItem, error)
+}
+
+// AddCartItem returns a Route for POST /carts/{cartId}/items, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func AddCartItem(h AddCartItemHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.POST("/carts/{cartId}/items", func(ctx context.Context, req bedrockrest.Request[AddItemRequest]) (CartItem, error) {
+		return h.AddCartItem(ctx, AddCartItemRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+			CartID:      bedrockrest.ParamFrom(req, cartIDParam),
+			Body:        req.Body(),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = cartIDParam.Read(ep)
+	ep = bedrockrest.ReadJSON[AddItemRequest](ep)
+	ep = bedrockrest.OperationID("addCartItem", ep)
+	ep = bedrockrest.Summary("Add an item to a shopping cart", ep)
+	ep = bedrockrest.WriteJSON[CartItem](http.StatusCreated, ep)
+	ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+// UpdateCartItemRequest is the request of UpdateCartItem.
+type UpdateCartItemRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+	CartID     string
+	ItemID     string
+	Body       UpdateItemRequest
+}
+
+// UpdateCartItemHandler handles PATCH /carts/{cartId}/items/{itemId}.
+type UpdateCartItemHandler interface {
+	// UpdateCartItem handles PATCH /carts/{cartId}/items/{itemId}.
+	//
+	// Update the quantity of an item in the cart
+	UpdateCartItem(ctx context.Context, req UpdateCartItemRequest) (CartItem, error)
+}
+
+// UpdateCartItem returns a Route for PATCH /carts/{cartId}/items/{itemId}, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func UpdateCartItem(h UpdateCartItemHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.PATCH("/carts/{cartId}/items/{itemId}", func(ctx context.Context, req bedrockrest.Request[UpdateItemRequest]) (CartItem, error) {
+		return h.UpdateCartItem(ctx, UpdateCartItemRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+			CartID:      bedrockrest.ParamFrom(req, cartIDParam),
+			ItemID:      bedrockrest.ParamFrom(req, itemIDParam),
+			Body:        req.Body(),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = cartIDParam.Read(ep)
+	ep = itemIDParam.Read(ep)
+	ep = bedrockrest.ReadJSON[UpdateItemRequest](ep)
+	ep = bedrockrest.OperationID("updateCartItem", ep)
+	ep = bedrockrest.Summary("Update the quantity of an item in the cart", ep)
+	ep = bedrockrest.WriteJSON[CartItem](http.StatusOK, ep)
+	ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+// RemoveCartItemRequest is the request of RemoveCartItem.
+type RemoveCartItemRequest struct {
+	// W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}
+	Traceparent string
+	// W3C Trace Context tracestate header carrying vendor-specific trace data.
+	Tracestate string
+	CartID     string
+	ItemID     string
+}
+
+// RemoveCartItemHandler handles DELETE /carts/{cartId}/items/{itemId}.
+type RemoveCartItemHandler interface {
+	// RemoveCartItem handles DELETE /carts/{cartId}/items/{itemId}.
+	//
+	// Remove an item from the shopping cart
+	RemoveCartItem(ctx context.Context, req RemoveCartItemRequest) error
+}
+
+// RemoveCartItem returns a Route for DELETE /carts/{cartId}/items/{itemId}, which is handled by h.
+// Errors returned by h are mapped to problems with reg.
+func RemoveCartItem(h RemoveCartItemHandler, reg rest.ErrorRegistry) bedrockrest.Route {
+	ep := bedrockrest.DELETE("/carts/{cartId}/items/{itemId}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (io.Reader, error) {
+		return http.NoBody, h.RemoveCartItem(ctx, RemoveCartItemRequest{
+			Traceparent: bedrockrest.ParamFrom(req, traceparentParam),
+			Tracestate:  bedrockrest.ParamFrom(req, tracestateParam),
+			CartID:      bedrockrest.ParamFrom(req, cartIDParam),
+			ItemID:      bedrockrest.ParamFrom(req, itemIDParam),
+		})
+	})
+	ep = traceparentParam.Read(ep)
+	ep = tracestateParam.Read(ep)
+	ep = cartIDParam.Read(ep)
+	ep = itemIDParam.Read(ep)
+	ep = bedrockrest.OperationID("removeCartItem", ep)
+	ep = bedrockrest.Summary("Remove an item from the shopping cart", ep)
+	ep = bedrockrest.WriteBinary(http.StatusNoContent, "", ep)
+	ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)
+	return rest.CatchProblems(reg, ep)
+}
+
+var (
+	cartIDParam      = bedrockrest.PathParam[string]("cartId")
+	itemIDParam      = bedrockrest.PathParam[string]("itemId")
+	traceparentParam = bedrockrest.HeaderParam[string]("traceparent",
+		bedrockrest.ParamDescription("W3C Trace Context traceparent header for distributed tracing. Format: 00-{traceId}-{parentId}-{flags}"),
+	)
+	tracestateParam = bedrockrest.HeaderParam[string]("tracestate",
+		bedrockrest.ParamDescription("W3C Trace Context tracestate header carrying vendor-specific trace data."),
+	)
+)
+
+// AddItemRequest is the AddItemRequest schema.
+type AddItemRequest struct {
+	ProductID string  `json:"productId" required:"true"`
+	Quantity  int     `json:"quantity" required:"true" minimum:"1"`
+	UnitPrice float64 `json:"unitPrice" required:"true"`
+}
+
+// Cart is the Cart schema.
+type Cart struct {
+	CartID    string     `json:"cartId" required:"true"`
+	CreatedAt time.Time  `json:"createdAt" required:"true"`
+	Items     []CartItem `json:"items" required:"true"`
+	UpdatedAt time.Time  `json:"updatedAt" required:"true"`
+}
+
+// CartItem is the CartItem schema.
+type CartItem struct {
+	CartID    string    `json:"cartId" required:"true"`
+	CreatedAt time.Time `json:"createdAt" required:"true"`
+	ItemID    string    `json:"itemId" required:"true"`
+	ProductID string    `json:"productId" required:"true"`
+	Quantity  int       `json:"quantity" required:"true" minimum:"1"`
+	UnitPrice float64   `json:"unitPrice" required:"true"`
+	UpdatedAt time.Time `json:"updatedAt" required:"true"`
+}
+
+// UpdateItemRequest is the UpdateItemRequest schema.
+type UpdateItemRequest struct {
+	Quantity int `json:"quantity" required:"true" minimum:"1"`
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cartapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus/rest"
+)
+
+var errCartNotFound = errors.New("cart not found")
+
+// memHandler is an in-memory Handler.
+type memHandler struct {
+	mu    sync.Mutex
+	carts map[string]Cart
+}
+
+var _ Handler = (*memHandler)(nil)
+
+func (h *memHandler) CreateCart(ctx context.Context, req CreateCartRequest) (Cart, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cart := Cart{CartID: uuid.NewString(), Items: []CartItem{}, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	h.carts[cart.CartID] = cart
+	return cart, nil
+}
+
+func (h *memHandler) GetCart(ctx context.Context, req GetCartRequest) (Cart, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cart, ok := h.carts[req.CartID]
+	if !ok {
+		return Cart{}, errCartNotFound
+	}
+	return cart, nil
+}
+
+func (h *memHandler) DeleteCart(ctx context.Context, req DeleteCartRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.carts[req.CartID]; !ok {
+		return errCartNotFound
+	}
+	delete(h.carts, req.CartID)
+	return nil
+}
+
+func (h *memHandler) AddCartItem(ctx context.Context, req AddCartItemRequest) (CartItem, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cart, ok := h.carts[req.CartID]
+	if !ok {
+		return CartItem{}, errCartNotFound
+	}
+	item := CartItem{
+		ItemID:    uuid.NewString(),
+		CartID:    req.CartID,
+		ProductID: req.Body.ProductID,
+		Quantity:  req.Body.Quantity,
+		UnitPrice: req.Body.UnitPrice,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	cart.Items = append(cart.Items, item)
+	h.carts[req.CartID] = cart
+	return item, nil
+}
+
+func (h *memHandler) UpdateCartItem(ctx context.Context, req UpdateCartItemRequest) (CartItem, error) {
+	return CartItem{}, errors.ErrUnsupported
+}
+
+func (h *memHandler) RemoveCartItem(ctx context.Context, req RemoveCartItemRequest) error {
+	return errors.ErrUnsupported
+}
+
+func TestRoutes(t *testing.T) {
+	h := &memHandler{carts: make(map[string]Cart)}
+	reg := rest.ErrorRegistry{rest.MapError(errCartNotFound, http.StatusNotFound, "The cart does not exist.")}
+	handler, err := bedrockrest.Build(
+		CreateCart(h, reg).Route(),
+		GetCart(h, reg).Route(),
+		DeleteCart(h, reg).Route(),
+		AddCartItem(h, reg).Route(),
+	).Build(context.Background())
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(rest.ProblemDetails(handler))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Post(srv.URL+"/carts", "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var cart Cart
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&cart))
+
+	resp, err = http.Post(srv.URL+"/carts/"+cart.CartID+"/items", "application/json",
+		strings.NewReader(`{"productId":"product-1","quantity":2,"unitPrice":9.99}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var item CartItem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&item))
+	require.Equal(t, cart.CartID, item.CartID)
+	require.Equal(t, 2, item.Quantity)
+
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+"/carts/"+cart.CartID, nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/carts/" + cart.CartID)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	var p rest.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+	require.Equal(t, "The cart does not exist.", p.Detail)
+}
+
+func TestOptions(t *testing.T) {
+	spec, err := rest.Spec(context.Background(), Options(&memHandler{}, nil)...)
+	require.NoError(t, err)
+
+	var doc struct {
+		Info struct {
+			Title string `json:"title"`
+		} `json:"info"`
+		Paths map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(spec, &doc))
+	require.Equal(t, "Shopping Cart API", doc.Info.Title)
+	require.Equal(t, "getCart", doc.Paths["/carts/{cartId}"]["get"].OperationID)
+	require.Contains(t, doc.Paths["/carts/{cartId}"]["get"].Responses, "404")
+	require.Equal(t, "removeCartItem", doc.Paths["/carts/{cartId}/items/{itemId}"]["delete"].OperationID)
+}
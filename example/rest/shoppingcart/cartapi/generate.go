@@ -0,0 +1,8 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cartapi
+
+//go:generate go run github.com/z5labs/humus/cmd/restservergen -spec ../specs/api.yaml -package cartapi -o api.go
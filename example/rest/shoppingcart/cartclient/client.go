@@ -0,0 +1,179 @@
+// Code generated by clientgen. DO NOT EDIT.
+
+// Package cartclient is a client of the Shopping Cart API.
+package cartclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	restclient "github.com/z5labs/humus/rest/client"
+)
+
+// Client is a client of the Shopping Cart API 1.0.0.
+//
+// REST API for managing shopping carts on an e-commerce platform.
+type Client struct {
+	c *restclient.Client
+}
+
+// New returns a client of the API served at baseURL.
+func New(baseURL string, opts ...restclient.Option) (*Client, error) {
+	c, err := restclient.New(baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: c}, nil
+}
+
+// CreateCart calls POST /carts.
+//
+// Create a new shopping cart
+func (c *Client) CreateCart(ctx context.Context) (DatabaseCart, error) {
+	req := restclient.Request{
+		Operation: "createCart",
+		Method:    http.MethodPost,
+		Path:      "/carts",
+	}
+	var out DatabaseCart
+	err := c.c.Do(ctx, req, &out)
+	return out, err
+}
+
+// GetCartParams are the parameters of GetCart.
+type GetCartParams struct {
+	// Shopping cart identifier (UUID)
+	CartID string
+}
+
+// GetCart calls GET /carts/{cartId}.
+//
+// Get a shopping cart and its items
+func (c *Client) GetCart(ctx context.Context, params GetCartParams) (DatabaseCart, error) {
+	req := restclient.Request{
+		Operation: "getCart",
+		Method:    http.MethodGet,
+		Path:      "/carts/" + url.PathEscape(restclient.FormatParam(params.CartID)),
+	}
+	var out DatabaseCart
+	err := c.c.Do(ctx, req, &out)
+	return out, err
+}
+
+// DeleteCartParams are the parameters of DeleteCart.
+type DeleteCartParams struct {
+	// Shopping cart identifier (UUID)
+	CartID string
+}
+
+// DeleteCart calls DELETE /carts/{cartId}.
+//
+// Delete a shopping cart and all its items
+func (c *Client) DeleteCart(ctx context.Context, params DeleteCartParams) error {
+	req := restclient.Request{
+		Operation: "deleteCart",
+		Method:    http.MethodDelete,
+		Path:      "/carts/" + url.PathEscape(restclient.FormatParam(params.CartID)),
+	}
+	return c.c.Do(ctx, req, nil)
+}
+
+// AddCartItemParams are the parameters of AddCartItem.
+type AddCartItemParams struct {
+	// Shopping cart identifier (UUID)
+	CartID string
+}
+
+// AddCartItem calls POST /carts/{cartId}/items.
+//
+// Add an item to a shopping cart
+func (c *Client) AddCartItem(ctx context.Context, params AddCartItemParams, body EndpointAddItemRequest) (DatabaseCartItem, error) {
+	req := restclient.Request{
+		Operation: "addCartItem",
+		Method:    http.MethodPost,
+		Path:      "/carts/" + url.PathEscape(restclient.FormatParam(params.CartID)) + "/items",
+		Body:      body,
+	}
+	var out DatabaseCartItem
+	err := c.c.Do(ctx, req, &out)
+	return out, err
+}
+
+// UpdateCartItemParams are the parameters of UpdateCartItem.
+type UpdateCartItemParams struct {
+	// Shopping cart identifier (UUID)
+	CartID string
+	// Cart item identifier (UUID)
+	ItemID string
+}
+
+// UpdateCartItem calls PATCH /carts/{cartId}/items/{itemId}.
+//
+// Update the quantity of an item in the cart
+func (c *Client) UpdateCartItem(ctx context.Context, params UpdateCartItemParams, body EndpointUpdateItemRequest) (DatabaseCartItem, error) {
+	req := restclient.Request{
+		Operation: "updateCartItem",
+		Method:    http.MethodPatch,
+		Path:      "/carts/" + url.PathEscape(restclient.FormatParam(params.CartID)) + "/items/" + url.PathEscape(restclient.FormatParam(params.ItemID)),
+		Body:      body,
+	}
+	var out DatabaseCartItem
+	err := c.c.Do(ctx, req, &out)
+	return out, err
+}
+
+// RemoveCartItemParams are the parameters of RemoveCartItem.
+type RemoveCartItemParams struct {
+	// Shopping cart identifier (UUID)
+	CartID string
+	// Cart item identifier (UUID)
+	ItemID string
+}
+
+// RemoveCartItem calls DELETE /carts/{cartId}/items/{itemId}.
+//
+// Remove an item from the shopping cart
+func (c *Client) RemoveCartItem(ctx context.Context, params RemoveCartItemParams) error {
+	req := restclient.Request{
+		Operation: "removeCartItem",
+		Method:    http.MethodDelete,
+		Path:      "/carts/" + url.PathEscape(restclient.FormatParam(params.CartID)) + "/items/" + url.PathEscape(restclient.FormatParam(params.ItemID)),
+	}
+	return c.c.Do(ctx, req, nil)
+}
+
+// DatabaseCart is the DatabaseCart schema.
+type DatabaseCart struct {
+	CartID    UUIDUUID           `json:"cartId,omitempty"`
+	CreatedAt time.Time          `json:"createdAt,omitempty"`
+	Items     []DatabaseCartItem `json:"items,omitempty"`
+	UpdatedAt time.Time          `json:"updatedAt,omitempty"`
+}
+
+// DatabaseCartItem is the DatabaseCartItem schema.
+type DatabaseCartItem struct {
+	CartID    UUIDUUID  `json:"cartId,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	ItemID    UUIDUUID  `json:"itemId,omitempty"`
+	ProductID string    `json:"productId,omitempty"`
+	Quantity  int       `json:"quantity,omitempty"`
+	UnitPrice float64   `json:"unitPrice,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// EndpointAddItemRequest is the EndpointAddItemRequest schema.
+type EndpointAddItemRequest struct {
+	ProductID string  `json:"productId" required:"true" minLength:"1"`
+	Quantity  int     `json:"quantity" required:"true" minimum:"1"`
+	UnitPrice float64 `json:"unitPrice" required:"true" minimum:"0"`
+}
+
+// EndpointUpdateItemRequest is the EndpointUpdateItemRequest schema.
+type EndpointUpdateItemRequest struct {
+	Quantity int `json:"quantity" required:"true" minimum:"1"`
+}
+
+// UUIDUUID is the UuidUUID schema.
+type UUIDUUID string
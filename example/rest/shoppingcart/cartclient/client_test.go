@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cartclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus/example/rest/shoppingcart/endpoint"
+	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
+)
+
+// memStore is an in-memory database.Store.
+type memStore struct {
+	mu    sync.Mutex
+	carts map[uuid.UUID]database.Cart
+}
+
+func (s *memStore) CreateCart(ctx context.Context) (database.Cart, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cart := database.Cart{CartID: uuid.New(), Items: []database.CartItem{}, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	s.carts[cart.CartID] = cart
+	return cart, nil
+}
+
+func (s *memStore) GetCart(ctx context.Context, cartID uuid.UUID) (database.Cart, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cart, ok := s.carts[cartID]
+	if !ok {
+		return database.Cart{}, database.ErrCartNotFound
+	}
+	return cart, nil
+}
+
+func (s *memStore) DeleteCart(ctx context.Context, cartID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.carts[cartID]; !ok {
+		return database.ErrCartNotFound
+	}
+	delete(s.carts, cartID)
+	return nil
+}
+
+func (s *memStore) AddCartItem(ctx context.Context, cartID uuid.UUID, req database.AddItemRequest) (database.CartItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cart, ok := s.carts[cartID]
+	if !ok {
+		return database.CartItem{}, database.ErrCartNotFound
+	}
+	item := database.CartItem{
+		ItemID:    uuid.New(),
+		CartID:    cartID,
+		ProductID: req.ProductID,
+		Quantity:  req.Quantity,
+		UnitPrice: req.UnitPrice,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	cart.Items = append(cart.Items, item)
+	s.carts[cartID] = cart
+	return item, nil
+}
+
+func (s *memStore) UpdateCartItem(ctx context.Context, cartID, itemID uuid.UUID, quantity int) (database.CartItem, error) {
+	return database.CartItem{}, errors.ErrUnsupported
+}
+
+func (s *memStore) RemoveCartItem(ctx context.Context, cartID, itemID uuid.UUID) error {
+	return errors.ErrUnsupported
+}
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	store := &memStore{carts: make(map[uuid.UUID]database.Cart)}
+	opts := []bedrockrest.Option{
+		endpoint.CreateCart(store).Route(),
+		endpoint.GetCart(store).Route(),
+		endpoint.DeleteCart(store).Route(),
+		endpoint.AddCartItem(store).Route(),
+	}
+	h, err := bedrockrest.Build(opts...).Build(context.Background())
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(rest.ProblemDetails(h))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	cart, err := c.CreateCart(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, cart.CartID)
+
+	item, err := c.AddCartItem(ctx, AddCartItemParams{CartID: string(cart.CartID)}, EndpointAddItemRequest{
+		ProductID: "product-1",
+		Quantity:  2,
+		UnitPrice: 9.99,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cart.CartID, item.CartID)
+	require.Equal(t, 2, item.Quantity)
+
+	got, err := c.GetCart(ctx, GetCartParams{CartID: string(cart.CartID)})
+	require.NoError(t, err)
+	require.Len(t, got.Items, 1)
+	require.Equal(t, "product-1", got.Items[0].ProductID)
+
+	require.NoError(t, c.DeleteCart(ctx, DeleteCartParams{CartID: string(cart.CartID)}))
+
+	_, err = c.GetCart(ctx, GetCartParams{CartID: string(cart.CartID)})
+	var p rest.Problem
+	require.ErrorAs(t, err, &p)
+	require.Equal(t, http.StatusNotFound, p.Status)
+	require.Equal(t, "The cart does not exist.", p.Detail)
+}
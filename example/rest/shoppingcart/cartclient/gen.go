@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:build ignore
+
+// gen generates the shopping cart client from the OpenAPI spec of the routes.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/z5labs/humus/example/rest/shoppingcart/app"
+	"github.com/z5labs/humus/rest"
+	"github.com/z5labs/humus/rest/client/clientgen"
+)
+
+func main() {
+	spec, err := rest.Spec(context.Background(), app.Options(nil)...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := clientgen.Generate(spec, clientgen.Package("cartclient"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("client.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package cartclient
+
+//go:generate go run gen.go
@@ -7,19 +7,19 @@ package endpoint
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 )
 
 // AddItemRequest is the JSON body for POST /carts/{cartId}/items.
 type AddItemRequest struct {
-	ProductID string  `json:"productId"`
-	Quantity  int     `json:"quantity"`
-	UnitPrice float64 `json:"unitPrice"`
+	ProductID string  `json:"productId" required:"true" minLength:"1"`
+	Quantity  int     `json:"quantity" required:"true" minimum:"1"`
+	UnitPrice float64 `json:"unitPrice" required:"true" minimum:"0"`
 }
 
 // AddCartItem returns a Route for POST /carts/{cartId}/items.
@@ -28,19 +28,15 @@ func AddCartItem(store database.Store) bedrockrest.Route {
 	ep := bedrockrest.POST("/carts/{cartId}/items", func(ctx context.Context, req bedrockrest.Request[AddItemRequest]) (database.CartItem, error) {
 		id, err := uuid.Parse(bedrockrest.ParamFrom(req, cartID))
 		if err != nil {
-			return database.CartItem{}, notFound("cart not found")
+			return database.CartItem{}, database.ErrCartNotFound
 		}
 
 		body := req.Body()
-		item, err := store.AddCartItem(ctx, id, database.AddItemRequest{
+		return store.AddCartItem(ctx, id, database.AddItemRequest{
 			ProductID: body.ProductID,
 			Quantity:  body.Quantity,
 			UnitPrice: body.UnitPrice,
 		})
-		if errors.Is(err, database.ErrCartNotFound) {
-			return database.CartItem{}, notFound("cart not found")
-		}
-		return item, err
 	})
 	ep = cartID.Read(ep)
 	ep = bedrockrest.ReadJSON[AddItemRequest](ep)
@@ -48,6 +44,5 @@ func AddCartItem(store database.Store) bedrockrest.Route {
 	ep = bedrockrest.Summary("Add an item to a shopping cart", ep)
 	ep = bedrockrest.Tags([]string{"items"}, ep)
 	ep = bedrockrest.WriteJSON[database.CartItem](http.StatusCreated, ep)
-	ep = bedrockrest.ErrorJSON[NotFoundError](http.StatusNotFound, ep)
-	return bedrockrest.CatchAll(http.StatusInternalServerError, internalError, ep)
+	return rest.CatchProblems(errorRegistry, ep)
 }
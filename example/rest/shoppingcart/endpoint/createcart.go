@@ -9,8 +9,9 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 )
 
 // CreateCart returns a Route for POST /carts.
@@ -23,5 +24,5 @@ func CreateCart(store database.Store) bedrockrest.Route {
 	ep = bedrockrest.Summary("Create a new shopping cart", ep)
 	ep = bedrockrest.Tags([]string{"carts"}, ep)
 	ep = bedrockrest.WriteJSON[database.Cart](http.StatusCreated, ep)
-	return bedrockrest.CatchAll(http.StatusInternalServerError, internalError, ep)
+	return rest.CatchProblems(nil, ep)
 }
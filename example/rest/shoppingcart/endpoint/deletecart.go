@@ -8,13 +8,13 @@ package endpoint
 import (
 	"bytes"
 	"context"
-	"errors"
 	"io"
 	"net/http"
 
 	"github.com/google/uuid"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 )
 
 // DeleteCart returns a Route for DELETE /carts/{cartId}.
@@ -23,13 +23,10 @@ func DeleteCart(store database.Store) bedrockrest.Route {
 	ep := bedrockrest.DELETE("/carts/{cartId}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (io.Reader, error) {
 		id, err := uuid.Parse(bedrockrest.ParamFrom(req, cartID))
 		if err != nil {
-			return nil, notFound("cart not found")
+			return nil, database.ErrCartNotFound
 		}
 
 		err = store.DeleteCart(ctx, id)
-		if errors.Is(err, database.ErrCartNotFound) {
-			return nil, notFound("cart not found")
-		}
 		if err != nil {
 			return nil, err
 		}
@@ -40,6 +37,5 @@ func DeleteCart(store database.Store) bedrockrest.Route {
 	ep = bedrockrest.Summary("Delete a shopping cart and all its items", ep)
 	ep = bedrockrest.Tags([]string{"carts"}, ep)
 	ep = bedrockrest.WriteBinary(http.StatusNoContent, "", ep)
-	ep = bedrockrest.ErrorJSON[NotFoundError](http.StatusNotFound, ep)
-	return bedrockrest.CatchAll(http.StatusInternalServerError, internalError, ep)
+	return rest.CatchProblems(errorRegistry, ep)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 
 	"github.com/stretchr/testify/require"
 )
@@ -66,7 +67,7 @@ func buildTestHandler(t *testing.T, routes ...bedrockrest.Route) http.Handler {
 	handler := bedrockrest.Build(opts...)
 	h, err := handler.Build(context.Background())
 	require.NoError(t, err)
-	return h
+	return rest.ProblemDetails(h)
 }
 
 func newCart() database.Cart {
@@ -111,6 +112,26 @@ func TestCreateCart(t *testing.T) {
 		require.Equal(t, cart.CartID, got.CartID)
 		require.Empty(t, got.Items)
 	})
+
+	t.Run("does not document a 404", func(t *testing.T) {
+		h := buildTestHandler(t, CreateCart(&mockStore{}))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]any `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+
+		responses := spec.Paths["/carts"]["post"].Responses
+		require.Contains(t, responses, "201")
+		require.NotContains(t, responses, "404")
+	})
 }
 
 func TestGetCart(t *testing.T) {
@@ -151,9 +172,10 @@ func TestGetCart(t *testing.T) {
 
 		require.Equal(t, http.StatusNotFound, w.Code)
 
-		var got NotFoundError
+		var got rest.Problem
 		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
 		require.Equal(t, 404, got.Status)
+		require.Equal(t, "The cart does not exist.", got.Detail)
 	})
 
 	t.Run("returns 404 for invalid cart ID", func(t *testing.T) {
@@ -165,6 +187,10 @@ func TestGetCart(t *testing.T) {
 		h.ServeHTTP(w, r)
 
 		require.Equal(t, http.StatusNotFound, w.Code)
+
+		var got rest.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		require.Equal(t, "The cart does not exist.", got.Detail)
 	})
 }
 
@@ -5,41 +5,15 @@
 
 package endpoint
 
-// NotFoundError is an RFC 7807 Problem Details error for 404 responses.
-type NotFoundError struct {
-	Type     string `json:"type,omitempty"`
-	Title    string `json:"title,omitempty"`
-	Status   int    `json:"status,omitempty"`
-	Detail   string `json:"detail,omitempty"`
-	Instance string `json:"instance,omitempty"`
-}
-
-func (e NotFoundError) Error() string { return e.Detail }
-
-func notFound(detail string) NotFoundError {
-	return NotFoundError{
-		Type:   "about:blank",
-		Title:  "Not Found",
-		Status: 404,
-		Detail: detail,
-	}
-}
-
-// InternalServerError is an RFC 7807 Problem Details error for 500 responses.
-type InternalServerError struct {
-	Type   string `json:"type,omitempty"`
-	Title  string `json:"title,omitempty"`
-	Status int    `json:"status,omitempty"`
-	Detail string `json:"detail,omitempty"`
-}
+import (
+	"net/http"
 
-func (e InternalServerError) Error() string { return e.Detail }
+	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
+)
 
-func internalError(_ error) InternalServerError {
-	return InternalServerError{
-		Type:   "about:blank",
-		Title:  "Internal Server Error",
-		Status: 500,
-		Detail: "An unexpected error occurred.",
-	}
+// errorRegistry maps the errors of the database service to problems.
+var errorRegistry = rest.ErrorRegistry{
+	rest.MapError(database.ErrCartNotFound, http.StatusNotFound, "The cart does not exist."),
+	rest.MapError(database.ErrItemNotFound, http.StatusNotFound, "The cart or item does not exist."),
 }
@@ -8,13 +8,13 @@ package endpoint
 import (
 	"bytes"
 	"context"
-	"errors"
 	"io"
 	"net/http"
 
 	"github.com/google/uuid"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 )
 
 // RemoveCartItem returns a Route for DELETE /carts/{cartId}/items/{itemId}.
@@ -23,18 +23,15 @@ func RemoveCartItem(store database.Store) bedrockrest.Route {
 	ep := bedrockrest.DELETE("/carts/{cartId}/items/{itemId}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (io.Reader, error) {
 		cID, err := uuid.Parse(bedrockrest.ParamFrom(req, cartID))
 		if err != nil {
-			return nil, notFound("cart not found")
+			return nil, database.ErrCartNotFound
 		}
 
 		iID, err := uuid.Parse(bedrockrest.ParamFrom(req, itemID))
 		if err != nil {
-			return nil, notFound("item not found")
+			return nil, database.ErrItemNotFound
 		}
 
 		err = store.RemoveCartItem(ctx, cID, iID)
-		if errors.Is(err, database.ErrItemNotFound) {
-			return nil, notFound("cart or item not found")
-		}
 		if err != nil {
 			return nil, err
 		}
@@ -46,6 +43,5 @@ func RemoveCartItem(store database.Store) bedrockrest.Route {
 	ep = bedrockrest.Summary("Remove an item from the shopping cart", ep)
 	ep = bedrockrest.Tags([]string{"items"}, ep)
 	ep = bedrockrest.WriteBinary(http.StatusNoContent, "", ep)
-	ep = bedrockrest.ErrorJSON[NotFoundError](http.StatusNotFound, ep)
-	return bedrockrest.CatchAll(http.StatusInternalServerError, internalError, ep)
+	return rest.CatchProblems(errorRegistry, ep)
 }
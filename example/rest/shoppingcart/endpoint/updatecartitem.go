@@ -7,17 +7,17 @@ package endpoint
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/z5labs/humus/example/rest/shoppingcart/service/database"
+	"github.com/z5labs/humus/rest"
 )
 
 // UpdateItemRequest is the JSON body for PATCH /carts/{cartId}/items/{itemId}.
 type UpdateItemRequest struct {
-	Quantity int `json:"quantity"`
+	Quantity int `json:"quantity" required:"true" minimum:"1"`
 }
 
 // UpdateCartItem returns a Route for PATCH /carts/{cartId}/items/{itemId}.
@@ -26,19 +26,15 @@ func UpdateCartItem(store database.Store) bedrockrest.Route {
 	ep := bedrockrest.PATCH("/carts/{cartId}/items/{itemId}", func(ctx context.Context, req bedrockrest.Request[UpdateItemRequest]) (database.CartItem, error) {
 		cID, err := uuid.Parse(bedrockrest.ParamFrom(req, cartID))
 		if err != nil {
-			return database.CartItem{}, notFound("cart not found")
+			return database.CartItem{}, database.ErrCartNotFound
 		}
 
 		iID, err := uuid.Parse(bedrockrest.ParamFrom(req, itemID))
 		if err != nil {
-			return database.CartItem{}, notFound("item not found")
+			return database.CartItem{}, database.ErrItemNotFound
 		}
 
-		item, err := store.UpdateCartItem(ctx, cID, iID, req.Body().Quantity)
-		if errors.Is(err, database.ErrItemNotFound) {
-			return database.CartItem{}, notFound("cart or item not found")
-		}
-		return item, err
+		return store.UpdateCartItem(ctx, cID, iID, req.Body().Quantity)
 	})
 	ep = cartID.Read(ep)
 	ep = itemID.Read(ep)
@@ -47,6 +43,5 @@ func UpdateCartItem(store database.Store) bedrockrest.Route {
 	ep = bedrockrest.Summary("Update the quantity of an item in the cart", ep)
 	ep = bedrockrest.Tags([]string{"items"}, ep)
 	ep = bedrockrest.WriteJSON[database.CartItem](http.StatusOK, ep)
-	ep = bedrockrest.ErrorJSON[NotFoundError](http.StatusNotFound, ep)
-	return bedrockrest.CatchAll(http.StatusInternalServerError, internalError, ep)
+	return rest.CatchProblems(errorRegistry, ep)
 }
@@ -87,3 +87,16 @@ func (om OrMonitor) Healthy(ctx context.Context) (bool, error) {
 	}
 	return false, errors.Join(errs...)
 }
+
+// NamedMonitor is a [Monitor] with a name which identifies it in health reports,
+// e.g. the per-check results returned by the rest package's health endpoints.
+type NamedMonitor struct {
+	Monitor
+
+	Name string
+}
+
+// Named is a simple helper for initializing a [NamedMonitor].
+func Named(name string, m Monitor) NamedMonitor {
+	return NamedMonitor{Monitor: m, Name: name}
+}
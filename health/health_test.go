@@ -110,3 +110,23 @@ func TestOrMonitor_Healthy(t *testing.T) {
 		})
 	})
 }
+
+func TestNamedMonitor_Healthy(t *testing.T) {
+	t.Run("will return the state of the wrapped Monitor", func(t *testing.T) {
+		var b Binary
+		b.MarkHealthy()
+
+		named := Named("database", &b)
+		if !assert.Equal(t, "database", named.Name) {
+			return
+		}
+
+		healthy, err := named.Healthy(context.Background())
+		if !assert.Nil(t, err) {
+			return
+		}
+		if !assert.True(t, healthy) {
+			return
+		}
+	})
+}
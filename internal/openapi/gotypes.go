@@ -0,0 +1,404 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoTypes generates Go type declarations for the schemas of a document.
+// Component schemas are declared as named types and inline objects and enums
+// as named types derived from where they are used. Struct fields carry the
+// json tag along with the validation tags of humus, e.g. required and
+// minimum, so the constraints of the schema are retained.
+type GoTypes struct {
+	doc *Document
+
+	names    map[string]string // component schema name to Go type name
+	reserved map[string]bool
+	decls    map[string]string
+	imports  map[string]bool
+}
+
+// NewGoTypes returns a generator of the declarations of the schemas of doc.
+// The component schemas are named upfront, avoiding the reserved names.
+func NewGoTypes(doc *Document, reserved ...string) *GoTypes {
+	t := &GoTypes{
+		doc:      doc,
+		names:    make(map[string]string),
+		reserved: make(map[string]bool),
+		decls:    make(map[string]string),
+		imports:  make(map[string]bool),
+	}
+	for _, name := range reserved {
+		t.reserved[name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(doc.Components.Schemas)) {
+		t.names[name] = t.unique(GoName(name))
+	}
+	return t
+}
+
+// unique reserves a Go type name based on name.
+func (t *GoTypes) unique(name string) string {
+	if name == "" {
+		name = "Type"
+	}
+	unique := name
+	for i := 2; t.reserved[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	t.reserved[unique] = true
+	return unique
+}
+
+// Reserve reserves a name for a declaration of the caller, returning the name
+// it was reserved under.
+func (t *GoTypes) Reserve(name string) string {
+	return t.unique(name)
+}
+
+// DeclareComponents declares every component schema, including schemas which
+// are not referred to by any operation.
+func (t *GoTypes) DeclareComponents() {
+	for _, name := range slices.Sorted(maps.Keys(t.doc.Components.Schemas)) {
+		t.Component(name)
+	}
+}
+
+// Component declares the component schema and returns its Go type name.
+func (t *GoTypes) Component(name string) string {
+	goName, ok := t.names[name]
+	if !ok {
+		return "any"
+	}
+	if _, declared := t.decls[goName]; declared {
+		return goName
+	}
+	t.decls[goName] = "" // guards against recursion
+
+	s := t.doc.Components.Schemas[name]
+	doc := fmt.Sprintf("// %s is the %s schema.\n", goName, name)
+	if s.Description != "" {
+		doc += "//\n" + Comment(s.Description)
+	}
+	t.decls[goName] = doc + t.declare(goName, s)
+	return goName
+}
+
+// declare returns the declaration of the named type of a schema.
+func (t *GoTypes) declare(name string, s *Schema) string {
+	if len(s.Enum) > 0 && s.Type.Has("string") {
+		var b strings.Builder
+		fmt.Fprintf(&b, "type %s string\n\n", name)
+		b.WriteString("const (\n")
+		for _, v := range s.Enum {
+			v, ok := v.(string)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "\t%s%s %s = %s\n", name, GoName(v), name, strconv.Quote(v))
+		}
+		b.WriteString(")\n")
+		return b.String()
+	}
+	if isStruct(s) {
+		return fmt.Sprintf("type %s %s\n", name, t.structOf(name, t.properties(s)))
+	}
+	return fmt.Sprintf("type %s %s\n", name, t.GoType(s, name+"Value"))
+}
+
+// GoType returns the Go type of a schema, declaring named types for inline
+// objects and enums with names derived from hint.
+func (t *GoTypes) GoType(s *Schema, hint string) string {
+	if s == nil {
+		return "any"
+	}
+	if ref, ok := SchemaRef(s.Ref); ok {
+		return t.Component(ref)
+	}
+	if s.Ref != "" {
+		return "any"
+	}
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return t.nullable(s, t.GoType(s.AllOf[0], hint))
+	}
+	if len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+		t.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	switch {
+	case isStruct(s):
+		name := t.unique(hint)
+		t.decls[name] = ""
+		t.decls[name] = fmt.Sprintf("// %s is an inline schema.\n", name) + fmt.Sprintf("type %s %s\n", name, t.structOf(name, t.properties(s)))
+		return t.nullable(s, name)
+	case s.Type.Has("array"):
+		return "[]" + t.GoType(s.Items, hint+"Item")
+	case s.Type.Has("object"):
+		if s.AdditionalProperties != nil {
+			return "map[string]" + t.GoType(s.AdditionalProperties, hint+"Value")
+		}
+		return "map[string]any"
+	case s.Type.Has("string"):
+		if len(s.Enum) > 0 {
+			name := t.unique(hint)
+			t.decls[name] = fmt.Sprintf("// %s is an inline enum.\n", name) + t.declare(name, s)
+			return t.nullable(s, name)
+		}
+		switch s.Format {
+		case "date-time":
+			t.imports["time"] = true
+			return t.nullable(s, "time.Time")
+		case "byte":
+			return "[]byte"
+		}
+		return t.nullable(s, "string")
+	case s.Type.Has("integer"):
+		switch s.Format {
+		case "int32":
+			return t.nullable(s, "int32")
+		case "int64":
+			return t.nullable(s, "int64")
+		}
+		return t.nullable(s, "int")
+	case s.Type.Has("number"):
+		if s.Format == "float" {
+			return t.nullable(s, "float32")
+		}
+		return t.nullable(s, "float64")
+	case s.Type.Has("boolean"):
+		return t.nullable(s, "bool")
+	}
+	return "any"
+}
+
+// nullable returns a pointer to typ if the schema allows null.
+func (t *GoTypes) nullable(s *Schema, typ string) string {
+	if s.Nullable || s.Type.Has("null") {
+		return "*" + typ
+	}
+	return typ
+}
+
+// isStruct reports whether a schema is an object with known properties.
+func isStruct(s *Schema) bool {
+	if len(s.Properties) > 0 {
+		return true
+	}
+	return len(s.AllOf) > 1
+}
+
+// properties returns the properties of an object schema, merging the
+// properties of the schemas it is composed of with allOf.
+func (t *GoTypes) properties(s *Schema) *Schema {
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	merged := &Schema{Properties: make(map[string]*Schema)}
+	for _, sub := range append(slices.Clone(s.AllOf), &Schema{Properties: s.Properties, Required: s.Required}) {
+		if ref, ok := SchemaRef(sub.Ref); ok {
+			sub = t.doc.Components.Schemas[ref]
+		}
+		if sub == nil {
+			continue
+		}
+		sub = t.properties(sub)
+		maps.Copy(merged.Properties, sub.Properties)
+		merged.Required = append(merged.Required, sub.Required...)
+	}
+	return merged
+}
+
+// structOf returns the struct type of an object schema.
+func (t *GoTypes) structOf(name string, s *Schema) string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	fields := make(map[string]bool)
+	for _, prop := range slices.Sorted(maps.Keys(s.Properties)) {
+		ps := s.Properties[prop]
+		field := GoName(prop)
+		for i := 2; fields[field]; i++ {
+			field = GoName(prop) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		required := slices.Contains(s.Required, prop)
+		typ := t.GoType(ps, name+field)
+		if !required && !strings.HasPrefix(typ, "*") && t.isStructType(ps) {
+			typ = "*" + typ
+		}
+		if ps.Description != "" {
+			b.WriteString(Comment(ps.Description))
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", field, typ, StructTag(prop, required, ps))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// isStructType reports whether the Go type of a schema is a struct.
+func (t *GoTypes) isStructType(s *Schema) bool {
+	if ref, ok := SchemaRef(s.Ref); ok {
+		s = t.doc.Components.Schemas[ref]
+	}
+	return s != nil && isStruct(s)
+}
+
+// Decls returns the declarations ordered by name.
+func (t *GoTypes) Decls() []string {
+	var decls []string
+	for _, name := range slices.Sorted(maps.Keys(t.decls)) {
+		decls = append(decls, t.decls[name])
+	}
+	return decls
+}
+
+// Imports returns the packages the declarations import.
+func (t *GoTypes) Imports() []string {
+	return slices.Sorted(maps.Keys(t.imports))
+}
+
+// StructTag returns the struct tag of a field of a property, i.e. the json
+// tag along with the validation tags of humus for its constraints.
+func StructTag(prop string, required bool, s *Schema) string {
+	jsonTag := prop
+	if !required {
+		jsonTag += ",omitempty"
+	}
+	tags := []string{"json:" + strconv.Quote(jsonTag)}
+	if required {
+		tags = append(tags, `required:"true"`)
+	}
+	if s != nil && s.Ref == "" {
+		tags = append(tags, constraintTags(s)...)
+	}
+
+	tag := strings.Join(tags, " ")
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+func constraintTags(s *Schema) []string {
+	var tags []string
+	number := func(key string, v *float64) {
+		if v != nil {
+			tags = append(tags, key+":"+strconv.Quote(strconv.FormatFloat(*v, 'f', -1, 64)))
+		}
+	}
+	integer := func(key string, v *int) {
+		if v != nil {
+			tags = append(tags, key+":"+strconv.Quote(strconv.Itoa(*v)))
+		}
+	}
+
+	number("minimum", s.Minimum)
+	switch {
+	case s.ExclusiveMinimum.Value != nil:
+		if s.Minimum == nil {
+			number("minimum", s.ExclusiveMinimum.Value)
+		}
+		number("exclusiveMinimum", s.ExclusiveMinimum.Value)
+	case s.ExclusiveMinimum.Exclusive && s.Minimum != nil:
+		number("exclusiveMinimum", s.Minimum)
+	}
+	number("maximum", s.Maximum)
+	switch {
+	case s.ExclusiveMaximum.Value != nil:
+		if s.Maximum == nil {
+			number("maximum", s.ExclusiveMaximum.Value)
+		}
+		number("exclusiveMaximum", s.ExclusiveMaximum.Value)
+	case s.ExclusiveMaximum.Exclusive && s.Maximum != nil:
+		number("exclusiveMaximum", s.Maximum)
+	}
+	number("multipleOf", s.MultipleOf)
+	integer("minLength", s.MinLength)
+	integer("maxLength", s.MaxLength)
+	if s.Pattern != "" {
+		tags = append(tags, "pattern:"+strconv.Quote(s.Pattern))
+	}
+	integer("minItems", s.MinItems)
+	integer("maxItems", s.MaxItems)
+
+	var enum []string
+	for _, v := range s.Enum {
+		enum = append(enum, fmt.Sprint(v))
+	}
+	if len(enum) > 0 {
+		tags = append(tags, "enum:"+strconv.Quote(strings.Join(enum, ",")))
+	}
+	return tags
+}
+
+// initialisms are words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "DNS": true, "EOF": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "JWT": true, "OK": true,
+	"SKU": true, "SQL": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// GoName converts a name of a document, e.g. a property or operation ID, into
+// an exported Go identifier.
+func GoName(s string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		r := []rune(w)
+		b.WriteRune(unicode.ToUpper(r[0]))
+		b.WriteString(string(r[1:]))
+	}
+	name := b.String()
+	if name == "" {
+		return ""
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// Comment formats text as a Go comment.
+func Comment(text string) string {
+	var b strings.Builder
+	for line := range strings.SplitSeq(strings.TrimSpace(text), "\n") {
+		b.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package openapi parses OpenAPI 3.x documents and generates Go declarations
+// of their schemas for the code generators of humus.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Document is an OpenAPI 3.x document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// Components holds the reusable objects of a document.
+type Components struct {
+	Schemas       map[string]*Schema      `json:"schemas"`
+	Parameters    map[string]*Parameter   `json:"parameters"`
+	RequestBodies map[string]*RequestBody `json:"requestBodies"`
+	Responses     map[string]*Response    `json:"responses"`
+}
+
+// PathItem holds the operations of a path.
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Put        *Operation   `json:"put"`
+	Post       *Operation   `json:"post"`
+	Delete     *Operation   `json:"delete"`
+	Options    *Operation   `json:"options"`
+	Head       *Operation   `json:"head"`
+	Patch      *Operation   `json:"patch"`
+	Trace      *Operation   `json:"trace"`
+}
+
+// Operation is an operation of a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Tags        []string             `json:"tags"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query, header or cookie parameter.
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Deprecated  bool    `json:"deprecated"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of a representation.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON Schema as used by OpenAPI 3.0 and 3.1.
+type Schema struct {
+	Ref         string `json:"$ref"`
+	Type        Types  `json:"type"`
+	Format      string `json:"format"`
+	Description string `json:"description"`
+	Nullable    bool   `json:"nullable"`
+	Deprecated  bool   `json:"deprecated"`
+	Enum        []any  `json:"enum"`
+	Default     any    `json:"default"`
+
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+
+	AllOf []*Schema `json:"allOf"`
+	AnyOf []*Schema `json:"anyOf"`
+	OneOf []*Schema `json:"oneOf"`
+
+	Minimum          *float64 `json:"minimum"`
+	Maximum          *float64 `json:"maximum"`
+	ExclusiveMinimum Bound    `json:"exclusiveMinimum"`
+	ExclusiveMaximum Bound    `json:"exclusiveMaximum"`
+	MultipleOf       *float64 `json:"multipleOf"`
+	MinLength        *int     `json:"minLength"`
+	MaxLength        *int     `json:"maxLength"`
+	Pattern          string   `json:"pattern"`
+	MinItems         *int     `json:"minItems"`
+	MaxItems         *int     `json:"maxItems"`
+}
+
+// Types is the type of a schema, which OpenAPI 3.1 allows to be a list.
+type Types []string
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (t *Types) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = Types{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Has reports whether typ is one of the types.
+func (t Types) Has(typ string) bool {
+	return slices.Contains(t, typ)
+}
+
+// Bound is an exclusive bound, which is a flag on the inclusive bound in
+// OpenAPI 3.0 and a number in OpenAPI 3.1.
+type Bound struct {
+	Exclusive bool
+	Value     *float64
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (b *Bound) UnmarshalJSON(data []byte) error {
+	var flag bool
+	if err := json.Unmarshal(data, &flag); err == nil {
+		b.Exclusive = flag
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	b.Exclusive = true
+	b.Value = &v
+	return nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. A boolean additionalProperties
+// is dropped, since it does not affect the Go type.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var flag bool
+	if err := json.Unmarshal(b, &flag); err == nil {
+		return nil
+	}
+	type schema Schema
+	return json.Unmarshal(b, (*schema)(s))
+}
+
+// Parse parses an OpenAPI 3.x document in JSON or YAML and resolves the
+// references to component parameters, request bodies and responses.
+func Parse(data []byte) (*Document, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("openapi: failed to parse document: %w", err)
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: failed to parse document: %w", err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: failed to parse document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("openapi: unsupported version %q", doc.OpenAPI)
+	}
+	if err := doc.resolve(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (d *Document) resolve() error {
+	for path, item := range d.Paths {
+		for i, p := range item.Parameters {
+			resolved, err := resolveRef(p, p.Ref, "parameters", d.Components.Parameters)
+			if err != nil {
+				return err
+			}
+			item.Parameters[i] = resolved
+		}
+		for _, op := range item.operations() {
+			for i, p := range op.Parameters {
+				resolved, err := resolveRef(p, p.Ref, "parameters", d.Components.Parameters)
+				if err != nil {
+					return err
+				}
+				op.Parameters[i] = resolved
+			}
+			if op.RequestBody != nil {
+				resolved, err := resolveRef(op.RequestBody, op.RequestBody.Ref, "requestBodies", d.Components.RequestBodies)
+				if err != nil {
+					return err
+				}
+				op.RequestBody = resolved
+			}
+			for code, resp := range op.Responses {
+				resolved, err := resolveRef(resp, resp.Ref, "responses", d.Components.Responses)
+				if err != nil {
+					return err
+				}
+				op.Responses[code] = resolved
+			}
+		}
+		d.Paths[path] = item
+	}
+	return nil
+}
+
+func resolveRef[T any](v *T, ref, kind string, components map[string]*T) (*T, error) {
+	if ref == "" {
+		return v, nil
+	}
+	name, ok := strings.CutPrefix(ref, "#/components/"+kind+"/")
+	if !ok {
+		return nil, fmt.Errorf("openapi: unsupported reference %q", ref)
+	}
+	resolved, ok := components[name]
+	if !ok {
+		return nil, fmt.Errorf("openapi: unknown reference %q", ref)
+	}
+	return resolved, nil
+}
+
+// SchemaRef returns the name of the component schema a reference refers to.
+func SchemaRef(ref string) (string, bool) {
+	return strings.CutPrefix(ref, "#/components/schemas/")
+}
+
+func (item PathItem) operations() map[string]*Operation {
+	ops := map[string]*Operation{
+		http.MethodGet:     item.Get,
+		http.MethodPut:     item.Put,
+		http.MethodPost:    item.Post,
+		http.MethodDelete:  item.Delete,
+		http.MethodOptions: item.Options,
+		http.MethodHead:    item.Head,
+		http.MethodPatch:   item.Patch,
+		http.MethodTrace:   item.Trace,
+	}
+	for method, op := range ops {
+		if op == nil {
+			delete(ops, method)
+		}
+	}
+	return ops
+}
+
+// Endpoint is an operation along with its method, path and parameters,
+// including the parameters shared by the operations of the path.
+type Endpoint struct {
+	Method     string
+	Path       string
+	Parameters []*Parameter
+	*Operation
+}
+
+var methodOrder = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodOptions, http.MethodTrace,
+}
+
+// Endpoints returns the operations of the document ordered by path and method.
+func (d *Document) Endpoints() []Endpoint {
+	var endpoints []Endpoint
+	for _, path := range slices.Sorted(maps.Keys(d.Paths)) {
+		item := d.Paths[path]
+		ops := item.operations()
+		for _, method := range methodOrder {
+			op, ok := ops[method]
+			if !ok {
+				continue
+			}
+			params := slices.Clone(op.Parameters)
+			for _, shared := range item.Parameters {
+				if !slices.ContainsFunc(params, func(p *Parameter) bool { return p.Name == shared.Name && p.In == shared.In }) {
+					params = append(params, shared)
+				}
+			}
+			endpoints = append(endpoints, Endpoint{Method: method, Path: path, Parameters: params, Operation: op})
+		}
+	}
+	return endpoints
+}
+
+// JSONRequestBody returns the schema of the JSON request body, if any.
+func (e Endpoint) JSONRequestBody() *Schema {
+	if e.RequestBody == nil {
+		return nil
+	}
+	return jsonSchema(e.RequestBody.Content)
+}
+
+// SuccessResponse returns the lowest successful status code of the operation
+// along with the schema of its JSON body, which is nil if the response has
+// no JSON body.
+func (e Endpoint) SuccessResponse() (string, *Schema) {
+	for _, code := range slices.Sorted(maps.Keys(e.Responses)) {
+		if strings.HasPrefix(code, "2") {
+			return code, jsonSchema(e.Responses[code].Content)
+		}
+	}
+	return "", nil
+}
+
+// jsonSchema returns the schema of the JSON media type of the content.
+func jsonSchema(content map[string]MediaType) *Schema {
+	if mt, ok := content["application/json"]; ok {
+		return mt.Schema
+	}
+	for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+		if strings.HasSuffix(mediaType, "+json") {
+			return content[mediaType].Schema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(`
+openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          $ref: '#/components/responses/Pet'
+    delete:
+      responses:
+        '204':
+          description: No Content
+components:
+  parameters:
+    PetID:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: string
+  responses:
+    Pet:
+      description: OK
+      content:
+        application/json:
+          schema:
+            type: [object, 'null']
+            properties:
+              weight:
+                type: number
+                exclusiveMinimum: 0
+`))
+	require.NoError(t, err)
+	require.Equal(t, "Pets", doc.Info.Title)
+
+	endpoints := doc.Endpoints()
+	require.Len(t, endpoints, 2)
+	require.Equal(t, http.MethodGet, endpoints[0].Method)
+	require.Equal(t, http.MethodDelete, endpoints[1].Method)
+	require.Equal(t, "petId", endpoints[0].Parameters[0].Name)
+
+	code, schema := endpoints[0].SuccessResponse()
+	require.Equal(t, "200", code)
+	require.True(t, schema.Type.Has("null"))
+	require.Equal(t, 0.0, *schema.Properties["weight"].ExclusiveMinimum.Value)
+
+	_, err = Parse([]byte(`swagger: "2.0"`))
+	require.Error(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	testCases := map[string]string{
+		"cartId":       "CartID",
+		"unit_price":   "UnitPrice",
+		"X-Request-ID": "XRequestID",
+		"getCart":      "GetCart",
+		"api url":      "APIURL",
+		"2fa":          "X2fa",
+		"HTTPServer":   "HTTPServer",
+	}
+	for in, want := range testCases {
+		require.Equal(t, want, GoName(in), in)
+	}
+}
+
+func TestStructTag(t *testing.T) {
+	minimum, maximum := 1.0, 10.0
+	minLength := 2
+
+	require.Equal(t,
+		"`json:\"quantity\" required:\"true\" minimum:\"1\" maximum:\"10\" exclusiveMaximum:\"10\"`",
+		StructTag("quantity", true, &Schema{Minimum: &minimum, Maximum: &maximum, ExclusiveMaximum: Bound{Exclusive: true}}),
+	)
+	require.Equal(t,
+		"`json:\"code,omitempty\" minLength:\"2\" pattern:\"^[a-z]+$\" enum:\"ab,cd\"`",
+		StructTag("code", false, &Schema{MinLength: &minLength, Pattern: "^[a-z]+$", Enum: []any{"ab", "cd"}}),
+	)
+	require.Equal(t,
+		"\"json:\\\"code,omitempty\\\" pattern:\\\"`\\\"\"",
+		StructTag("code", false, &Schema{Pattern: "`"}),
+	)
+}
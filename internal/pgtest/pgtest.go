@@ -0,0 +1,67 @@
+//go:build testcontainers
+
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package pgtest starts PostgreSQL containers for integration tests.
+package pgtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Start starts a Postgres container and returns a connection pool and cleanup function.
+func Start(t *testing.T) (db *pgxpool.Pool, cleanup func()) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "docker.io/library/postgres:17-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "humus",
+			"POSTGRES_PASSWORD": "humus",
+			"POSTGRES_DB":       "humus",
+		},
+		// Postgres restarts once after initializing the database.
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(60 * time.Second),
+	}
+
+	postgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err, "failed to start Postgres container")
+
+	host, err := postgresContainer.Host(ctx)
+	require.NoError(t, err, "failed to get Postgres host")
+	port, err := postgresContainer.MappedPort(ctx, "5432/tcp")
+	require.NoError(t, err, "failed to get Postgres port")
+
+	db, err = pgxpool.New(ctx, fmt.Sprintf("postgres://humus:humus@%s:%s/humus?sslmode=disable", host, port.Port()))
+	require.NoError(t, err, "failed to connect to Postgres")
+
+	cleanup = func() {
+		db.Close()
+
+		ctx := context.Background()
+		if err := postgresContainer.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate Postgres container: %v", err)
+		}
+	}
+
+	return db, cleanup
+}
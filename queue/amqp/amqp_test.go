@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func runRuntime(t *testing.T, rt Runtime) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rt.ProcessQueue(ctx)
+	}()
+
+	return func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}
+}
+
+func TestRuntime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should ack processed messages and requeue failures with at-least-once", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+		broker.send("orders", "ok", nil)
+		broker.send("orders", "flaky", nil)
+
+		var (
+			mu        sync.Mutex
+			processed []Message
+		)
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			AtLeastOnce("orders", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, msg)
+
+				if string(msg.Body) == "flaky" && !msg.Redelivered {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, broker.drained, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Len(t, processed, 3)
+		require.Equal(t, "ok", string(processed[0].Body))
+		require.Equal(t, "orders", processed[0].Queue)
+		require.Equal(t, "flaky", string(processed[1].Body))
+		require.False(t, processed[1].Redelivered)
+		require.Equal(t, "flaky", string(processed[2].Body))
+		require.True(t, processed[2].Redelivered)
+		require.Equal(t, 2, broker.acked)
+		require.Empty(t, broker.deadLettered)
+	})
+
+	t.Run("should dead letter failures if requeue is disabled", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+		broker.send("orders", "poison", nil)
+		broker.send("orders", "ok", nil)
+
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			RequeueOnFailure(false),
+			AtLeastOnce("orders", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				if string(msg.Body) == "poison" {
+					return errors.New("malformed message")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, broker.drained, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Equal(t, 1, broker.acked)
+		require.Len(t, broker.deadLettered, 1)
+		require.Equal(t, "poison", string(broker.deadLettered[0].publishing.Body))
+	})
+
+	t.Run("should ack messages before processing with at-most-once", func(t *testing.T) {
+		broker := newFakeBroker(t, "metrics")
+		broker.send("metrics", "fail", nil)
+		broker.send("metrics", "ok", nil)
+
+		var (
+			mu        sync.Mutex
+			processed []string
+		)
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			AtMostOnce("metrics", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, string(msg.Body))
+
+				if string(msg.Body) == "fail" {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(processed) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.True(t, broker.drained())
+		require.Equal(t, 2, broker.acked)
+		require.Empty(t, broker.deadLettered)
+	})
+
+	t.Run("should consume multiple queues with their own semantics", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders", "metrics")
+		broker.send("orders", "order", nil)
+		broker.send("metrics", "metric", nil)
+
+		msgCh := make(chan Message, 2)
+		processor := queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+			msgCh <- msg
+			return nil
+		})
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			AtLeastOnce("orders", processor),
+			AtMostOnce("metrics", processor),
+		)
+
+		stop := runRuntime(t, rt)
+		first, second := <-msgCh, <-msgCh
+		require.Eventually(t, broker.drained, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.ElementsMatch(t, []string{"orders", "metrics"}, []string{first.Queue, second.Queue})
+	})
+
+	t.Run("should not deliver more than the prefetch count", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+		for range 10 {
+			broker.send("orders", "msg", nil)
+		}
+
+		var (
+			inflight    atomic.Int32
+			maxInflight atomic.Int32
+			processed   atomic.Int32
+		)
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			Prefetch(2),
+			Concurrency(5),
+			AtLeastOnce("orders", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				n := inflight.Add(1)
+				defer inflight.Add(-1)
+				for {
+					m := maxInflight.Load()
+					if n <= m || maxInflight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+				processed.Add(1)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return processed.Load() == 10 && broker.drained()
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.LessOrEqual(t, maxInflight.Load(), int32(2))
+	})
+
+	t.Run("should link the propagated trace context", func(t *testing.T) {
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		broker := newFakeBroker(t, "orders")
+		broker.send("orders", "traced", amqp.Table{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		})
+
+		spanCtxCh := make(chan trace.SpanContext, 1)
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			AtLeastOnce("orders", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				carrier := tableCarrier(msg.Headers)
+				propagated := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+				spanCtxCh <- trace.SpanContextFromContext(propagated)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		spanCtx := <-spanCtxCh
+		stop()
+
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanCtx.TraceID().String())
+	})
+
+	t.Run("should return an error if the queue does not exist", func(t *testing.T) {
+		broker := newFakeBroker(t)
+
+		rt := NewRuntime(
+			"amqp://fake",
+			withFakeBroker(broker),
+			AtLeastOnce("missing", queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				return nil
+			})),
+		)
+
+		err := rt.ProcessQueue(t.Context())
+
+		var amqpErr *amqp.Error
+		require.ErrorAs(t, err, &amqpErr)
+		require.Equal(t, amqp.NotFound, amqpErr.Code)
+	})
+
+	t.Run("should panic if no queue is configured", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewRuntime("amqp://fake")
+		})
+	})
+}
+
+func TestDeliveryConsumer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return ErrChannelClosed if the broker closes the channel", func(t *testing.T) {
+		deliveries := make(chan amqp.Delivery)
+		close(deliveries)
+
+		c := &deliveryConsumer{deliveries: deliveries}
+		_, err := c.Consume(t.Context())
+		require.ErrorIs(t, err, ErrChannelClosed)
+	})
+}
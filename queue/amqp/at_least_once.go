@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// AtLeastOnce configures the AMQP runtime to process messages from the specified queue
+// with at-least-once delivery semantics (process before ack).
+//
+// Messages are only acknowledged once they have been successfully processed.
+// Messages which fail processing are negatively acknowledged and, depending on
+// [RequeueOnFailure], requeued for redelivery or dead lettered. Messages which are
+// still unacknowledged when the runtime stops are requeued by the broker.
+// Processors must therefore be idempotent.
+func AtLeastOnce(queue string, processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.queues[queue] = atLeastOnceOrchestrator{processor: processor}
+	}
+}
+
+type atLeastOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atLeastOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atLeastOnceRuntime{
+		log:         orc.log,
+		queueName:   orc.queueName,
+		consumer:    orc.consumer,
+		concurrency: orc.concurrency,
+		requeue:     orc.requeue,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			queueName:         orc.queueName,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atLeastOnceRuntime struct {
+	log               *slog.Logger
+	queueName         string
+	consumer          queue.Consumer[amqp.Delivery]
+	processor         messageProcessor
+	concurrency       int
+	requeue           bool
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atLeastOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	deliveryCh := make(chan amqp.Delivery)
+	p.Go(consumeDeliveries(rt.log, rt.consumer, deliveryCh))
+
+	p.Go(rt.processDeliveries(deliveryCh))
+
+	return p.Wait()
+}
+
+func (rt atLeastOnceRuntime) processDeliveries(deliveryCh <-chan amqp.Delivery) func(context.Context) error {
+	return func(ctx context.Context) error {
+		p := pool.New().WithMaxGoroutines(rt.concurrency)
+
+		for d := range deliveryCh {
+			p.Go(func() {
+				rt.processDelivery(ctx, d)
+			})
+		}
+
+		p.Wait()
+		return nil
+	}
+}
+
+func (rt atLeastOnceRuntime) processDelivery(ctx context.Context, d amqp.Delivery) {
+	err := rt.processor.process(ctx, d)
+	if err != nil {
+		err = d.Nack(false, rt.requeue)
+		if err != nil {
+			rt.log.ErrorContext(
+				ctx,
+				"failed to nack amqp message",
+				RoutingKeyAttr(d.RoutingKey),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	err = d.Ack(false)
+	if err != nil {
+		rt.log.ErrorContext(
+			ctx,
+			"failed to ack amqp message",
+			RoutingKeyAttr(d.RoutingKey),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	rt.messagesCommitted.Add(ctx, 1, metric.WithAttributes(
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingDestinationName(rt.queueName),
+	))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// AtMostOnce configures the AMQP runtime to process messages from the specified queue
+// with at-most-once delivery semantics (ack before process).
+//
+// Messages are acknowledged as soon as they are received, so a message which
+// fails processing is lost. Messages which fail to be acknowledged are not
+// processed.
+func AtMostOnce(queue string, processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.queues[queue] = atMostOnceOrchestrator{processor: processor}
+	}
+}
+
+type atMostOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atMostOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atMostOnceRuntime{
+		log:         orc.log,
+		queueName:   orc.queueName,
+		consumer:    orc.consumer,
+		concurrency: orc.concurrency,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			queueName:         orc.queueName,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atMostOnceRuntime struct {
+	log               *slog.Logger
+	queueName         string
+	consumer          queue.Consumer[amqp.Delivery]
+	processor         messageProcessor
+	concurrency       int
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atMostOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	deliveryCh := make(chan amqp.Delivery)
+	p.Go(consumeDeliveries(rt.log, rt.consumer, deliveryCh))
+
+	ackedCh := make(chan amqp.Delivery)
+	p.Go(rt.acknowledgeDeliveries(ackedCh, deliveryCh))
+
+	p.Go(rt.processDeliveries(ackedCh))
+
+	return p.Wait()
+}
+
+func (rt atMostOnceRuntime) acknowledgeDeliveries(ackedCh chan<- amqp.Delivery, deliveryCh <-chan amqp.Delivery) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(ackedCh)
+
+		for d := range deliveryCh {
+			err := d.Ack(false)
+			if err != nil {
+				rt.log.ErrorContext(
+					ctx,
+					"failed to ack amqp message",
+					RoutingKeyAttr(d.RoutingKey),
+					slog.Any("error", err),
+				)
+				continue
+			}
+
+			rt.messagesCommitted.Add(ctx, 1, metric.WithAttributes(
+				semconv.MessagingSystemRabbitMQ,
+				semconv.MessagingDestinationName(rt.queueName),
+			))
+
+			select {
+			case <-ctx.Done():
+				rt.log.WarnContext(
+					ctx,
+					"context cancelled after acknowledging messages",
+					slog.Any("error", ctx.Err()),
+				)
+				return nil
+			case ackedCh <- d:
+			}
+		}
+
+		return nil
+	}
+}
+
+func (rt atMostOnceRuntime) processDeliveries(ackedCh <-chan amqp.Delivery) func(context.Context) error {
+	return func(ctx context.Context) error {
+		p := pool.New().WithMaxGoroutines(rt.concurrency)
+
+		for d := range ackedCh {
+			p.Go(func() {
+				rt.processor.process(ctx, d)
+			})
+		}
+
+		p.Wait()
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// channel is the subset of [amqp.Channel] used by the runtime and publisher.
+type channel interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Confirm(noWait bool) error
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Close() error
+}
+
+type connection interface {
+	channel() (channel, error)
+	Close() error
+}
+
+type dialer func(url string, cfg *tls.Config) (connection, error)
+
+type amqpConnection struct {
+	*amqp.Connection
+}
+
+func (c amqpConnection) channel() (channel, error) {
+	return c.Channel()
+}
+
+func dialAMQP(url string, cfg *tls.Config) (connection, error) {
+	conn, err := amqp.DialConfig(url, amqp.Config{
+		TLSClientConfig: cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return amqpConnection{Connection: conn}, nil
+}
+
+// ErrChannelClosed is returned when the broker closes the channel a queue
+// is being consumed on, e.g. because the connection was lost or the queue
+// was deleted.
+var ErrChannelClosed = errors.New("amqp: channel closed")
+
+type deliveryConsumer struct {
+	deliveries <-chan amqp.Delivery
+}
+
+func (c *deliveryConsumer) Consume(ctx context.Context) (amqp.Delivery, error) {
+	select {
+	case <-ctx.Done():
+		return amqp.Delivery{}, ctx.Err()
+	case d, ok := <-c.deliveries:
+		if ok {
+			return d, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return amqp.Delivery{}, err
+		}
+		return amqp.Delivery{}, ErrChannelClosed
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"sync"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type fakeMessage struct {
+	exchange    string
+	routingKey  string
+	publishing  amqp.Publishing
+	redelivered bool
+}
+
+// fakeBroker is an in-process AMQP broker which routes messages published on the
+// default exchange to the queue named by the routing key. It implements enough of
+// the channel semantics (prefetch, manual acks, requeue, confirms) to exercise
+// the runtime and publisher.
+type fakeBroker struct {
+	mu           sync.Mutex
+	changed      chan struct{}
+	queues       map[string][]fakeMessage
+	deadLettered []fakeMessage
+	acked        int
+	unacked      int
+	nackPublish  bool
+	holdConfirms bool
+	confirmed    int
+}
+
+func newFakeBroker(t *testing.T, queues ...string) *fakeBroker {
+	t.Helper()
+
+	b := &fakeBroker{
+		changed: make(chan struct{}),
+		queues:  make(map[string][]fakeMessage),
+	}
+	for _, q := range queues {
+		b.queues[q] = nil
+	}
+	return b
+}
+
+// notify wakes up all consumers. The caller must hold b.mu.
+func (b *fakeBroker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// releaseConfirms delivers the publisher confirms held back by holdConfirms.
+func (b *fakeBroker) releaseConfirms() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.holdConfirms = false
+	b.notify()
+}
+
+// confirmsDelivered returns the number of publisher confirms received by publishers.
+func (b *fakeBroker) confirmsDelivered() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.confirmed
+}
+
+func (b *fakeBroker) send(queue string, body string, headers amqp.Table) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.queues[queue] = append(b.queues[queue], fakeMessage{
+		routingKey: queue,
+		publishing: amqp.Publishing{Body: []byte(body), Headers: headers},
+	})
+	b.notify()
+}
+
+// drained reports whether every message has been delivered and settled.
+func (b *fakeBroker) drained() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msgs := range b.queues {
+		if len(msgs) > 0 {
+			return false
+		}
+	}
+	return b.unacked == 0
+}
+
+func (b *fakeBroker) ready(queue string) []fakeMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]fakeMessage(nil), b.queues[queue]...)
+}
+
+func (b *fakeBroker) dial(url string, cfg *tls.Config) (connection, error) {
+	return &fakeConnection{broker: b}, nil
+}
+
+func withFakeBroker(b *fakeBroker) Option {
+	return func(o *Options) {
+		o.dial = b.dial
+	}
+}
+
+func publisherWithFakeBroker(b *fakeBroker) PublisherOption {
+	return func(po *PublisherOptions) {
+		po.dial = b.dial
+	}
+}
+
+type fakeConnection struct {
+	broker *fakeBroker
+
+	mu       sync.Mutex
+	channels []*fakeChannel
+}
+
+func (c *fakeConnection) channel() (channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := &fakeChannel{
+		broker:   c.broker,
+		done:     make(chan struct{}),
+		inflight: make(map[uint64]fakeDelivery),
+	}
+	c.channels = append(c.channels, ch)
+	return ch, nil
+}
+
+func (c *fakeConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ch := range c.channels {
+		ch.Close()
+	}
+	return nil
+}
+
+type fakeDelivery struct {
+	queue string
+	msg   fakeMessage
+}
+
+type fakeChannel struct {
+	broker *fakeBroker
+
+	done chan struct{}
+
+	// guarded by broker.mu
+	closed      bool
+	prefetch    int
+	deliveryTag uint64
+	inflight    map[uint64]fakeDelivery
+	publishSeq  uint64
+	confirmMode bool
+	confirms    chan amqp.Confirmation
+	outbox      []amqp.Confirmation
+}
+
+func (ch *fakeChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	ch.broker.mu.Lock()
+	defer ch.broker.mu.Unlock()
+
+	ch.prefetch = prefetchCount
+	return nil
+}
+
+func (ch *fakeChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	b := ch.broker
+	b.mu.Lock()
+	_, exists := b.queues[queue]
+	b.mu.Unlock()
+	if !exists {
+		return nil, &amqp.Error{Code: amqp.NotFound, Reason: "NOT_FOUND - no queue '" + queue + "'"}
+	}
+
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+
+		for {
+			b.mu.Lock()
+			if ch.closed {
+				b.mu.Unlock()
+				return
+			}
+
+			changed := b.changed
+			msgs := b.queues[queue]
+			if len(msgs) == 0 || ch.prefetch > 0 && len(ch.inflight) >= ch.prefetch {
+				b.mu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+				}
+				continue
+			}
+
+			msg := msgs[0]
+			b.queues[queue] = msgs[1:]
+			ch.deliveryTag++
+			tag := ch.deliveryTag
+			ch.inflight[tag] = fakeDelivery{queue: queue, msg: msg}
+			b.unacked++
+			b.mu.Unlock()
+
+			d := amqp.Delivery{
+				Acknowledger:  ch,
+				Headers:       msg.publishing.Headers,
+				ContentType:   msg.publishing.ContentType,
+				CorrelationId: msg.publishing.CorrelationId,
+				MessageId:     msg.publishing.MessageId,
+				Timestamp:     msg.publishing.Timestamp,
+				ConsumerTag:   consumer,
+				DeliveryTag:   tag,
+				Redelivered:   msg.redelivered,
+				Exchange:      msg.exchange,
+				RoutingKey:    msg.routingKey,
+				Body:          msg.publishing.Body,
+			}
+
+			select {
+			case <-ctx.Done():
+				// Undelivered messages are returned to the queue.
+				ch.Nack(tag, false, true)
+				return
+			case deliveries <- d:
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+func (ch *fakeChannel) Ack(tag uint64, multiple bool) error {
+	b := ch.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := ch.inflight[tag]; !ok {
+		return amqp.ErrClosed
+	}
+	delete(ch.inflight, tag)
+	b.unacked--
+	b.acked++
+	b.notify()
+	return nil
+}
+
+func (ch *fakeChannel) Nack(tag uint64, multiple bool, requeue bool) error {
+	b := ch.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return ch.nack(tag, requeue)
+}
+
+// nack settles the delivery. The caller must hold broker.mu.
+func (ch *fakeChannel) nack(tag uint64, requeue bool) error {
+	b := ch.broker
+
+	d, ok := ch.inflight[tag]
+	if !ok {
+		return amqp.ErrClosed
+	}
+	delete(ch.inflight, tag)
+	b.unacked--
+
+	d.msg.redelivered = true
+	if requeue {
+		b.queues[d.queue] = append([]fakeMessage{d.msg}, b.queues[d.queue]...)
+	} else {
+		b.deadLettered = append(b.deadLettered, d.msg)
+	}
+	b.notify()
+	return nil
+}
+
+func (ch *fakeChannel) Reject(tag uint64, requeue bool) error {
+	return ch.Nack(tag, false, requeue)
+}
+
+func (ch *fakeChannel) Confirm(noWait bool) error {
+	ch.broker.mu.Lock()
+	defer ch.broker.mu.Unlock()
+
+	ch.confirmMode = true
+	return nil
+}
+
+func (ch *fakeChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	ch.broker.mu.Lock()
+	defer ch.broker.mu.Unlock()
+
+	ch.confirms = confirm
+	go ch.deliverConfirms(confirm)
+	return confirm
+}
+
+// deliverConfirms delivers publisher confirms in order, like the connection
+// reader of a real client, so it blocks until each confirm is received.
+func (ch *fakeChannel) deliverConfirms(confirms chan amqp.Confirmation) {
+	defer close(confirms)
+
+	b := ch.broker
+	for {
+		b.mu.Lock()
+		if ch.closed {
+			b.mu.Unlock()
+			return
+		}
+
+		changed := b.changed
+		if len(ch.outbox) == 0 || b.holdConfirms {
+			b.mu.Unlock()
+			<-changed
+			continue
+		}
+
+		confirm := ch.outbox[0]
+		ch.outbox = ch.outbox[1:]
+		b.mu.Unlock()
+
+		select {
+		case <-ch.done:
+			return
+		case confirms <- confirm:
+		}
+
+		b.mu.Lock()
+		b.confirmed++
+		b.mu.Unlock()
+	}
+}
+
+func (ch *fakeChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	b := ch.broker
+	b.mu.Lock()
+	if ch.closed {
+		b.mu.Unlock()
+		return amqp.ErrClosed
+	}
+	if exchange != "" {
+		b.mu.Unlock()
+		return errors.New("fake broker only supports the default exchange")
+	}
+
+	ack := !b.nackPublish
+	if ack {
+		b.queues[key] = append(b.queues[key], fakeMessage{
+			exchange:   exchange,
+			routingKey: key,
+			publishing: msg,
+		})
+		b.notify()
+	}
+
+	ch.publishSeq++
+	if ch.confirmMode && ch.confirms != nil {
+		ch.outbox = append(ch.outbox, amqp.Confirmation{DeliveryTag: ch.publishSeq, Ack: ack})
+		b.notify()
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+func (ch *fakeChannel) Close() error {
+	b := ch.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch.closed {
+		return nil
+	}
+	ch.closed = true
+
+	// Unacknowledged deliveries are requeued when a channel closes.
+	for tag := range ch.inflight {
+		ch.nack(tag, true)
+	}
+	close(ch.done)
+	b.notify()
+	return nil
+}
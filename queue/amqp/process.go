@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func consumeDeliveries(log *slog.Logger, consumer queue.Consumer[amqp.Delivery], deliveryCh chan<- amqp.Delivery) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(deliveryCh)
+
+		for {
+			d, err := consumer.Consume(ctx)
+			if errors.Is(err, queue.ErrEndOfQueue) {
+				log.InfoContext(ctx, "encountered end of queue")
+				return nil
+			}
+			if err != nil && ctx.Err() != nil {
+				log.WarnContext(ctx, "context cancelled while consuming messages")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				log.WarnContext(ctx, "context cancelled while consuming messages")
+				// The broker requeues deliveries which are never acknowledged.
+				return nil
+			case deliveryCh <- d:
+			}
+		}
+	}
+}
+
+type messageProcessor struct {
+	log               *slog.Logger
+	tracer            trace.Tracer
+	queueName         string
+	processor         queue.Processor[Message]
+	messagesProcessed metric.Int64Counter
+}
+
+func (mp messageProcessor) process(ctx context.Context, d amqp.Delivery) error {
+	msg := toMessage(mp.queueName, d)
+
+	queueAttr := semconv.MessagingDestinationName(mp.queueName)
+	spanOpts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeProcess,
+			queueAttr,
+			semconv.MessagingRabbitMQDestinationRoutingKey(d.RoutingKey),
+			semconv.MessagingRabbitMQMessageDeliveryTag(int(d.DeliveryTag)),
+			semconv.MessagingMessageID(d.MessageId),
+		),
+	}
+
+	propagated := otel.GetTextMapPropagator().Extract(context.Background(), tableCarrier(d.Headers))
+	if s := trace.SpanContextFromContext(propagated); s.IsValid() {
+		spanOpts = append(spanOpts, trace.WithLinks(trace.Link{SpanContext: s}))
+	}
+
+	spanCtx, span := mp.tracer.Start(ctx, "process "+mp.queueName, spanOpts...)
+	defer span.End()
+
+	err := mp.processor.Process(spanCtx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		mp.log.ErrorContext(
+			spanCtx,
+			"failed to process amqp message",
+			RoutingKeyAttr(d.RoutingKey),
+			slog.Bool("messaging.rabbitmq.redelivered", d.Redelivered),
+			slog.Any("error", err),
+		)
+	}
+
+	mp.messagesProcessed.Add(spanCtx, 1, metric.WithAttributes(
+		semconv.MessagingSystemRabbitMQ,
+		queueAttr,
+		attribute.String("messaging.process.status", processStatus(err)),
+	))
+
+	return err
+}
+
+func processStatus(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func toMessage(queueName string, d amqp.Delivery) Message {
+	return Message{
+		Queue:         queueName,
+		Exchange:      d.Exchange,
+		RoutingKey:    d.RoutingKey,
+		Body:          d.Body,
+		Headers:       d.Headers,
+		ContentType:   d.ContentType,
+		CorrelationID: d.CorrelationId,
+		MessageID:     d.MessageId,
+		Timestamp:     d.Timestamp,
+		Redelivered:   d.Redelivered,
+	}
+}
+
+// tableCarrier adapts AMQP headers to a [propagation.TextMapCarrier].
+type tableCarrier amqp.Table
+
+func (c tableCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (c tableCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c tableCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
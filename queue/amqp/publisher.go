@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrNacked is returned by [Publisher.Publish] when the broker negatively
+// acknowledges a published message, e.g. because it could not be persisted.
+var ErrNacked = errors.New("amqp: message was nacked by the broker")
+
+// Publishing represents a message to be published with a [Publisher].
+type Publishing struct {
+	Body          []byte
+	Headers       map[string]any
+	ContentType   string
+	CorrelationID string
+	MessageID     string
+
+	// Persistent marks the message to be persisted to disk by the broker
+	// so it survives a broker restart when routed to a durable queue.
+	Persistent bool
+}
+
+// PublisherOptions represents configuration options for a [Publisher].
+type PublisherOptions struct {
+	tlsConfig *tls.Config
+	dial      dialer
+}
+
+// PublisherOption defines a function type for configuring [Publisher] options.
+type PublisherOption func(*PublisherOptions)
+
+// PublisherTLS configures TLS/mTLS for secure connections to the broker.
+// The broker URL should use the amqps scheme.
+func PublisherTLS(cfg *tls.Config) PublisherOption {
+	return func(po *PublisherOptions) {
+		po.tlsConfig = cfg
+	}
+}
+
+// Publisher publishes messages to an AMQP broker with publisher confirms enabled,
+// so [Publisher.Publish] only returns once the broker has taken responsibility
+// for the message. Trace context is propagated through the message headers.
+//
+// A Publisher is safe for concurrent use, however publishes are serialized
+// on a single channel. Confirms are received in the background, so publishes
+// abandoned due to context cancellation never block the connection.
+type Publisher struct {
+	tracer trace.Tracer
+	conn   connection
+
+	mu  sync.Mutex
+	ch  channel
+	seq uint64
+
+	waitersMu sync.Mutex
+	waiters   map[uint64]chan amqp.Confirmation
+	closed    bool
+}
+
+// NewPublisher connects to the broker at the given URL and opens a channel in confirm mode.
+func NewPublisher(url string, opts ...PublisherOption) (*Publisher, error) {
+	cfg := &PublisherOptions{
+		dial: dialAMQP,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	conn, err := cfg.dial(url, cfg.tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("amqp: failed to connect: %w", err)
+	}
+
+	ch, err := conn.channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp: failed to open channel: %w", err)
+	}
+
+	err = ch.Confirm(false)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp: failed to enable publisher confirms: %w", err)
+	}
+
+	p := &Publisher{
+		tracer:  tracer(),
+		conn:    conn,
+		ch:      ch,
+		waiters: make(map[uint64]chan amqp.Confirmation),
+	}
+	go p.receiveConfirms(ch.NotifyPublish(make(chan amqp.Confirmation, 1)))
+	return p, nil
+}
+
+// receiveConfirms hands every confirm to the publish waiting for it. Confirms
+// must always be received, otherwise the connection blocks delivering them.
+func (p *Publisher) receiveConfirms(confirms <-chan amqp.Confirmation) {
+	for confirm := range confirms {
+		p.waitersMu.Lock()
+		waiter, ok := p.waiters[confirm.DeliveryTag]
+		delete(p.waiters, confirm.DeliveryTag)
+		p.waitersMu.Unlock()
+
+		// Publishes abandoned due to context cancellation no longer wait.
+		if ok {
+			waiter <- confirm
+		}
+	}
+
+	p.waitersMu.Lock()
+	defer p.waitersMu.Unlock()
+
+	p.closed = true
+	for tag, waiter := range p.waiters {
+		close(waiter)
+		delete(p.waiters, tag)
+	}
+}
+
+// Publish publishes the message to the exchange with the given routing key and
+// waits for the broker to confirm it. Use the empty exchange name to publish
+// directly to the queue named by the routing key.
+func (p *Publisher) Publish(ctx context.Context, exchange, routingKey string, msg Publishing) error {
+	destination := exchange
+	if destination == "" {
+		destination = routingKey
+	}
+
+	spanCtx, span := p.tracer.Start(
+		ctx,
+		"send "+destination,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(destination),
+			semconv.MessagingRabbitMQDestinationRoutingKey(routingKey),
+		),
+	)
+	defer span.End()
+
+	headers := make(amqp.Table, len(msg.Headers))
+	maps.Copy(headers, msg.Headers)
+	otel.GetTextMapPropagator().Inject(spanCtx, tableCarrier(headers))
+
+	deliveryMode := amqp.Transient
+	if msg.Persistent {
+		deliveryMode = amqp.Persistent
+	}
+
+	err := p.publish(spanCtx, exchange, routingKey, amqp.Publishing{
+		Headers:       headers,
+		ContentType:   msg.ContentType,
+		CorrelationId: msg.CorrelationID,
+		MessageId:     msg.MessageID,
+		DeliveryMode:  deliveryMode,
+		Body:          msg.Body,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (p *Publisher) publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	tag, waiter, err := p.send(ctx, exchange, routingKey, msg)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		p.abandon(tag)
+		return ctx.Err()
+	case confirm, ok := <-waiter:
+		if !ok {
+			return ErrChannelClosed
+		}
+		if !confirm.Ack {
+			return ErrNacked
+		}
+		return nil
+	}
+}
+
+// send publishes the message and returns its delivery tag along with the
+// channel its confirm is delivered on.
+func (p *Publisher) send(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) (uint64, chan amqp.Confirmation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The waiter is registered before publishing since the confirm may
+	// arrive before PublishWithContext returns.
+	tag := p.seq + 1
+	waiter := make(chan amqp.Confirmation, 1)
+
+	p.waitersMu.Lock()
+	if p.closed {
+		p.waitersMu.Unlock()
+		return 0, nil, ErrChannelClosed
+	}
+	p.waiters[tag] = waiter
+	p.waitersMu.Unlock()
+
+	err := p.ch.PublishWithContext(ctx, exchange, routingKey, false, false, msg)
+	if err != nil {
+		p.abandon(tag)
+		return 0, nil, fmt.Errorf("amqp: failed to publish message: %w", err)
+	}
+	p.seq++
+	return tag, waiter, nil
+}
+
+// abandon stops waiting for the confirm of the given delivery tag.
+func (p *Publisher) abandon(tag uint64) {
+	p.waitersMu.Lock()
+	defer p.waitersMu.Unlock()
+
+	delete(p.waiters, tag)
+}
+
+// Close closes the underlying channel and connection.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return errors.Join(p.ch.Close(), p.conn.Close())
+}
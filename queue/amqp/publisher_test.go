@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	t.Run("should publish the message once confirmed by the broker", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+
+		p, err := NewPublisher("amqp://fake", publisherWithFakeBroker(broker))
+		require.NoError(t, err)
+		defer p.Close()
+
+		headers := map[string]any{"tenant": "acme"}
+		err = p.Publish(t.Context(), "", "orders", Publishing{
+			Body:        []byte("hello"),
+			Headers:     headers,
+			ContentType: "text/plain",
+			MessageID:   "1",
+			Persistent:  true,
+		})
+		require.NoError(t, err)
+
+		msgs := broker.ready("orders")
+		require.Len(t, msgs, 1)
+
+		pub := msgs[0].publishing
+		require.Equal(t, "hello", string(pub.Body))
+		require.Equal(t, "text/plain", pub.ContentType)
+		require.Equal(t, "1", pub.MessageId)
+		require.Equal(t, amqp.Persistent, pub.DeliveryMode)
+		require.Equal(t, "acme", pub.Headers["tenant"])
+
+		// The caller's headers should not be modified by trace propagation.
+		require.Len(t, headers, 1)
+	})
+
+	t.Run("should inject the trace context into the headers", func(t *testing.T) {
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		tp := sdktrace.NewTracerProvider()
+		otel.SetTracerProvider(tp)
+		defer tp.Shutdown(t.Context())
+
+		broker := newFakeBroker(t, "orders")
+
+		p, err := NewPublisher("amqp://fake", publisherWithFakeBroker(broker))
+		require.NoError(t, err)
+		defer p.Close()
+
+		err = p.Publish(t.Context(), "", "orders", Publishing{Body: []byte("traced")})
+		require.NoError(t, err)
+
+		msgs := broker.ready("orders")
+		require.Len(t, msgs, 1)
+		require.Contains(t, msgs[0].publishing.Headers, "traceparent")
+	})
+
+	t.Run("should return ErrNacked if the broker rejects the message", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+		broker.nackPublish = true
+
+		p, err := NewPublisher("amqp://fake", publisherWithFakeBroker(broker))
+		require.NoError(t, err)
+		defer p.Close()
+
+		err = p.Publish(t.Context(), "", "orders", Publishing{Body: []byte("hello")})
+		require.ErrorIs(t, err, ErrNacked)
+		require.Empty(t, broker.ready("orders"))
+	})
+
+	t.Run("should confirm consecutive publishes", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+
+		p, err := NewPublisher("amqp://fake", publisherWithFakeBroker(broker))
+		require.NoError(t, err)
+		defer p.Close()
+
+		for range 3 {
+			err = p.Publish(t.Context(), "", "orders", Publishing{Body: []byte("msg")})
+			require.NoError(t, err)
+		}
+		require.Len(t, broker.ready("orders"), 3)
+	})
+	t.Run("should keep publishing after publishes are abandoned", func(t *testing.T) {
+		broker := newFakeBroker(t, "orders")
+		broker.holdConfirms = true
+
+		p, err := NewPublisher("amqp://fake", publisherWithFakeBroker(broker))
+		require.NoError(t, err)
+		defer p.Close()
+
+		for range 2 {
+			ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+			err = p.Publish(ctx, "", "orders", Publishing{Body: []byte("abandoned")})
+			cancel()
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+
+		// The confirms of abandoned publishes must be received even though
+		// nobody is publishing, otherwise the connection is blocked.
+		broker.releaseConfirms()
+		require.Eventually(t, func() bool {
+			return broker.confirmsDelivered() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- p.Publish(t.Context(), "", "orders", Publishing{Body: []byte("msg")})
+		}()
+
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("publish blocked by abandoned confirms")
+		}
+		require.Len(t, broker.ready("orders"), 3)
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package amqp
+
+import "log/slog"
+
+// QueueAttr returns a slog attribute for the AMQP queue name.
+func QueueAttr(name string) slog.Attr {
+	return slog.String("messaging.destination.name", name)
+}
+
+// ExchangeAttr returns a slog attribute for the AMQP exchange name.
+func ExchangeAttr(name string) slog.Attr {
+	return slog.String("messaging.rabbitmq.exchange", name)
+}
+
+// RoutingKeyAttr returns a slog attribute for the AMQP routing key.
+func RoutingKeyAttr(key string) slog.Attr {
+	return slog.String("messaging.rabbitmq.destination.routing_key", key)
+}
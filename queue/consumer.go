@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// ChannelConsumer is a [Consumer] which receives messages from a channel.
+// It returns [ErrEndOfQueue] once the channel has been closed and drained.
+type ChannelConsumer[T any] struct {
+	ch <-chan T
+}
+
+// NewChannelConsumer initializes a [ChannelConsumer] for the given channel.
+func NewChannelConsumer[T any](ch <-chan T) *ChannelConsumer[T] {
+	return &ChannelConsumer[T]{ch: ch}
+}
+
+// Consume implements the [Consumer] interface.
+func (c *ChannelConsumer[T]) Consume(ctx context.Context) (T, error) {
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case t, ok := <-c.ch:
+		if !ok {
+			return zero, ErrEndOfQueue
+		}
+		return t, nil
+	}
+}
+
+// SliceConsumer is a [Consumer] which returns messages from an in-memory slice,
+// in order. It returns [ErrEndOfQueue] once every message has been consumed.
+// It is safe for concurrent use.
+type SliceConsumer[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+// NewSliceConsumer initializes a [SliceConsumer] for the given messages.
+func NewSliceConsumer[T any](items ...T) *SliceConsumer[T] {
+	return &SliceConsumer[T]{items: items}
+}
+
+// Consume implements the [Consumer] interface.
+func (c *SliceConsumer[T]) Consume(ctx context.Context) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.items) == 0 {
+		return zero, ErrEndOfQueue
+	}
+
+	t := c.items[0]
+	c.items[0] = zero
+	c.items = c.items[1:]
+	return t, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelConsumer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return messages until the channel is closed", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+		close(ch)
+
+		c := NewChannelConsumer(ch)
+
+		msg, err := c.Consume(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, 1, msg)
+
+		msg, err = c.Consume(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, 2, msg)
+
+		_, err = c.Consume(t.Context())
+		require.ErrorIs(t, err, ErrEndOfQueue)
+	})
+
+	t.Run("should return the context error if cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		c := NewChannelConsumer(make(chan int))
+
+		_, err := c.Consume(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestSliceConsumer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return messages in order", func(t *testing.T) {
+		c := NewSliceConsumer("a", "b")
+
+		msg, err := c.Consume(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, "a", msg)
+
+		msg, err = c.Consume(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, "b", msg)
+
+		_, err = c.Consume(t.Context())
+		require.ErrorIs(t, err, ErrEndOfQueue)
+	})
+
+	t.Run("should return the context error if cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		c := NewSliceConsumer("a")
+
+		_, err := c.Consume(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
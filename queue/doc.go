@@ -13,4 +13,20 @@
 //
 // [Runtime] implementations orchestrate these three phases. When a [Consumer]
 // returns [ErrEndOfQueue], it signals the [Runtime] to shut down gracefully.
+//
+// # Generic Runtime
+//
+// [NewRuntime] provides a [Runtime] for any message source, so non-Kafka queues
+// share the same delivery semantics and instrumentation:
+//
+//	runtime := queue.NewRuntime(
+//	    queue.NewChannelConsumer(msgs),
+//	    processor,
+//	    acknowledger,
+//	    queue.AtLeastOnce(),
+//	    queue.Concurrency(4),
+//	)
+//
+// [NewChannelConsumer] and [NewSliceConsumer] adapt channels and in-memory
+// slices into [Consumer]s which return [ErrEndOfQueue] once exhausted.
 package queue
@@ -188,23 +188,6 @@ func (loop eventLoop) tick(ctx context.Context) error {
 	}
 }
 
-type channelConsumer struct {
-	fetches <-chan fetch
-}
-
-func (c *channelConsumer) Consume(ctx context.Context) (fetch, error) {
-	select {
-	case <-ctx.Done():
-		return fetch{}, ctx.Err()
-	case f, ok := <-c.fetches:
-		if !ok {
-			return fetch{}, queue.ErrEndOfQueue
-		}
-
-		return f, nil
-	}
-}
-
 type committerAcknowledger struct {
 	committer recordsCommitter
 }
@@ -225,7 +208,7 @@ func (loop eventLoop) handleAssignedPartition(ctx context.Context, ap assignedPa
 	loop.topicPartitions[ap.topicPartition] = records
 
 	// Create adapters for the orchestrator
-	consumer := &channelConsumer{fetches: records}
+	consumer := queue.NewChannelConsumer(records)
 	acknowledger := &committerAcknowledger{committer: ap.committer}
 
 	// Create runtime from orchestrator
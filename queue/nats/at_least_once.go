@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// AtLeastOnce configures the NATS runtime to process messages with at-least-once
+// delivery semantics (process before ack).
+//
+// Messages are only acknowledged once they have been successfully processed.
+// Messages which fail processing are negatively acknowledged (Nak) and redelivered
+// until the consumer's MaxDeliver limit is reached. Processors may return an error
+// wrapped with [Terminal] to stop a message from being redelivered at all.
+// Processors must therefore be idempotent.
+func AtLeastOnce(processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.orchestrator = atLeastOnceOrchestrator{processor: processor}
+	}
+}
+
+type atLeastOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atLeastOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atLeastOnceRuntime{
+		log:          orc.log,
+		consumer:     orc.consumer,
+		acknowledger: orc.acknowledger,
+		ackWait:      orc.ackWait,
+		concurrency:  orc.concurrency,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			consumerName:      orc.consumerName,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atLeastOnceRuntime struct {
+	log               *slog.Logger
+	consumer          queue.Consumer[[]jetstream.Msg]
+	processor         messageProcessor
+	acknowledger      queue.Acknowledger[jetstream.Msg]
+	ackWait           time.Duration
+	concurrency       int
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atLeastOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	batchCh := make(chan pendingBatch)
+	p.Go(consumeBatches(rt.log, heartbeatConsumer{rt: rt}, batchCh))
+
+	p.Go(rt.processBatches(batchCh))
+
+	return p.Wait()
+}
+
+// pendingBatch is a fetched batch whose messages are signalled to be in
+// progress until they have been processed.
+type pendingBatch struct {
+	msgs    []jetstream.Msg
+	pending *pendingMsgs
+	stop    func()
+}
+
+// heartbeatConsumer starts signalling progress for every batch as soon as it
+// is fetched, so messages waiting to be processed, including those of the
+// next batch, are not redelivered.
+type heartbeatConsumer struct {
+	rt atLeastOnceRuntime
+}
+
+func (c heartbeatConsumer) Consume(ctx context.Context) (pendingBatch, error) {
+	msgs, err := c.rt.consumer.Consume(ctx)
+	if err != nil {
+		return pendingBatch{}, err
+	}
+	pending := newPendingMsgs(msgs)
+	return pendingBatch{msgs: msgs, pending: pending, stop: c.rt.heartbeat(ctx, pending)}, nil
+}
+
+func (rt atLeastOnceRuntime) processBatches(batchCh <-chan pendingBatch) func(context.Context) error {
+	return func(ctx context.Context) error {
+		for batch := range batchCh {
+			p := pool.New().WithMaxGoroutines(rt.concurrency)
+			for _, msg := range batch.msgs {
+				p.Go(func() {
+					rt.processMessage(ctx, batch.pending, msg)
+				})
+			}
+			p.Wait()
+			batch.stop()
+		}
+		return nil
+	}
+}
+
+func (rt atLeastOnceRuntime) processMessage(ctx context.Context, pending *pendingMsgs, msg jetstream.Msg) {
+	err := rt.processor.process(ctx, msg)
+	pending.remove(msg)
+
+	// Acks should still be sent during shutdown to avoid
+	// needlessly redelivering processed messages.
+	ackCtx := context.WithoutCancel(ctx)
+
+	if err != nil {
+		rt.reject(ackCtx, msg, err)
+		return
+	}
+
+	err = rt.acknowledger.Acknowledge(ackCtx, msg)
+	if err != nil {
+		rt.log.ErrorContext(
+			ctx,
+			"failed to acknowledge nats message",
+			SubjectAttr(msg.Subject()),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	rt.messagesCommitted.Add(ctx, 1, metric.WithAttributes(
+		messagingSystemNATS,
+		messagingDestination(msg),
+	))
+}
+
+func (rt atLeastOnceRuntime) reject(ctx context.Context, msg jetstream.Msg, err error) {
+	if isTerminal(err) {
+		err = msg.TermWithReason(err.Error())
+	} else {
+		err = msg.Nak()
+	}
+	if err != nil {
+		rt.log.ErrorContext(
+			ctx,
+			"failed to reject nats message",
+			SubjectAttr(msg.Subject()),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// pendingMsgs are the messages of a batch which have not finished processing.
+type pendingMsgs struct {
+	mu   sync.Mutex
+	msgs map[jetstream.Msg]struct{}
+}
+
+func newPendingMsgs(batch []jetstream.Msg) *pendingMsgs {
+	msgs := make(map[jetstream.Msg]struct{}, len(batch))
+	for _, msg := range batch {
+		msgs[msg] = struct{}{}
+	}
+	return &pendingMsgs{msgs: msgs}
+}
+
+// remove stops signalling progress for the message. Progress signalled for a
+// message after it has been acknowledged is ignored by the server.
+func (p *pendingMsgs) remove(msg jetstream.Msg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.msgs, msg)
+}
+
+// snapshot returns the messages which are still pending.
+func (p *pendingMsgs) snapshot() []jetstream.Msg {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Collect(maps.Keys(p.msgs))
+}
+
+// heartbeat periodically tells the server the pending messages are still being
+// worked on so they are not redelivered, either while a long running processor
+// is executing or while they wait for a processor to become available.
+func (rt atLeastOnceRuntime) heartbeat(ctx context.Context, pending *pendingMsgs) (stop func()) {
+	interval := rt.ackWait / 2
+	if interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			// Progress is signalled without holding the lock, so finished
+			// messages can be removed and acknowledged in the meantime.
+			for _, msg := range pending.snapshot() {
+				err := msg.InProgress()
+				if err != nil && !errors.Is(err, jetstream.ErrMsgAlreadyAckd) {
+					rt.log.WarnContext(
+						ctx,
+						"failed to extend nats message ack deadline",
+						SubjectAttr(msg.Subject()),
+						slog.Any("error", err),
+					)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// AtMostOnce configures the NATS runtime to process messages with at-most-once
+// delivery semantics (ack before process).
+//
+// Messages are acknowledged as soon as they are received, so a message which
+// fails processing is lost. Messages which fail to be acknowledged are not
+// processed and will be redelivered once their ack wait expires.
+func AtMostOnce(processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.orchestrator = atMostOnceOrchestrator{processor: processor}
+	}
+}
+
+type atMostOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atMostOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atMostOnceRuntime{
+		log:          orc.log,
+		consumer:     orc.consumer,
+		acknowledger: orc.acknowledger,
+		concurrency:  orc.concurrency,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			consumerName:      orc.consumerName,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atMostOnceRuntime struct {
+	log               *slog.Logger
+	consumer          queue.Consumer[[]jetstream.Msg]
+	processor         messageProcessor
+	acknowledger      queue.Acknowledger[jetstream.Msg]
+	concurrency       int
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atMostOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	batchCh := make(chan []jetstream.Msg)
+	p.Go(consumeBatches(rt.log, rt.consumer, batchCh))
+
+	msgCh := make(chan jetstream.Msg)
+	p.Go(rt.acknowledgeBatches(msgCh, batchCh))
+
+	p.Go(rt.processMessages(msgCh))
+
+	return p.Wait()
+}
+
+func (rt atMostOnceRuntime) acknowledgeBatches(msgCh chan<- jetstream.Msg, batchCh <-chan []jetstream.Msg) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(msgCh)
+
+		for batch := range batchCh {
+			for _, msg := range batch {
+				err := rt.acknowledger.Acknowledge(ctx, msg)
+				if err != nil {
+					rt.log.ErrorContext(
+						ctx,
+						"failed to acknowledge nats message",
+						SubjectAttr(msg.Subject()),
+						slog.Any("error", err),
+					)
+					continue
+				}
+
+				rt.messagesCommitted.Add(ctx, 1, metric.WithAttributes(
+					messagingSystemNATS,
+					messagingDestination(msg),
+				))
+
+				select {
+				case <-ctx.Done():
+					rt.log.WarnContext(
+						ctx,
+						"context cancelled after acknowledging messages",
+						slog.Any("error", ctx.Err()),
+					)
+					return nil
+				case msgCh <- msg:
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func (rt atMostOnceRuntime) processMessages(msgCh <-chan jetstream.Msg) func(context.Context) error {
+	return func(ctx context.Context) error {
+		p := pool.New().WithMaxGoroutines(rt.concurrency)
+
+		for msg := range msgCh {
+			p.Go(func() {
+				rt.processor.process(ctx, msg)
+			})
+		}
+
+		p.Wait()
+		return nil
+	}
+}
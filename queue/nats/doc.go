@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package nats provides a NATS JetStream runtime implementation for the Humus queue framework.
+//
+// The runtime consumes from an existing JetStream stream using a durable pull consumer
+// and offers at-most-once and at-least-once delivery semantics.
+//
+// # Usage
+//
+//	runtime := nats.NewRuntime(
+//	    "nats://localhost:4222",
+//	    "ORDERS",
+//	    "order-processor",
+//	    nats.AtLeastOnce(processor),
+//	    nats.FilterSubjects("orders.created"),
+//	    nats.Concurrency(5),
+//	)
+//	app := queue.NewApp(runtime)
+//
+// # Durable Consumers
+//
+// The durable consumer is created, or updated, with explicit acknowledgements when the
+// runtime starts. Multiple instances sharing the same durable name split the stream's
+// messages between them.
+//
+// # Acknowledgements
+//
+// With at-least-once semantics, successfully processed messages are acknowledged and
+// failed messages are negatively acknowledged so they are redelivered until [MaxDeliver]
+// is reached. Wrapping an error with [Terminal] terminates the message instead. Until a
+// fetched message has been processed, progress is periodically signalled to the server
+// so neither long running processors nor messages waiting for a processor exceed the
+// [AckWait].
+//
+// With at-most-once semantics, every message is acknowledged before it is processed.
+//
+// Acknowledgements are confirmed by the server (double ack) before a message is
+// considered committed.
+//
+// # OpenTelemetry Instrumentation
+//
+// Every message is processed within a consumer span named "process <subject>".
+// Trace context propagated through message headers is linked to the span.
+//
+// The following metrics are automatically collected:
+//
+//	messaging.client.messages.processed - Total number of NATS messages processed
+//	  Labels: messaging.system, messaging.destination.name (subject), messaging.process.status
+//	  Unit: {message}
+//
+//	messaging.client.messages.committed - Total number of NATS messages successfully acknowledged
+//	  Labels: messaging.system, messaging.destination.name (subject)
+//	  Unit: {message}
+package nats
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import "errors"
+
+// TerminalError signals that a message can never be processed successfully
+// and should not be redelivered.
+type TerminalError struct {
+	Err error
+}
+
+// Terminal wraps err in a [TerminalError]. When returned by a processor
+// running with at-least-once semantics, the message is terminated (Term)
+// instead of being negatively acknowledged (Nak) for redelivery.
+func Terminal(err error) error {
+	return &TerminalError{Err: err}
+}
+
+// Error implements the [error] interface.
+func (e *TerminalError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TerminalError) Unwrap() error {
+	return e.Err
+}
+
+func isTerminal(err error) bool {
+	var te *TerminalError
+	return errors.As(err, &te)
+}
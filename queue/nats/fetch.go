@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type pullConsumer interface {
+	Fetch(batch int, opts ...jetstream.FetchOpt) (jetstream.MessageBatch, error)
+}
+
+type fetcher struct {
+	consumer pullConsumer
+	batch    int
+	maxWait  time.Duration
+}
+
+// Consume pulls from the durable consumer until at least one message is received.
+func (f *fetcher) Consume(ctx context.Context) ([]jetstream.Msg, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msgs, err := f.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(msgs) > 0 {
+			return msgs, nil
+		}
+	}
+}
+
+func (f *fetcher) fetch(ctx context.Context) ([]jetstream.Msg, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, f.maxWait)
+	defer cancel()
+
+	batch, err := f.consumer.Fetch(f.batch, jetstream.FetchContext(fetchCtx))
+	if err != nil {
+		return nil, fmt.Errorf("nats: failed to fetch messages: %w", err)
+	}
+
+	var msgs []jetstream.Msg
+	for msg := range batch.Messages() {
+		msgs = append(msgs, msg)
+	}
+
+	err = batch.Error()
+	if err == nil || isEmptyFetch(err) && ctx.Err() == nil {
+		return msgs, nil
+	}
+	if len(msgs) > 0 {
+		// Deliver what was received, the next fetch will surface the error again.
+		return msgs, nil
+	}
+	return nil, fmt.Errorf("nats: failed to fetch messages: %w", err)
+}
+
+func isEmptyFetch(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, nats.ErrTimeout) ||
+		errors.Is(err, jetstream.ErrNoMessages)
+}
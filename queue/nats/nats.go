@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Message represents a NATS JetStream message.
+type Message struct {
+	Subject          string
+	Data             []byte
+	Headers          map[string][]string
+	Stream           string
+	Consumer         string
+	StreamSequence   uint64
+	ConsumerSequence uint64
+	Timestamp        time.Time
+
+	// NumDelivered is the number of times the message has been delivered,
+	// including this delivery.
+	NumDelivered uint64
+}
+
+type orchestration struct {
+	log          *slog.Logger
+	stream       string
+	consumerName string
+	consumer     queue.Consumer[[]jetstream.Msg]
+	acknowledger queue.Acknowledger[jetstream.Msg]
+	ackWait      time.Duration
+	concurrency  int
+}
+
+type consumerOrchestrator interface {
+	Orchestrate(orchestration) queue.Runtime
+}
+
+// Options represents configuration options for the NATS runtime.
+type Options struct {
+	orchestrator   consumerOrchestrator
+	filterSubjects []string
+	ackWait        time.Duration
+	maxDeliver     int
+	fetchBatch     int
+	fetchMaxWait   time.Duration
+	concurrency    int
+	tlsConfig      *tls.Config
+	natsOpts       []nats.Option
+}
+
+// Option defines a function type for configuring NATS runtime options.
+type Option func(*Options)
+
+// FilterSubjects restricts the durable consumer to messages published on the given subjects.
+// By default all subjects of the stream are consumed.
+func FilterSubjects(subjects ...string) Option {
+	return func(o *Options) {
+		o.filterSubjects = append(o.filterSubjects, subjects...)
+	}
+}
+
+// AckWait sets how long the server waits for a message to be acknowledged before
+// redelivering it. Until a fetched message has been processed with at-least-once
+// semantics, the runtime periodically signals progress so neither long running
+// processors nor messages waiting to be processed cause redeliveries. Default is 30 seconds if not set.
+func AckWait(d time.Duration) Option {
+	return func(o *Options) {
+		o.ackWait = d
+	}
+}
+
+// MaxDeliver sets the maximum number of delivery attempts for a message.
+// Default is unlimited if not set.
+func MaxDeliver(n int) Option {
+	return func(o *Options) {
+		o.maxDeliver = n
+	}
+}
+
+// FetchBatch sets the maximum number of messages requested by a single pull.
+// Default is 100 if not set.
+func FetchBatch(n int) Option {
+	return func(o *Options) {
+		o.fetchBatch = n
+	}
+}
+
+// FetchMaxWait sets how long a single pull waits for messages to become available.
+// Default is 5 seconds if not set.
+func FetchMaxWait(d time.Duration) Option {
+	return func(o *Options) {
+		o.fetchMaxWait = d
+	}
+}
+
+// Concurrency sets the maximum number of messages from a single pull which
+// are processed concurrently. Default is 1 if not set.
+func Concurrency(n int) Option {
+	return func(o *Options) {
+		o.concurrency = n
+	}
+}
+
+// WithTLS configures TLS/mTLS for secure connections to the NATS servers.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *Options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// ConnectOptions passes additional options, e.g. credentials, to the NATS connection.
+func ConnectOptions(opts ...nats.Option) Option {
+	return func(o *Options) {
+		o.natsOpts = append(o.natsOpts, opts...)
+	}
+}
+
+// Runtime represents the NATS JetStream runtime for processing messages.
+type Runtime struct {
+	log            *slog.Logger
+	url            string
+	stream         string
+	consumerName   string
+	orchestrator   consumerOrchestrator
+	filterSubjects []string
+	ackWait        time.Duration
+	maxDeliver     int
+	fetchBatch     int
+	fetchMaxWait   time.Duration
+	concurrency    int
+	natsOpts       []nats.Option
+}
+
+// NewRuntime creates a new NATS JetStream runtime which consumes from the given
+// stream using a durable pull consumer. The durable consumer is created, or updated,
+// when the runtime starts.
+func NewRuntime(
+	url string,
+	stream string,
+	durable string,
+	opts ...Option,
+) Runtime {
+	cfg := &Options{
+		ackWait:      30 * time.Second,
+		maxDeliver:   -1,
+		fetchBatch:   100,
+		fetchMaxWait: 5 * time.Second,
+		concurrency:  1,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.orchestrator == nil {
+		panic("nats: a processor must be configured with AtLeastOnce or AtMostOnce")
+	}
+
+	natsOpts := append([]nats.Option{nats.Name(durable)}, cfg.natsOpts...)
+	if cfg.tlsConfig != nil {
+		natsOpts = append(natsOpts, nats.Secure(cfg.tlsConfig))
+	}
+
+	return Runtime{
+		log:            logger().With(StreamAttr(stream), ConsumerAttr(durable)),
+		url:            url,
+		stream:         stream,
+		consumerName:   durable,
+		orchestrator:   cfg.orchestrator,
+		filterSubjects: cfg.filterSubjects,
+		ackWait:        cfg.ackWait,
+		maxDeliver:     cfg.maxDeliver,
+		fetchBatch:     cfg.fetchBatch,
+		fetchMaxWait:   cfg.fetchMaxWait,
+		concurrency:    cfg.concurrency,
+		natsOpts:       natsOpts,
+	}
+}
+
+// ProcessQueue starts processing the NATS JetStream stream.
+func (r Runtime) ProcessQueue(ctx context.Context) error {
+	nc, err := nats.Connect(r.url, r.natsOpts...)
+	if err != nil {
+		return fmt.Errorf("nats: failed to connect: %w", err)
+	}
+	defer nc.Close()
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return fmt.Errorf("nats: failed to create jetstream context: %w", err)
+	}
+
+	cons, err := js.CreateOrUpdateConsumer(ctx, r.stream, jetstream.ConsumerConfig{
+		Durable:        r.consumerName,
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        r.ackWait,
+		MaxDeliver:     r.maxDeliver,
+		FilterSubjects: r.filterSubjects,
+	})
+	if err != nil {
+		return fmt.Errorf("nats: failed to create durable consumer: %w", err)
+	}
+
+	rt := r.orchestrator.Orchestrate(orchestration{
+		log:          r.log,
+		stream:       r.stream,
+		consumerName: r.consumerName,
+		consumer: &fetcher{
+			consumer: cons,
+			batch:    r.fetchBatch,
+			maxWait:  r.fetchMaxWait,
+		},
+		acknowledger: queue.AcknowledgerFunc[jetstream.Msg](func(ctx context.Context, msg jetstream.Msg) error {
+			return msg.DoubleAck(ctx)
+		}),
+		ackWait:     r.ackWait,
+		concurrency: r.concurrency,
+	})
+
+	return rt.ProcessQueue(ctx)
+}
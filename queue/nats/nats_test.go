@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+const testStream = "ORDERS"
+
+func startServer(t *testing.T) (url string, js jetstream.JetStream) {
+	t.Helper()
+
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	require.True(t, s.ReadyForConnections(5*time.Second))
+
+	nc, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	js, err = jetstream.New(nc)
+	require.NoError(t, err)
+
+	_, err = js.CreateStream(t.Context(), jetstream.StreamConfig{
+		Name:     testStream,
+		Subjects: []string{"orders.>"},
+	})
+	require.NoError(t, err)
+
+	return s.ClientURL(), js
+}
+
+func publish(t *testing.T, js jetstream.JetStream, subject string, data ...string) {
+	t.Helper()
+
+	for _, d := range data {
+		_, err := js.Publish(t.Context(), subject, []byte(d))
+		require.NoError(t, err)
+	}
+}
+
+// drained reports whether the durable consumer exists and has no
+// pending or unacknowledged messages.
+func drained(t *testing.T, js jetstream.JetStream, durable string) bool {
+	t.Helper()
+
+	cons, err := js.Consumer(t.Context(), testStream, durable)
+	if err != nil {
+		return false
+	}
+
+	info, err := cons.Info(t.Context())
+	if err != nil {
+		return false
+	}
+	return info.NumPending == 0 && info.NumAckPending == 0
+}
+
+func runRuntime(t *testing.T, rt Runtime) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rt.ProcessQueue(ctx)
+	}()
+
+	return func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}
+}
+
+func TestRuntime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should ack processed messages and redeliver failures with at-least-once", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "ok", "flaky")
+
+		var (
+			mu        sync.Mutex
+			processed []string
+		)
+		rt := NewRuntime(
+			url,
+			testStream,
+			"at-least-once",
+			FetchMaxWait(100*time.Millisecond),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, string(msg.Data))
+
+				if string(msg.Data) == "flaky" && msg.NumDelivered == 1 {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return drained(t, js, "at-least-once")
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.ElementsMatch(t, []string{"ok", "flaky", "flaky"}, processed)
+	})
+
+	t.Run("should not redeliver terminal failures", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "poison", "ok")
+
+		var (
+			mu        sync.Mutex
+			processed []string
+		)
+		rt := NewRuntime(
+			url,
+			testStream,
+			"terminal",
+			FetchMaxWait(100*time.Millisecond),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, string(msg.Data))
+
+				if string(msg.Data) == "poison" {
+					return Terminal(errors.New("malformed message"))
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return drained(t, js, "terminal")
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Give the server a chance to (incorrectly) redeliver.
+		time.Sleep(200 * time.Millisecond)
+		stop()
+
+		require.ElementsMatch(t, []string{"poison", "ok"}, processed)
+	})
+
+	t.Run("should ack messages before processing with at-most-once", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "fail", "ok")
+
+		var (
+			mu        sync.Mutex
+			processed []Message
+		)
+		rt := NewRuntime(
+			url,
+			testStream,
+			"at-most-once",
+			FetchMaxWait(100*time.Millisecond),
+			AtMostOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, msg)
+
+				if string(msg.Data) == "fail" {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(processed) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.True(t, drained(t, js, "at-most-once"))
+
+		for _, msg := range processed {
+			require.Equal(t, "orders.created", msg.Subject)
+			require.Equal(t, testStream, msg.Stream)
+			require.Equal(t, "at-most-once", msg.Consumer)
+			require.Equal(t, uint64(1), msg.NumDelivered)
+		}
+	})
+
+	t.Run("should only consume filtered subjects", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "created")
+		publish(t, js, "orders.deleted", "deleted")
+
+		msgCh := make(chan Message, 2)
+		rt := NewRuntime(
+			url,
+			testStream,
+			"filtered",
+			FetchMaxWait(100*time.Millisecond),
+			FilterSubjects("orders.deleted"),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				msgCh <- msg
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		msg := <-msgCh
+		stop()
+
+		require.Equal(t, "deleted", string(msg.Data))
+		require.Empty(t, msgCh)
+	})
+
+	t.Run("should signal progress while processing", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "slow")
+
+		var calls int
+		rt := NewRuntime(
+			url,
+			testStream,
+			"slow",
+			FetchMaxWait(100*time.Millisecond),
+			AckWait(time.Second),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				calls++
+				time.Sleep(1500 * time.Millisecond)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return drained(t, js, "slow")
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("should signal progress while messages wait to be processed", func(t *testing.T) {
+		url, js := startServer(t)
+		publish(t, js, "orders.created", "a", "b", "c", "d")
+
+		var (
+			mu        sync.Mutex
+			delivered []uint64
+		)
+		rt := NewRuntime(
+			url,
+			testStream,
+			"queued",
+			FetchMaxWait(100*time.Millisecond),
+			AckWait(time.Second),
+			FetchBatch(2),
+			Concurrency(1),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				delivered = append(delivered, msg.NumDelivered)
+				mu.Unlock()
+
+				// The second batch is fetched while the first is processed,
+				// so its last message waits longer than the ack wait.
+				time.Sleep(400 * time.Millisecond)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return drained(t, js, "queued")
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Equal(t, []uint64{1, 1, 1, 1}, delivered)
+	})
+
+	t.Run("should return an error if the stream does not exist", func(t *testing.T) {
+		url, _ := startServer(t)
+
+		rt := NewRuntime(
+			url,
+			"MISSING",
+			"missing",
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				return nil
+			})),
+		)
+
+		err := rt.ProcessQueue(t.Context())
+		require.ErrorIs(t, err, jetstream.ErrStreamNotFound)
+	})
+
+	t.Run("should panic if no processor is configured", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewRuntime("nats://localhost:4222", testStream, "durable")
+		})
+	})
+}
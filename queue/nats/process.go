@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var messagingSystemNATS = semconv.MessagingSystemKey.String("nats")
+
+func consumeBatches[T any](log *slog.Logger, consumer queue.Consumer[T], batchCh chan<- T) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(batchCh)
+
+		for {
+			msgs, err := consumer.Consume(ctx)
+			if errors.Is(err, queue.ErrEndOfQueue) {
+				log.InfoContext(ctx, "encountered end of queue")
+				return nil
+			}
+			if err != nil && ctx.Err() != nil {
+				log.WarnContext(ctx, "context cancelled while fetching messages")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				log.WarnContext(ctx, "context cancelled while fetching messages")
+				return nil
+			case batchCh <- msgs:
+			}
+		}
+	}
+}
+
+type messageProcessor struct {
+	log               *slog.Logger
+	tracer            trace.Tracer
+	consumerName      string
+	processor         queue.Processor[Message]
+	messagesProcessed metric.Int64Counter
+}
+
+func (mp messageProcessor) process(ctx context.Context, msg jetstream.Msg) error {
+	m := toMessage(msg)
+
+	subjectAttr := semconv.MessagingDestinationName(m.Subject)
+	spanOpts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			messagingSystemNATS,
+			semconv.MessagingOperationTypeProcess,
+			subjectAttr,
+			semconv.MessagingConsumerGroupName(mp.consumerName),
+			attribute.Int64("messaging.nats.stream_sequence", int64(m.StreamSequence)),
+			attribute.Int64("messaging.nats.num_delivered", int64(m.NumDelivered)),
+		),
+	}
+
+	propagated := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(m.Headers))
+	if s := trace.SpanContextFromContext(propagated); s.IsValid() {
+		spanOpts = append(spanOpts, trace.WithLinks(trace.Link{SpanContext: s}))
+	}
+
+	spanCtx, span := mp.tracer.Start(ctx, "process "+m.Subject, spanOpts...)
+	defer span.End()
+
+	err := mp.processor.Process(spanCtx, m)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		mp.log.ErrorContext(
+			spanCtx,
+			"failed to process nats message",
+			SubjectAttr(m.Subject),
+			StreamSequenceAttr(m.StreamSequence),
+			slog.Any("error", err),
+		)
+	}
+
+	mp.messagesProcessed.Add(spanCtx, 1, metric.WithAttributes(
+		messagingSystemNATS,
+		subjectAttr,
+		attribute.String("messaging.process.status", processStatus(err)),
+	))
+
+	return err
+}
+
+func messagingDestination(msg jetstream.Msg) attribute.KeyValue {
+	return semconv.MessagingDestinationName(msg.Subject())
+}
+
+func processStatus(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func toMessage(msg jetstream.Msg) Message {
+	m := Message{
+		Subject: msg.Subject(),
+		Data:    msg.Data(),
+		Headers: msg.Headers(),
+	}
+
+	md, err := msg.Metadata()
+	if err != nil {
+		return m
+	}
+
+	m.Stream = md.Stream
+	m.Consumer = md.Consumer
+	m.StreamSequence = md.Sequence.Stream
+	m.ConsumerSequence = md.Sequence.Consumer
+	m.Timestamp = md.Timestamp
+	m.NumDelivered = md.NumDelivered
+	return m
+}
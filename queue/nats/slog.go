@@ -0,0 +1,28 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package nats
+
+import "log/slog"
+
+// StreamAttr returns a slog attribute for the JetStream stream name.
+func StreamAttr(name string) slog.Attr {
+	return slog.String("messaging.nats.stream", name)
+}
+
+// ConsumerAttr returns a slog attribute for the JetStream durable consumer name.
+func ConsumerAttr(name string) slog.Attr {
+	return slog.String("messaging.consumer.group.name", name)
+}
+
+// SubjectAttr returns a slog attribute for the NATS subject a message was published on.
+func SubjectAttr(subject string) slog.Attr {
+	return slog.String("messaging.destination.name", subject)
+}
+
+// StreamSequenceAttr returns a slog attribute for the stream sequence number of a message.
+func StreamSequenceAttr(seq uint64) slog.Attr {
+	return slog.Uint64("messaging.nats.stream_sequence", seq)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package queue
+
+import (
+	"log/slog"
+
+	"github.com/z5labs/humus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func logger() *slog.Logger {
+	return humus.Logger("github.com/z5labs/humus/queue")
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/z5labs/humus/queue")
+}
+
+func meter() metric.Meter {
+	return otel.Meter("github.com/z5labs/humus/queue")
+}
+
+type runtimeMetrics struct {
+	messagesProcessed    metric.Int64Counter
+	messagesAcknowledged metric.Int64Counter
+}
+
+func initRuntimeMetrics(log *slog.Logger) runtimeMetrics {
+	m := meter()
+
+	messagesProcessed, err := m.Int64Counter(
+		"messaging.client.messages.processed",
+		metric.WithDescription("Total number of messages processed"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		log.Warn("failed to create messages processed metric", slog.Any("error", err))
+	}
+
+	messagesAcknowledged, err := m.Int64Counter(
+		"messaging.client.messages.acknowledged",
+		metric.WithDescription("Total number of messages successfully acknowledged"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		log.Warn("failed to create messages acknowledged metric", slog.Any("error", err))
+	}
+
+	return runtimeMetrics{
+		messagesProcessed:    messagesProcessed,
+		messagesAcknowledged: messagesAcknowledged,
+	}
+}
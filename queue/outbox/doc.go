@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package outbox implements the transactional outbox pattern on top of
+// PostgreSQL and Kafka.
+//
+// Handlers write [Event]s into an outbox table using the same pgx transaction
+// as their business data, which guarantees an event is recorded if, and only if,
+// the business change is committed. A [Runtime] then relays the events to Kafka
+// in the background.
+//
+// # Schema
+//
+// The outbox table must have the following columns:
+//
+//	CREATE TABLE outbox (
+//	    id           BIGSERIAL PRIMARY KEY,
+//	    aggregate_id TEXT NOT NULL,
+//	    topic        TEXT NOT NULL,
+//	    payload      BYTEA,
+//	    headers      JSONB NOT NULL DEFAULT '[]',
+//	    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+//	    xact_id      XID8 NOT NULL DEFAULT pg_current_xact_id()
+//	);
+//	CREATE INDEX ON outbox (xact_id, id);
+//
+// The xact_id column records the transaction which wrote the event and requires
+// PostgreSQL 13 or later.
+//
+// # Writing Events
+//
+//	func (s *PostgresStore) CreateCart(ctx context.Context) (Cart, error) {
+//	    tx, err := s.pool.Begin(ctx)
+//	    if err != nil {
+//	        return Cart{}, err
+//	    }
+//	    defer tx.Rollback(ctx)
+//
+//	    // insert the cart using tx ...
+//
+//	    err = outbox.Write(ctx, tx, outbox.Event{
+//	        AggregateID: cart.CartID.String(),
+//	        Topic:       "carts",
+//	        Payload:     payload,
+//	    })
+//	    if err != nil {
+//	        return Cart{}, err
+//	    }
+//	    return cart, tx.Commit(ctx)
+//	}
+//
+// # Relaying Events
+//
+//	runtime := outbox.NewRuntime(pool, []string{"localhost:9092"})
+//	app := queue.NewApp(runtime)
+//
+// # Delivery Semantics
+//
+// Events are relayed with at-least-once semantics. Each batch is read, published
+// and deleted within a single transaction, so events are only removed from the
+// outbox after Kafka has acknowledged them. Consumers of the relayed topics must
+// therefore be idempotent.
+//
+// # Ordering
+//
+// The [Event] AggregateID is used as the Kafka record key, so all events for an
+// aggregate are published to the same partition. Events are relayed in the order
+// of the transactions which wrote them and a PostgreSQL advisory lock ensures only
+// one replica relays events at a time, which preserves the order of events for
+// each aggregate.
+//
+// Events are only relayed once every transaction which started before them has
+// ended, since an earlier transaction may still commit events which must be
+// published first. Long running transactions therefore delay the relay.
+//
+// # OpenTelemetry Instrumentation
+//
+// The trace context active when an event is written is stored in its headers and
+// propagated onto the Kafka record, linking consumers back to the originating request.
+// Each relayed batch is traced and the following metric is collected:
+//
+//	messaging.client.sent.messages - Total number of outbox events relayed to Kafka
+//	  Labels: messaging.system, messaging.destination.name (topic)
+//	  Unit: {message}
+package outbox
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"log/slog"
+
+	"github.com/z5labs/humus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func logger() *slog.Logger {
+	return humus.Logger("github.com/z5labs/humus/queue/outbox")
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/z5labs/humus/queue/outbox")
+}
+
+func meter() metric.Meter {
+	return otel.Meter("github.com/z5labs/humus/queue/outbox")
+}
+
+type relayMetrics struct {
+	eventsRelayed metric.Int64Counter
+}
+
+func initRelayMetrics(log *slog.Logger) relayMetrics {
+	m := meter()
+
+	eventsRelayed, err := m.Int64Counter(
+		"messaging.client.sent.messages",
+		metric.WithDescription("Total number of outbox events relayed to Kafka"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		log.Warn("failed to create events relayed metric", slog.Any("error", err))
+	}
+
+	return relayMetrics{
+		eventsRelayed: eventsRelayed,
+	}
+}
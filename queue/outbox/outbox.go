@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+)
+
+// DefaultTable is the name of the outbox table used when none is configured.
+const DefaultTable = "outbox"
+
+// Header represents a message header which will be attached to the Kafka record
+// produced for an [Event].
+type Header struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Event represents a message which should be published to Kafka once the
+// transaction it was written in commits.
+type Event struct {
+	// AggregateID identifies the entity the event belongs to. It is used as
+	// the Kafka record key so all events for the same aggregate land on the
+	// same partition and are therefore delivered in order.
+	AggregateID string
+
+	// Topic is the Kafka topic the event will be published to.
+	Topic string
+
+	// Payload is the Kafka record value.
+	Payload []byte
+
+	// Headers are attached to the Kafka record alongside any
+	// propagated trace context.
+	Headers []Header
+}
+
+// Execer is implemented by pgx.Tx, *pgx.Conn and *pgxpool.Pool.
+//
+// Events should always be written with a pgx.Tx so they are only
+// published if the surrounding business transaction commits.
+type Execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// Writer inserts [Event]s into an outbox table.
+type Writer struct {
+	insertQuery string
+}
+
+// NewWriter initializes a [Writer] for the given outbox table. The table name
+// may be schema qualified, e.g. "events.outbox".
+func NewWriter(table string) Writer {
+	return Writer{
+		insertQuery: fmt.Sprintf(
+			`INSERT INTO %s (aggregate_id, topic, payload, headers) VALUES ($1, $2, $3, $4)`,
+			sanitizeTable(table),
+		),
+	}
+}
+
+// Write inserts the events into the outbox table using the given [Execer].
+//
+// The trace context found in ctx is propagated through the event headers so
+// the eventual Kafka record is correlated with the request which wrote it.
+func (w Writer) Write(ctx context.Context, tx Execer, events ...Event) error {
+	for _, event := range events {
+		headers := headerCarrier(append([]Header(nil), event.Headers...))
+		otel.GetTextMapPropagator().Inject(ctx, &headers)
+
+		b, err := json.Marshal([]Header(headers))
+		if err != nil {
+			return fmt.Errorf("outbox: failed to marshal event headers: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, w.insertQuery, event.AggregateID, event.Topic, event.Payload, string(b))
+		if err != nil {
+			return fmt.Errorf("outbox: failed to insert event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Write inserts the events into the [DefaultTable] using the given [Execer].
+func Write(ctx context.Context, tx Execer, events ...Event) error {
+	return NewWriter(DefaultTable).Write(ctx, tx, events...)
+}
+
+func sanitizeTable(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
+
+type headerCarrier []Header
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range c {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c *headerCarrier) Set(key, value string) {
+	for i, h := range *c {
+		if h.Key == key {
+			(*c)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c = append(*c, Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, len(c))
+	for i, h := range c {
+		keys[i] = h.Key
+	}
+	return keys
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type execerFunc func(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+
+func (f execerFunc) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return f(ctx, sql, args...)
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Run("should insert one row per event", func(t *testing.T) {
+		var (
+			queries []string
+			args    [][]any
+		)
+		tx := execerFunc(func(ctx context.Context, sql string, a ...any) (pgconn.CommandTag, error) {
+			queries = append(queries, sql)
+			args = append(args, a)
+			return pgconn.CommandTag{}, nil
+		})
+
+		err := NewWriter("events.outbox").Write(
+			t.Context(),
+			tx,
+			Event{AggregateID: "a", Topic: "t", Payload: []byte("1")},
+			Event{AggregateID: "b", Topic: "t", Payload: []byte("2"), Headers: []Header{{Key: "k", Value: []byte("v")}}},
+		)
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		require.Contains(t, queries[0], `INSERT INTO "events"."outbox"`)
+
+		require.Equal(t, "a", args[0][0])
+		require.Equal(t, "t", args[0][1])
+		require.Equal(t, []byte("1"), args[0][2])
+
+		var headers []Header
+		require.NoError(t, json.Unmarshal([]byte(args[1][3].(string)), &headers))
+		require.Equal(t, []Header{{Key: "k", Value: []byte("v")}}, headers)
+	})
+
+	t.Run("should propagate trace context through the event headers", func(t *testing.T) {
+		var stored string
+		tx := execerFunc(func(ctx context.Context, sql string, a ...any) (pgconn.CommandTag, error) {
+			stored = a[3].(string)
+			return pgconn.CommandTag{}, nil
+		})
+
+		traceID := trace.TraceID{1}
+		spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1},
+			TraceFlags: trace.FlagsSampled,
+		})
+		ctx := trace.ContextWithSpanContext(t.Context(), spanCtx)
+
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		err := NewWriter(DefaultTable).Write(ctx, tx, Event{AggregateID: "a", Topic: "t"})
+		require.NoError(t, err)
+
+		var headers []Header
+		require.NoError(t, json.Unmarshal([]byte(stored), &headers))
+
+		carrier := headerCarrier(headers)
+		extracted := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), &carrier))
+		require.Equal(t, traceID, extracted.TraceID())
+	})
+
+	t.Run("should return an error if the insert fails", func(t *testing.T) {
+		insertErr := errors.New("insert failed")
+		tx := execerFunc(func(ctx context.Context, sql string, a ...any) (pgconn.CommandTag, error) {
+			return pgconn.CommandTag{}, insertErr
+		})
+
+		err := Write(t.Context(), tx, Event{AggregateID: "a", Topic: "t"})
+		require.ErrorIs(t, err, insertErr)
+	})
+}
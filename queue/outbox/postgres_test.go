@@ -0,0 +1,194 @@
+//go:build testcontainers
+
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/z5labs/humus/internal/pgtest"
+)
+
+// createOutbox creates an outbox table with the documented schema.
+func createOutbox(t *testing.T, db *pgxpool.Pool, table string) {
+	t.Helper()
+
+	_, err := db.Exec(context.Background(), fmt.Sprintf(`CREATE TABLE %s (
+    id           BIGSERIAL PRIMARY KEY,
+    aggregate_id TEXT NOT NULL,
+    topic        TEXT NOT NULL,
+    payload      BYTEA,
+    headers      JSONB NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    xact_id      XID8 NOT NULL DEFAULT pg_current_xact_id()
+)`, sanitizeTable(table)))
+	require.NoError(t, err, "failed to create outbox table")
+}
+
+// writeEvents writes the events within a transaction, which is committed or rolled back.
+func writeEvents(t *testing.T, db *pgxpool.Pool, table string, commit bool, events ...Event) {
+	t.Helper()
+
+	ctx := context.Background()
+	err := pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		err := NewWriter(table).Write(ctx, tx, events...)
+		if err != nil {
+			return err
+		}
+		if !commit {
+			return errors.New("rollback")
+		}
+		return nil
+	})
+	if commit {
+		require.NoError(t, err, "failed to write events")
+	}
+}
+
+func countEvents(t *testing.T, db *pgxpool.Pool, table string) int {
+	t.Helper()
+
+	var n int
+	err := db.QueryRow(context.Background(), fmt.Sprintf(`SELECT count(*) FROM %s`, sanitizeTable(table))).Scan(&n)
+	require.NoError(t, err)
+	return n
+}
+
+// recordingProducer records the published records.
+func recordingProducer(published *[]*kgo.Record) producerFunc {
+	return func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+		*published = append(*published, records...)
+		return kgo.ProduceResults{}
+	}
+}
+
+func TestPostgres(t *testing.T) {
+	db, cleanup := pgtest.Start(t)
+	defer cleanup()
+
+	_, err := db.Exec(context.Background(), `CREATE SCHEMA events`)
+	require.NoError(t, err)
+
+	t.Run("should relay committed events in order and delete them", func(t *testing.T) {
+		table := "events.ordered"
+		createOutbox(t, db, table)
+
+		writeEvents(t, db, table, true,
+			Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("a"), Headers: []Header{{Key: "k", Value: []byte("v")}}},
+			Event{AggregateID: "cart-2", Topic: "carts", Payload: []byte("b")},
+		)
+		writeEvents(t, db, table, false, Event{AggregateID: "cart-3", Topic: "carts", Payload: []byte("rolled back")})
+		writeEvents(t, db, table, true, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("c")})
+
+		var published []*kgo.Record
+		rt := NewRuntime(db, nil, Table(table))
+		err := rt.relay(recordingProducer(&published)).relayBatch(t.Context())
+		require.NoError(t, err)
+
+		require.Len(t, published, 3)
+		for i, want := range []string{"a", "b", "c"} {
+			require.Equal(t, want, string(published[i].Value))
+			require.Equal(t, "carts", published[i].Topic)
+		}
+		require.Equal(t, []byte("cart-1"), published[0].Key)
+		require.Equal(t, []kgo.RecordHeader{{Key: "k", Value: []byte("v")}}, published[0].Headers)
+		require.Zero(t, countEvents(t, db, table))
+	})
+
+	t.Run("should relay events in commit order", func(t *testing.T) {
+		table := "commits"
+		createOutbox(t, db, table)
+
+		ctx := context.Background()
+		tx, err := db.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		// The first transaction takes the lower id but commits last.
+		err = NewWriter(table).Write(ctx, tx, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("a")})
+		require.NoError(t, err)
+		writeEvents(t, db, table, true, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("b")})
+
+		var published []*kgo.Record
+		r := NewRuntime(db, nil, Table(table)).relay(recordingProducer(&published))
+
+		require.ErrorIs(t, r.relayBatch(t.Context()), errNoEvents)
+		require.Empty(t, published)
+
+		require.NoError(t, tx.Commit(ctx))
+		require.NoError(t, r.relayBatch(t.Context()))
+
+		require.Len(t, published, 2)
+		require.Equal(t, "a", string(published[0].Value))
+		require.Equal(t, "b", string(published[1].Value))
+	})
+
+	t.Run("should relay events in batches", func(t *testing.T) {
+		table := "batches"
+		createOutbox(t, db, table)
+
+		for _, payload := range []string{"a", "b", "c"} {
+			writeEvents(t, db, table, true, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte(payload)})
+		}
+
+		var published []*kgo.Record
+		r := NewRuntime(db, nil, Table(table), BatchSize(2)).relay(recordingProducer(&published))
+
+		require.NoError(t, r.relayBatch(t.Context()))
+		require.Len(t, published, 2)
+		require.Equal(t, 1, countEvents(t, db, table))
+
+		require.NoError(t, r.relayBatch(t.Context()))
+		require.Len(t, published, 3)
+		require.Equal(t, "c", string(published[2].Value))
+
+		require.ErrorIs(t, r.relayBatch(t.Context()), errNoEvents)
+	})
+
+	t.Run("should not delete events if publishing fails", func(t *testing.T) {
+		table := "failures"
+		createOutbox(t, db, table)
+		writeEvents(t, db, table, true, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("a")})
+
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			return kgo.ProduceResults{{Err: errors.New("broker unavailable")}}
+		})
+		err := NewRuntime(db, nil, Table(table)).relay(p).relayBatch(t.Context())
+		require.Error(t, err)
+		require.Equal(t, 1, countEvents(t, db, table))
+	})
+
+	t.Run("should skip relaying while another replica holds the lock", func(t *testing.T) {
+		table := "locked"
+		createOutbox(t, db, table)
+		writeEvents(t, db, table, true, Event{AggregateID: "cart-1", Topic: "carts", Payload: []byte("a")})
+
+		var published []*kgo.Record
+		rt := NewRuntime(db, nil, Table(table))
+		r := rt.relay(recordingProducer(&published))
+
+		holder, err := db.Begin(t.Context())
+		require.NoError(t, err)
+		_, err = holder.Exec(t.Context(), `SELECT pg_advisory_xact_lock($1)`, rt.lockKey)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, r.relayBatch(t.Context()), errNoEvents)
+		require.Empty(t, published)
+
+		// The lock is released when the transaction of the other replica ends.
+		require.NoError(t, holder.Rollback(t.Context()))
+		require.NoError(t, r.relayBatch(t.Context()))
+		require.Len(t, published, 1)
+	})
+}
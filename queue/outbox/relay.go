@@ -0,0 +1,277 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/plugin/kotel"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errNoEvents is returned by the batch consumer when there is nothing
+// to relay, either because the outbox is empty or another replica
+// currently holds the relay lock.
+var errNoEvents = errors.New("outbox: no events to relay")
+
+type storedEvent struct {
+	Event
+
+	id int64
+}
+
+type batch struct {
+	tx     pgx.Tx
+	events []storedEvent
+}
+
+// selectQuery reads the events of transactions which precede every running
+// transaction. Ids are allocated before commit, so a transaction holding a
+// lower id may still commit after events with higher ids have been relayed.
+// Only relaying events below the xmin of the current snapshot ensures no
+// event can commit ahead of those already relayed.
+func selectQuery(table string) string {
+	return fmt.Sprintf(
+		`SELECT id, aggregate_id, topic, payload, headers FROM %s
+WHERE xact_id < pg_snapshot_xmin(pg_current_snapshot())
+ORDER BY xact_id, id
+LIMIT $1`,
+		sanitizeTable(table),
+	)
+}
+
+func deleteQuery(table string) string {
+	return fmt.Sprintf(
+		`DELETE FROM %s WHERE id = ANY($1)`,
+		sanitizeTable(table),
+	)
+}
+
+type batchConsumer struct {
+	db          TxBeginner
+	lockKey     int64
+	selectQuery string
+	batchSize   int
+}
+
+// Consume begins a transaction, acquires the relay advisory lock and reads
+// the oldest events from the outbox. The returned batch owns the transaction
+// which must be committed or rolled back by the caller.
+func (c *batchConsumer) Consume(ctx context.Context) (batch, error) {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return batch{}, err
+	}
+
+	events, err := c.readEvents(ctx, tx)
+	if err != nil {
+		rollback(ctx, tx)
+		return batch{}, err
+	}
+
+	return batch{tx: tx, events: events}, nil
+}
+
+func (c *batchConsumer) readEvents(ctx context.Context, tx pgx.Tx) ([]storedEvent, error) {
+	// The lock is released automatically when the transaction ends which
+	// guarantees only one replica relays events at a time, preserving
+	// the per aggregate ordering of the outbox.
+	var locked bool
+	err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, c.lockKey).Scan(&locked)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, errNoEvents
+	}
+
+	rows, err := tx.Query(ctx, c.selectQuery, c.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]storedEvent, 0, c.batchSize)
+	for rows.Next() {
+		var (
+			event   storedEvent
+			headers []byte
+		)
+		err := rows.Scan(&event.id, &event.AggregateID, &event.Topic, &event.Payload, &headers)
+		if err != nil {
+			return nil, err
+		}
+		if len(headers) > 0 {
+			err = json.Unmarshal(headers, &event.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("outbox: failed to unmarshal event headers: %w", err)
+			}
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errNoEvents
+	}
+	return events, nil
+}
+
+type producer interface {
+	ProduceSync(context.Context, ...*kgo.Record) kgo.ProduceResults
+}
+
+type batchPublisher struct {
+	producer producer
+}
+
+// Process publishes every event in the batch to Kafka, in outbox order,
+// and waits for all of them to be acknowledged by the brokers.
+func (p batchPublisher) Process(ctx context.Context, b batch) error {
+	records := make([]*kgo.Record, len(b.events))
+	for i, event := range b.events {
+		headers := make([]kgo.RecordHeader, len(event.Headers))
+		for j, hdr := range event.Headers {
+			headers[j] = kgo.RecordHeader{
+				Key:   hdr.Key,
+				Value: hdr.Value,
+			}
+		}
+
+		record := &kgo.Record{
+			Key:     []byte(event.AggregateID),
+			Value:   event.Payload,
+			Headers: headers,
+			Topic:   event.Topic,
+		}
+		record.Context = otel.GetTextMapPropagator().Extract(ctx, kotel.NewRecordCarrier(record))
+
+		records[i] = record
+	}
+
+	return p.producer.ProduceSync(ctx, records...).FirstErr()
+}
+
+type batchDeleter struct {
+	deleteQuery string
+}
+
+// Acknowledge removes the relayed events from the outbox and commits
+// the batch transaction.
+func (d batchDeleter) Acknowledge(ctx context.Context, b batch) error {
+	ids := make([]int64, len(b.events))
+	for i, event := range b.events {
+		ids[i] = event.id
+	}
+
+	_, err := b.tx.Exec(ctx, d.deleteQuery, ids)
+	if err != nil {
+		return err
+	}
+	return b.tx.Commit(ctx)
+}
+
+type relay struct {
+	log           *slog.Logger
+	tracer        trace.Tracer
+	pollInterval  time.Duration
+	consumer      queue.Consumer[batch]
+	processor     queue.Processor[batch]
+	acknowledger  queue.Acknowledger[batch]
+	eventsRelayed metric.Int64Counter
+}
+
+func (r relay) run(ctx context.Context) error {
+	for {
+		err := r.relayBatch(ctx)
+		if ctx.Err() != nil {
+			r.log.InfoContext(ctx, "stopped relaying events", slog.Any("error", ctx.Err()))
+			return nil
+		}
+		if errors.Is(err, queue.ErrEndOfQueue) {
+			r.log.InfoContext(ctx, "encountered end of queue")
+			return nil
+		}
+		if err == nil {
+			// There may be more events waiting so immediately poll again.
+			continue
+		}
+		if !errors.Is(err, errNoEvents) {
+			r.log.ErrorContext(ctx, "failed to relay outbox events", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			r.log.InfoContext(ctx, "stopped relaying events", slog.Any("error", ctx.Err()))
+			return nil
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// relayBatch processes a single batch of events with at-least-once semantics.
+// Events are only deleted from the outbox once Kafka has acknowledged them, so
+// a failure at any point results in the whole batch being relayed again.
+func (r relay) relayBatch(ctx context.Context) error {
+	b, err := r.consumer.Consume(ctx)
+	if err != nil {
+		return err
+	}
+	defer rollback(ctx, b.tx)
+
+	spanCtx, span := r.tracer.Start(
+		ctx,
+		"relay outbox",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingBatchMessageCount(len(b.events)),
+		),
+	)
+	defer span.End()
+
+	err = r.processor.Process(spanCtx, b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("outbox: failed to publish events: %w", err)
+	}
+
+	err = r.acknowledger.Acknowledge(spanCtx, b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("outbox: failed to delete relayed events: %w", err)
+	}
+
+	for _, event := range b.events {
+		r.eventsRelayed.Add(spanCtx, 1, metric.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(event.Topic),
+		))
+	}
+	return nil
+}
+
+// rollback is safe to call after the transaction has been committed.
+func rollback(ctx context.Context, tx pgx.Tx) {
+	_ = tx.Rollback(context.WithoutCancel(ctx))
+}
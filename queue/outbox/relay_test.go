@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type rowFunc func(dest ...any) error
+
+func (f rowFunc) Scan(dest ...any) error {
+	return f(dest...)
+}
+
+type fakeRows struct {
+	pgx.Rows
+
+	rows [][]any
+	idx  int
+}
+
+func (r *fakeRows) Close() {}
+
+func (r *fakeRows) Err() error { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.idx++
+	return r.idx <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.rows[r.idx-1]
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *int64:
+			*d = row[i].(int64)
+		case *string:
+			*d = row[i].(string)
+		case *[]byte:
+			*d = row[i].([]byte)
+		}
+	}
+	return nil
+}
+
+type fakeTx struct {
+	pgx.Tx
+
+	locked     bool
+	rows       [][]any
+	execErr    error
+	deleted    []int64
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return rowFunc(func(dest ...any) error {
+		*dest[0].(*bool) = tx.locked
+		return nil
+	})
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return &fakeRows{rows: tx.rows}, nil
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx.execErr != nil {
+		return pgconn.CommandTag{}, tx.execErr
+	}
+	tx.deleted = args[0].([]int64)
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if !tx.committed {
+		tx.rolledBack = true
+	}
+	return nil
+}
+
+type txBeginnerFunc func(context.Context) (pgx.Tx, error)
+
+func (f txBeginnerFunc) Begin(ctx context.Context) (pgx.Tx, error) {
+	return f(ctx)
+}
+
+type producerFunc func(context.Context, ...*kgo.Record) kgo.ProduceResults
+
+func (f producerFunc) ProduceSync(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+	return f(ctx, records...)
+}
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish events in order and delete them", func(t *testing.T) {
+		tx := &fakeTx{
+			locked: true,
+			rows: [][]any{
+				{int64(1), "cart-1", "carts", []byte("a"), []byte(`[{"key":"k","value":"dg=="}]`)},
+				{int64(2), "cart-2", "carts", []byte("b"), []byte(`[]`)},
+				{int64(3), "cart-1", "carts", []byte("c"), []byte(nil)},
+			},
+		}
+
+		var published []*kgo.Record
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			published = records
+			return kgo.ProduceResults{}
+		})
+
+		rt := NewRuntime(txBeginnerFunc(func(ctx context.Context) (pgx.Tx, error) {
+			return tx, nil
+		}), nil)
+
+		err := rt.relay(p).relayBatch(t.Context())
+		require.NoError(t, err)
+
+		require.Len(t, published, 3)
+		require.Equal(t, []byte("cart-1"), published[0].Key)
+		require.Equal(t, []byte("a"), published[0].Value)
+		require.Equal(t, "carts", published[0].Topic)
+		require.Equal(t, []kgo.RecordHeader{{Key: "k", Value: []byte("v")}}, published[0].Headers)
+		require.Equal(t, []byte("cart-2"), published[1].Key)
+		require.Equal(t, []byte("cart-1"), published[2].Key)
+
+		require.Equal(t, []int64{1, 2, 3}, tx.deleted)
+		require.True(t, tx.committed)
+	})
+
+	t.Run("should not delete events if publishing fails", func(t *testing.T) {
+		tx := &fakeTx{
+			locked: true,
+			rows: [][]any{
+				{int64(1), "cart-1", "carts", []byte("a"), []byte(`[]`)},
+			},
+		}
+
+		produceErr := errors.New("produce failed")
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			return kgo.ProduceResults{{Record: records[0], Err: produceErr}}
+		})
+
+		rt := NewRuntime(txBeginnerFunc(func(ctx context.Context) (pgx.Tx, error) {
+			return tx, nil
+		}), nil)
+
+		err := rt.relay(p).relayBatch(t.Context())
+		require.ErrorIs(t, err, produceErr)
+		require.Nil(t, tx.deleted)
+		require.False(t, tx.committed)
+		require.True(t, tx.rolledBack)
+	})
+
+	t.Run("should not commit if deleting the events fails", func(t *testing.T) {
+		deleteErr := errors.New("delete failed")
+		tx := &fakeTx{
+			locked:  true,
+			execErr: deleteErr,
+			rows: [][]any{
+				{int64(1), "cart-1", "carts", []byte("a"), []byte(`[]`)},
+			},
+		}
+
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			return kgo.ProduceResults{}
+		})
+
+		rt := NewRuntime(txBeginnerFunc(func(ctx context.Context) (pgx.Tx, error) {
+			return tx, nil
+		}), nil)
+
+		err := rt.relay(p).relayBatch(t.Context())
+		require.ErrorIs(t, err, deleteErr)
+		require.False(t, tx.committed)
+		require.True(t, tx.rolledBack)
+	})
+
+	t.Run("should skip relaying if another replica holds the lock", func(t *testing.T) {
+		tx := &fakeTx{
+			locked: false,
+			rows: [][]any{
+				{int64(1), "cart-1", "carts", []byte("a"), []byte(`[]`)},
+			},
+		}
+
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			require.Fail(t, "should not be called")
+			return nil
+		})
+
+		rt := NewRuntime(txBeginnerFunc(func(ctx context.Context) (pgx.Tx, error) {
+			return tx, nil
+		}), nil)
+
+		err := rt.relay(p).relayBatch(t.Context())
+		require.ErrorIs(t, err, errNoEvents)
+		require.True(t, tx.rolledBack)
+	})
+
+	t.Run("should keep polling until the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		var polls atomic.Int64
+		db := txBeginnerFunc(func(ctx context.Context) (pgx.Tx, error) {
+			if polls.Add(1) >= 3 {
+				cancel()
+			}
+			return &fakeTx{locked: true}, nil
+		})
+
+		p := producerFunc(func(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+			require.Fail(t, "should not be called")
+			return nil
+		})
+
+		rt := NewRuntime(db, nil, PollInterval(time.Millisecond))
+
+		err := rt.relay(p).run(ctx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, polls.Load(), int64(3))
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"time"
+
+	"github.com/z5labs/humus"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/plugin/kotel"
+	"github.com/twmb/franz-go/plugin/kslog"
+	"go.opentelemetry.io/otel"
+)
+
+// TxBeginner is implemented by *pgxpool.Pool and *pgx.Conn.
+type TxBeginner interface {
+	Begin(context.Context) (pgx.Tx, error)
+}
+
+// Options represents configuration options for the outbox runtime.
+type Options struct {
+	table        string
+	batchSize    int
+	pollInterval time.Duration
+	lockKey      int64
+	tlsConfig    *tls.Config
+}
+
+// Option defines a function type for configuring outbox runtime options.
+type Option func(*Options)
+
+// Table sets the name of the outbox table to relay events from.
+// Default is [DefaultTable] if not set.
+func Table(name string) Option {
+	return func(o *Options) {
+		o.table = name
+	}
+}
+
+// BatchSize sets the maximum number of events relayed per transaction.
+// Default is 100 if not set.
+func BatchSize(n int) Option {
+	return func(o *Options) {
+		o.batchSize = n
+	}
+}
+
+// PollInterval sets how long the runtime waits before polling the outbox
+// table again after finding it empty or failing to relay a batch.
+// Default is 1 second if not set.
+func PollInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.pollInterval = d
+	}
+}
+
+// LockKey sets the PostgreSQL advisory lock key used to elect a single relay
+// across replicas. Default is derived from the table name if not set.
+func LockKey(key int64) Option {
+	return func(o *Options) {
+		o.lockKey = key
+	}
+}
+
+// WithTLS configures TLS/mTLS for secure connections to Kafka brokers.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *Options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// Runtime relays events from a PostgreSQL outbox table to Kafka.
+type Runtime struct {
+	log          *slog.Logger
+	db           TxBeginner
+	brokers      []string
+	table        string
+	batchSize    int
+	pollInterval time.Duration
+	lockKey      int64
+	tlsConfig    *tls.Config
+}
+
+// NewRuntime creates a new outbox runtime which relays events written to the
+// outbox table in db to the provided Kafka brokers.
+func NewRuntime(
+	db TxBeginner,
+	brokers []string,
+	opts ...Option,
+) Runtime {
+	cfg := &Options{
+		table:        DefaultTable,
+		batchSize:    100,
+		pollInterval: time.Second,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.lockKey == 0 {
+		cfg.lockKey = lockKeyFor(cfg.table)
+	}
+
+	return Runtime{
+		log:          logger().With(TableAttr(cfg.table)),
+		db:           db,
+		brokers:      brokers,
+		table:        cfg.table,
+		batchSize:    cfg.batchSize,
+		pollInterval: cfg.pollInterval,
+		lockKey:      cfg.lockKey,
+		tlsConfig:    cfg.tlsConfig,
+	}
+}
+
+// ProcessQueue starts relaying events from the outbox table to Kafka.
+func (r Runtime) ProcessQueue(ctx context.Context) error {
+	clientOpts := []kgo.Opt{
+		kgo.WithLogger(kslog.New(humus.Logger("github.com/twmb/franz-go/pkg/kgo"))),
+		kgo.WithHooks(
+			kotel.NewTracer(
+				kotel.TracerProvider(otel.GetTracerProvider()),
+				kotel.TracerPropagator(otel.GetTextMapPropagator()),
+			),
+			kotel.NewMeter(
+				kotel.MeterProvider(otel.GetMeterProvider()),
+				kotel.WithMergedConnectsMeter(),
+			),
+		),
+		kgo.SeedBrokers(r.brokers...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+	}
+
+	// Configure TLS if provided
+	if r.tlsConfig != nil {
+		clientOpts = append(clientOpts, kgo.DialTLSConfig(r.tlsConfig))
+	}
+
+	client, err := kgo.NewClient(clientOpts...)
+	if err != nil {
+		return fmt.Errorf("outbox: failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	return r.relay(client).run(ctx)
+}
+
+func (r Runtime) relay(p producer) relay {
+	metrics := initRelayMetrics(r.log)
+
+	return relay{
+		log:          r.log,
+		tracer:       tracer(),
+		pollInterval: r.pollInterval,
+		consumer: &batchConsumer{
+			db:          r.db,
+			lockKey:     r.lockKey,
+			selectQuery: selectQuery(r.table),
+			batchSize:   r.batchSize,
+		},
+		processor: batchPublisher{
+			producer: p,
+		},
+		acknowledger: batchDeleter{
+			deleteQuery: deleteQuery(r.table),
+		},
+		eventsRelayed: metrics.eventsRelayed,
+	}
+}
+
+func lockKeyFor(table string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("humus/outbox:" + table))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package outbox
+
+import "log/slog"
+
+// TableAttr returns a slog attribute for the outbox table name.
+func TableAttr(table string) slog.Attr {
+	return slog.String("db.collection.name", table)
+}
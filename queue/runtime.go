@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type deliverySemantics int
+
+const (
+	atLeastOnce deliverySemantics = iota
+	atMostOnce
+)
+
+// Options represents configuration options for the runtime returned by [NewRuntime].
+type Options struct {
+	semantics   deliverySemantics
+	concurrency int
+}
+
+// Option defines a function type for configuring runtime options.
+type Option func(*Options)
+
+// AtLeastOnce configures the runtime to acknowledge messages only after they
+// have been successfully processed (consume, process, acknowledge).
+//
+// Messages which fail processing are never acknowledged, leaving redelivery up
+// to the underlying queue. Processors must therefore be idempotent.
+//
+// This is the default delivery semantics.
+func AtLeastOnce() Option {
+	return func(o *Options) {
+		o.semantics = atLeastOnce
+	}
+}
+
+// AtMostOnce configures the runtime to acknowledge messages before they are
+// processed (consume, acknowledge, process).
+//
+// If processing fails, the message is lost since it has already been acknowledged.
+// If acknowledging fails, the message is not processed.
+func AtMostOnce() Option {
+	return func(o *Options) {
+		o.semantics = atMostOnce
+	}
+}
+
+// Concurrency sets the number of workers processing messages concurrently.
+// Messages are consumed sequentially and handed off to the next available
+// worker, so no ordering guarantees are made when n is greater than 1.
+// Default is 1 if not set.
+func Concurrency(n int) Option {
+	return func(o *Options) {
+		o.concurrency = n
+	}
+}
+
+type runtime[T any] struct {
+	log          *slog.Logger
+	tracer       trace.Tracer
+	consumer     Consumer[T]
+	processor    Processor[T]
+	acknowledger Acknowledger[T]
+	semantics    deliverySemantics
+	concurrency  int
+	metrics      runtimeMetrics
+}
+
+// NewRuntime creates a [Runtime] which consumes messages from the [Consumer],
+// processes them with the [Processor] and confirms them with the [Acknowledger],
+// in the order dictated by the configured delivery semantics.
+//
+// The runtime stops gracefully when the context is cancelled or the [Consumer]
+// returns [ErrEndOfQueue]. Any other error returned by the [Consumer] stops the
+// runtime once in-flight messages have finished processing. Processing and
+// acknowledgement errors are recorded but do not stop the runtime.
+func NewRuntime[T any](
+	consumer Consumer[T],
+	processor Processor[T],
+	acknowledger Acknowledger[T],
+	opts ...Option,
+) Runtime {
+	cfg := &Options{
+		semantics:   atLeastOnce,
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.concurrency < 1 {
+		panic("queue: concurrency must be at least 1")
+	}
+
+	log := logger()
+
+	return runtime[T]{
+		log:          log,
+		tracer:       tracer(),
+		consumer:     consumer,
+		processor:    processor,
+		acknowledger: acknowledger,
+		semantics:    cfg.semantics,
+		concurrency:  cfg.concurrency,
+		metrics:      initRuntimeMetrics(log),
+	}
+}
+
+// ProcessQueue implements the [Runtime] interface.
+func (rt runtime[T]) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	msgCh := make(chan T)
+	p.Go(rt.consume(msgCh))
+
+	for range rt.concurrency {
+		p.Go(rt.work(msgCh))
+	}
+
+	return p.Wait()
+}
+
+func (rt runtime[T]) consume(msgCh chan<- T) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(msgCh)
+
+		for {
+			msg, err := rt.consumer.Consume(ctx)
+			if errors.Is(err, ErrEndOfQueue) {
+				rt.log.InfoContext(ctx, "encountered end of queue")
+				return nil
+			}
+			if err != nil && ctx.Err() != nil {
+				rt.log.WarnContext(ctx, "context cancelled while consuming message", slog.Any("error", ctx.Err()))
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				rt.log.WarnContext(ctx, "context cancelled while consuming message", slog.Any("error", ctx.Err()))
+				return nil
+			case msgCh <- msg:
+			}
+		}
+	}
+}
+
+func (rt runtime[T]) work(msgCh <-chan T) func(context.Context) error {
+	return func(ctx context.Context) error {
+		for msg := range msgCh {
+			rt.handle(ctx, msg)
+		}
+		return nil
+	}
+}
+
+func (rt runtime[T]) handle(ctx context.Context, msg T) {
+	spanCtx, span := rt.tracer.Start(
+		ctx,
+		"process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(semconv.MessagingOperationTypeProcess),
+	)
+	defer span.End()
+
+	switch rt.semantics {
+	case atMostOnce:
+		if !rt.acknowledge(spanCtx, span, msg) {
+			return
+		}
+		rt.process(spanCtx, span, msg)
+	default:
+		if !rt.process(spanCtx, span, msg) {
+			return
+		}
+		rt.acknowledge(spanCtx, span, msg)
+	}
+}
+
+func (rt runtime[T]) process(ctx context.Context, span trace.Span, msg T) bool {
+	err := rt.processor.Process(ctx, msg)
+	rt.metrics.messagesProcessed.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("messaging.process.status", processStatus(err)),
+	))
+	if err == nil {
+		return true
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	rt.log.ErrorContext(ctx, "failed to process message", slog.Any("error", err))
+	return false
+}
+
+func (rt runtime[T]) acknowledge(ctx context.Context, span trace.Span, msg T) bool {
+	err := rt.acknowledger.Acknowledge(ctx, msg)
+	if err == nil {
+		rt.metrics.messagesAcknowledged.Add(ctx, 1)
+		return true
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	rt.log.ErrorContext(ctx, "failed to acknowledge message", slog.Any("error", err))
+	return false
+}
+
+func processStatus(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
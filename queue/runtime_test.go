@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestNewRuntime(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		opts         []Option
+		processErr   error
+		ackErr       error
+		expectEvents []string
+	}{
+		{
+			name:         "should acknowledge after processing by default",
+			expectEvents: []string{"process 1", "ack 1", "process 2", "ack 2"},
+		},
+		{
+			name:         "should acknowledge after processing with at-least-once",
+			opts:         []Option{AtLeastOnce()},
+			expectEvents: []string{"process 1", "ack 1", "process 2", "ack 2"},
+		},
+		{
+			name:         "should not acknowledge if processing fails with at-least-once",
+			opts:         []Option{AtLeastOnce()},
+			processErr:   errors.New("process failed"),
+			expectEvents: []string{"process 1", "process 2"},
+		},
+		{
+			name:         "should acknowledge before processing with at-most-once",
+			opts:         []Option{AtMostOnce()},
+			expectEvents: []string{"ack 1", "process 1", "ack 2", "process 2"},
+		},
+		{
+			name:         "should not process if acknowledging fails with at-most-once",
+			opts:         []Option{AtMostOnce()},
+			ackErr:       errors.New("ack failed"),
+			expectEvents: []string{"ack 1", "ack 2"},
+		},
+		{
+			name:         "should still acknowledge if processing fails with at-most-once",
+			opts:         []Option{AtMostOnce()},
+			processErr:   errors.New("process failed"),
+			expectEvents: []string{"ack 1", "process 1", "ack 2", "process 2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rec recorder
+
+			rt := NewRuntime(
+				NewSliceConsumer("1", "2"),
+				ProcessorFunc[string](func(ctx context.Context, msg string) error {
+					rec.record("process " + msg)
+					return tc.processErr
+				}),
+				AcknowledgerFunc[string](func(ctx context.Context, msg string) error {
+					rec.record("ack " + msg)
+					return tc.ackErr
+				}),
+				tc.opts...,
+			)
+
+			err := rt.ProcessQueue(t.Context())
+			require.NoError(t, err)
+			require.Equal(t, tc.expectEvents, rec.events)
+		})
+	}
+
+	t.Run("should process every message with multiple workers", func(t *testing.T) {
+		msgs := make([]int, 100)
+		for i := range msgs {
+			msgs[i] = i
+		}
+
+		var processed, acked atomic.Int64
+		rt := NewRuntime(
+			NewSliceConsumer(msgs...),
+			ProcessorFunc[int](func(ctx context.Context, msg int) error {
+				processed.Add(1)
+				return nil
+			}),
+			AcknowledgerFunc[int](func(ctx context.Context, msg int) error {
+				acked.Add(1)
+				return nil
+			}),
+			Concurrency(10),
+		)
+
+		err := rt.ProcessQueue(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, int64(100), processed.Load())
+		require.Equal(t, int64(100), acked.Load())
+	})
+
+	t.Run("should return the consumer error", func(t *testing.T) {
+		consumeErr := errors.New("consume failed")
+
+		rt := NewRuntime(
+			ConsumerFunc[int](func(ctx context.Context) (int, error) {
+				return 0, consumeErr
+			}),
+			ProcessorFunc[int](func(ctx context.Context, msg int) error {
+				require.Fail(t, "should not be called")
+				return nil
+			}),
+			AcknowledgerFunc[int](func(ctx context.Context, msg int) error {
+				require.Fail(t, "should not be called")
+				return nil
+			}),
+		)
+
+		err := rt.ProcessQueue(t.Context())
+		require.ErrorIs(t, err, consumeErr)
+	})
+
+	t.Run("should shutdown if context is cancelled while consuming", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		ch := make(chan int)
+		rt := NewRuntime(
+			NewChannelConsumer(ch),
+			ProcessorFunc[int](func(ctx context.Context, msg int) error {
+				cancel()
+				return nil
+			}),
+			AcknowledgerFunc[int](func(ctx context.Context, msg int) error {
+				return nil
+			}),
+		)
+
+		go func() {
+			ch <- 1
+		}()
+
+		err := rt.ProcessQueue(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("should panic if concurrency is less than 1", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewRuntime(
+				NewSliceConsumer[int](),
+				ProcessorFunc[int](func(ctx context.Context, msg int) error { return nil }),
+				AcknowledgerFunc[int](func(ctx context.Context, msg int) error { return nil }),
+				Concurrency(0),
+			)
+		})
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// AtLeastOnce configures the SQS runtime to process messages with at-least-once
+// delivery semantics (process before delete).
+//
+// Messages are only deleted once they have been successfully processed. Messages
+// which fail processing become visible again once their visibility timeout expires
+// and are redelivered, until the queue's redrive policy moves them to a dead-letter
+// queue. Processors must therefore be idempotent.
+func AtLeastOnce(processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.orchestrator = atLeastOnceOrchestrator{processor: processor}
+	}
+}
+
+type atLeastOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atLeastOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atLeastOnceRuntime{
+		log:          orc.log,
+		queueName:    orc.queueName,
+		consumer:     orc.consumer,
+		acknowledger: orc.acknowledger,
+		extender:     orc.extender,
+		concurrency:  orc.concurrency,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			queueURL:          orc.extender.queueURL,
+			queueName:         orc.queueName,
+			redrive:           orc.redrive,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atLeastOnceRuntime struct {
+	log               *slog.Logger
+	queueName         string
+	consumer          queue.Consumer[[]types.Message]
+	processor         messageProcessor
+	acknowledger      queue.Acknowledger[[]types.Message]
+	extender          visibilityExtender
+	concurrency       int
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atLeastOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	batchCh := make(chan hiddenBatch)
+	p.Go(consumeBatches(rt.log, hidingConsumer{consumer: rt.consumer, extender: rt.extender}, batchCh))
+
+	processedCh := make(chan []types.Message)
+	p.Go(rt.processBatches(processedCh, batchCh))
+
+	p.Go(rt.acknowledgeBatches(processedCh))
+
+	return p.Wait()
+}
+
+// hiddenBatch is a received batch whose messages are kept hidden from other
+// consumers until they are released or the batch is stopped.
+type hiddenBatch struct {
+	msgs    []types.Message
+	release func(types.Message)
+	stop    func()
+}
+
+// hidingConsumer starts extending the visibility timeout of every batch as
+// soon as it is received, so messages waiting to be processed, including
+// those of the next batch, are not redelivered.
+type hidingConsumer struct {
+	consumer queue.Consumer[[]types.Message]
+	extender visibilityExtender
+}
+
+func (c hidingConsumer) Consume(ctx context.Context) (hiddenBatch, error) {
+	msgs, err := c.consumer.Consume(ctx)
+	if err != nil {
+		return hiddenBatch{}, err
+	}
+	release, stop := c.extender.extend(ctx, msgs)
+	return hiddenBatch{msgs: msgs, release: release, stop: stop}, nil
+}
+
+func (rt atLeastOnceRuntime) processBatches(processedCh chan<- []types.Message, batchCh <-chan hiddenBatch) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(processedCh)
+
+		for batch := range batchCh {
+			var (
+				mu        sync.Mutex
+				processed []types.Message
+			)
+
+			// Processed messages are kept hidden until they are handed
+			// over to be deleted, while failed ones are released so they
+			// become visible again once their visibility timeout expires.
+			p := pool.New().WithMaxGoroutines(rt.concurrency)
+			for _, msg := range batch.msgs {
+				p.Go(func() {
+					err := rt.processor.process(ctx, msg)
+					if err != nil {
+						batch.release(msg)
+						return
+					}
+
+					mu.Lock()
+					defer mu.Unlock()
+					processed = append(processed, msg)
+				})
+			}
+			p.Wait()
+			batch.stop()
+
+			if len(processed) == 0 {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				rt.log.WarnContext(
+					ctx,
+					"context cancelled while processing messages",
+					slog.Any("error", ctx.Err()),
+				)
+				return nil
+			case processedCh <- processed:
+			}
+		}
+
+		return nil
+	}
+}
+
+func (rt atLeastOnceRuntime) acknowledgeBatches(processedCh <-chan []types.Message) func(context.Context) error {
+	return func(ctx context.Context) error {
+		for batch := range processedCh {
+			// Processed messages should still be deleted during shutdown
+			// to avoid needlessly redelivering them.
+			err := rt.acknowledger.Acknowledge(context.WithoutCancel(ctx), batch)
+			if err != nil {
+				rt.log.ErrorContext(
+					ctx,
+					"failed to delete sqs messages",
+					slog.Any("error", err),
+				)
+			}
+
+			rt.messagesCommitted.Add(ctx, int64(len(batch)-len(undeletedMessages(err))), metric.WithAttributes(
+				semconv.MessagingSystemAWSSQS,
+				semconv.MessagingDestinationName(rt.queueName),
+			))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// AtMostOnce configures the SQS runtime to process messages with at-most-once
+// delivery semantics (delete before process).
+//
+// Messages are deleted from the queue as soon as they are received, so a message
+// which fails processing is lost. Messages which fail to be deleted are not
+// processed and will be redelivered once their visibility timeout expires.
+func AtMostOnce(processor queue.Processor[Message]) Option {
+	return func(o *Options) {
+		o.orchestrator = atMostOnceOrchestrator{processor: processor}
+	}
+}
+
+type atMostOnceOrchestrator struct {
+	processor queue.Processor[Message]
+}
+
+func (o atMostOnceOrchestrator) Orchestrate(orc orchestration) queue.Runtime {
+	metrics := initConsumerMetrics(orc.log)
+
+	return atMostOnceRuntime{
+		log:          orc.log,
+		queueName:    orc.queueName,
+		consumer:     orc.consumer,
+		acknowledger: orc.acknowledger,
+		concurrency:  orc.concurrency,
+		processor: messageProcessor{
+			log:               orc.log,
+			tracer:            tracer(),
+			queueURL:          orc.extender.queueURL,
+			queueName:         orc.queueName,
+			redrive:           orc.redrive,
+			processor:         o.processor,
+			messagesProcessed: metrics.messagesProcessed,
+		},
+		messagesCommitted: metrics.messagesCommitted,
+	}
+}
+
+type atMostOnceRuntime struct {
+	log               *slog.Logger
+	queueName         string
+	consumer          queue.Consumer[[]types.Message]
+	processor         messageProcessor
+	acknowledger      queue.Acknowledger[[]types.Message]
+	concurrency       int
+	messagesCommitted metric.Int64Counter
+}
+
+func (rt atMostOnceRuntime) ProcessQueue(ctx context.Context) error {
+	p := pool.New().WithContext(ctx)
+
+	batchCh := make(chan []types.Message)
+	p.Go(consumeBatches(rt.log, rt.consumer, batchCh))
+
+	msgCh := make(chan types.Message)
+	p.Go(rt.acknowledgeBatches(msgCh, batchCh))
+
+	p.Go(rt.processMessages(msgCh))
+
+	return p.Wait()
+}
+
+func (rt atMostOnceRuntime) acknowledgeBatches(msgCh chan<- types.Message, batchCh <-chan []types.Message) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(msgCh)
+
+		for batch := range batchCh {
+			err := rt.acknowledger.Acknowledge(ctx, batch)
+			if err != nil {
+				rt.log.ErrorContext(
+					ctx,
+					"failed to delete sqs messages",
+					slog.Any("error", err),
+				)
+			}
+
+			undeleted := undeletedMessages(err)
+			rt.messagesCommitted.Add(ctx, int64(len(batch)-len(undeleted)), metric.WithAttributes(
+				semconv.MessagingSystemAWSSQS,
+				semconv.MessagingDestinationName(rt.queueName),
+			))
+
+			for _, msg := range batch {
+				if undeleted[aws.ToString(msg.MessageId)] {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					rt.log.WarnContext(
+						ctx,
+						"context cancelled after deleting messages",
+						slog.Any("error", ctx.Err()),
+					)
+					return nil
+				case msgCh <- msg:
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func (rt atMostOnceRuntime) processMessages(msgCh <-chan types.Message) func(context.Context) error {
+	return func(ctx context.Context) error {
+		p := pool.New().WithMaxGoroutines(rt.concurrency)
+
+		for msg := range msgCh {
+			p.Go(func() {
+				rt.processor.process(ctx, msg)
+			})
+		}
+
+		p.Wait()
+		return nil
+	}
+}
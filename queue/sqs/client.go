@@ -0,0 +1,294 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+type receiver struct {
+	log               *slog.Logger
+	client            Client
+	queueURL          string
+	maxMessages       int32
+	waitTime          time.Duration
+	visibilityTimeout time.Duration
+}
+
+// Consume long polls the queue until at least one message is received.
+func (r *receiver) Consume(ctx context.Context) ([]types.Message, error) {
+	for {
+		out, err := r.client.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(r.queueURL),
+			MaxNumberOfMessages:   r.maxMessages,
+			WaitTimeSeconds:       int32(r.waitTime / time.Second),
+			VisibilityTimeout:     int32(r.visibilityTimeout / time.Second),
+			MessageAttributeNames: []string{"All"},
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+				types.MessageSystemAttributeNameApproximateReceiveCount,
+				types.MessageSystemAttributeNameSentTimestamp,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sqs: failed to receive messages: %w", err)
+		}
+		if len(out.Messages) > 0 {
+			return out.Messages, nil
+		}
+	}
+}
+
+type batchDeleter struct {
+	client   Client
+	queueURL string
+}
+
+// maxBatchEntries is the maximum number of entries accepted by SQS batch APIs.
+const maxBatchEntries = 10
+
+// Acknowledge deletes the messages from the queue using as few batch requests as possible.
+func (d *batchDeleter) Acknowledge(ctx context.Context, msgs []types.Message) error {
+	var errs []error
+	for start := 0; start < len(msgs); start += maxBatchEntries {
+		end := min(start+maxBatchEntries, len(msgs))
+
+		entries := make([]types.DeleteMessageBatchRequestEntry, 0, end-start)
+		for i, msg := range msgs[start:end] {
+			entries = append(entries, types.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(i)),
+				ReceiptHandle: msg.ReceiptHandle,
+			})
+		}
+
+		out, err := d.client.DeleteMessageBatch(ctx, &awssqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(d.queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			for _, msg := range msgs[start:end] {
+				errs = append(errs, &DeleteError{
+					MessageID: aws.ToString(msg.MessageId),
+					Err:       err,
+				})
+			}
+			continue
+		}
+		for _, failed := range out.Failed {
+			errs = append(errs, &DeleteError{
+				MessageID: aws.ToString(msgs[start+entryIndex(failed.Id)].MessageId),
+				Err:       fmt.Errorf("%s: %s", aws.ToString(failed.Code), aws.ToString(failed.Message)),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func entryIndex(id *string) int {
+	i, _ := strconv.Atoi(aws.ToString(id))
+	return i
+}
+
+// DeleteError is returned when SQS fails to delete a message.
+type DeleteError struct {
+	MessageID string
+	Err       error
+}
+
+// Error implements the [error] interface.
+func (e *DeleteError) Error() string {
+	return fmt.Sprintf("sqs: failed to delete message %s: %s", e.MessageID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DeleteError) Unwrap() error {
+	return e.Err
+}
+
+// undeletedMessages returns the IDs of the messages which failed
+// to be deleted according to the error returned by [batchDeleter].
+func undeletedMessages(err error) map[string]bool {
+	undeleted := make(map[string]bool)
+	if err == nil {
+		return undeleted
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err := range errs {
+		var de *DeleteError
+		if errors.As(err, &de) {
+			undeleted[de.MessageID] = true
+		}
+	}
+	return undeleted
+}
+
+type visibilityExtender struct {
+	log      *slog.Logger
+	client   Client
+	queueURL string
+	timeout  time.Duration
+}
+
+// extend keeps the messages of a batch hidden from other consumers by
+// periodically resetting their visibility timeout, both while they are
+// processed and while they wait to be. Messages stop being extended once
+// they are released or the returned stop func is called.
+func (e visibilityExtender) extend(ctx context.Context, batch []types.Message) (release func(types.Message), stop func()) {
+	if e.timeout < 2*time.Second {
+		return func(types.Message) {}, func() {}
+	}
+
+	var mu sync.Mutex
+	pending := make(map[string]types.Message, len(batch))
+	for _, msg := range batch {
+		pending[aws.ToString(msg.ReceiptHandle)] = msg
+	}
+	release = func(msg types.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(pending, aws.ToString(msg.ReceiptHandle))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(e.timeout / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			mu.Lock()
+			msgs := slices.Collect(maps.Values(pending))
+			mu.Unlock()
+
+			for _, msg := range msgs {
+				e.changeVisibility(ctx, msg)
+			}
+		}
+	}()
+
+	return release, func() {
+		cancel()
+		<-done
+	}
+}
+
+func (e visibilityExtender) changeVisibility(ctx context.Context, msg types.Message) {
+	_, err := e.client.ChangeMessageVisibility(ctx, &awssqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(e.queueURL),
+		ReceiptHandle:     msg.ReceiptHandle,
+		VisibilityTimeout: int32(e.timeout / time.Second),
+	})
+	if err != nil && ctx.Err() == nil {
+		e.log.WarnContext(
+			ctx,
+			"failed to extend message visibility timeout",
+			MessageIDAttr(aws.ToString(msg.MessageId)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+type redrivePolicy struct {
+	deadLetterTargetArn string
+	maxReceiveCount     int
+}
+
+// loadRedrivePolicy fetches the dead-letter queue configuration of the queue.
+// Failing to load it only disables dead-letter awareness so errors are logged
+// instead of returned.
+func loadRedrivePolicy(ctx context.Context, log *slog.Logger, client Client, queueURL string) redrivePolicy {
+	out, err := client.GetQueueAttributes(ctx, &awssqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameRedrivePolicy},
+	})
+	if err != nil {
+		log.WarnContext(ctx, "failed to get queue redrive policy", slog.Any("error", err))
+		return redrivePolicy{}
+	}
+
+	raw, ok := out.Attributes[string(types.QueueAttributeNameRedrivePolicy)]
+	if !ok {
+		return redrivePolicy{}
+	}
+
+	var policy struct {
+		DeadLetterTargetArn string          `json:"deadLetterTargetArn"`
+		MaxReceiveCount     json.RawMessage `json:"maxReceiveCount"`
+	}
+	err = json.Unmarshal([]byte(raw), &policy)
+	if err != nil {
+		log.WarnContext(ctx, "failed to parse queue redrive policy", slog.Any("error", err))
+		return redrivePolicy{}
+	}
+
+	// SQS returns maxReceiveCount as either a JSON number or string.
+	maxReceiveCount, err := strconv.Atoi(strings.Trim(string(policy.MaxReceiveCount), `"`))
+	if err != nil {
+		log.WarnContext(ctx, "failed to parse queue redrive policy", slog.Any("error", err))
+		return redrivePolicy{}
+	}
+
+	return redrivePolicy{
+		deadLetterTargetArn: policy.DeadLetterTargetArn,
+		maxReceiveCount:     maxReceiveCount,
+	}
+}
+
+func toMessage(queueURL string, redrive redrivePolicy, msg types.Message) Message {
+	attrs := make(map[string]MessageAttribute, len(msg.MessageAttributes))
+	for name, attr := range msg.MessageAttributes {
+		attrs[name] = MessageAttribute{
+			DataType:    aws.ToString(attr.DataType),
+			StringValue: aws.ToString(attr.StringValue),
+			BinaryValue: attr.BinaryValue,
+		}
+	}
+
+	m := Message{
+		ID:              aws.ToString(msg.MessageId),
+		ReceiptHandle:   aws.ToString(msg.ReceiptHandle),
+		Body:            aws.ToString(msg.Body),
+		Attributes:      attrs,
+		QueueURL:        queueURL,
+		MaxReceiveCount: redrive.maxReceiveCount,
+	}
+
+	if v, ok := msg.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)]; ok {
+		m.ReceiveCount, _ = strconv.Atoi(v)
+	}
+	if v, ok := msg.Attributes[string(types.MessageSystemAttributeNameSentTimestamp)]; ok {
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			m.SentTimestamp = time.UnixMilli(ms)
+		}
+	}
+	return m
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package sqs provides an AWS SQS runtime implementation for the Humus queue framework.
+//
+// The runtime works with any SQS-compatible service reachable through the AWS SDK
+// (Amazon SQS, LocalStack, ElasticMQ, etc.) and offers at-most-once and at-least-once
+// delivery semantics.
+//
+// # Usage
+//
+//	cfg, err := config.LoadDefaultConfig(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	runtime := sqs.NewRuntime(
+//	    awssqs.NewFromConfig(cfg),
+//	    "https://sqs.us-east-1.amazonaws.com/123456789012/orders",
+//	    sqs.AtLeastOnce(processor),
+//	    sqs.Concurrency(5),
+//	)
+//	app := queue.NewApp(runtime)
+//
+// # Receiving
+//
+// Messages are received in batches of up to 10 using long polling (see [WaitTime]).
+// Until a received message has been processed with at-least-once semantics, its
+// visibility timeout is periodically extended so neither long running processors nor
+// messages waiting for a processor cause the message to be redelivered to another
+// consumer.
+//
+// # Deleting
+//
+// Messages are deleted using the DeleteMessageBatch API. With at-least-once
+// semantics only successfully processed messages are deleted; with at-most-once
+// semantics every received message is deleted before it is processed.
+//
+// # Dead-Letter Queues
+//
+// The queue's redrive policy is loaded at startup. Every [Message] carries its
+// ReceiveCount and the MaxReceiveCount after which SQS moves it to the
+// dead-letter queue, allowing processors to react to a final delivery attempt.
+// Failures on the final attempt are logged and flagged on the processing span.
+//
+// # OpenTelemetry Instrumentation
+//
+// Every message is processed within a consumer span named "process <queue>".
+// Trace context propagated through message attributes is linked to the span.
+//
+// The following metrics are automatically collected:
+//
+//	messaging.client.messages.processed - Total number of SQS messages processed
+//	  Labels: messaging.system, messaging.destination.name (queue), messaging.process.status
+//	  Unit: {message}
+//
+//	messaging.client.messages.committed - Total number of SQS messages successfully deleted
+//	  Labels: messaging.system, messaging.destination.name (queue)
+//	  Unit: {message}
+package sqs
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+type fakeMessage struct {
+	id            string
+	body          string
+	attributes    map[string]string
+	receiveCount  int
+	receiptHandle string
+	visibleAt     time.Time
+	sentAt        time.Time
+}
+
+// fakeSQS is an in-process fake of the SQS JSON HTTP API supporting the
+// operations used by the runtime.
+type fakeSQS struct {
+	mu                sync.Mutex
+	nextID            int
+	messages          []*fakeMessage
+	redrivePolicy     string
+	visibilityChanges int
+	deleteRequests    int
+}
+
+func newFakeSQS(t *testing.T) (*fakeSQS, *awssqs.Client) {
+	t.Helper()
+
+	fake := &fakeSQS{}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client := awssqs.New(awssqs.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	})
+	return fake, client
+}
+
+const fakeQueueURL = "http://localhost/000000000000/test-queue"
+
+func (f *fakeSQS) send(body string, attributes map[string]string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	id := "msg-" + strconv.Itoa(f.nextID)
+	f.messages = append(f.messages, &fakeMessage{
+		id:         id,
+		body:       body,
+		attributes: attributes,
+		sentAt:     time.Now(),
+	})
+	return id
+}
+
+func (f *fakeSQS) messageIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, len(f.messages))
+	for i, m := range f.messages {
+		ids[i] = m.id
+	}
+	return ids
+}
+
+func (f *fakeSQS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.")
+
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var resp any
+	switch op {
+	case "ReceiveMessage":
+		resp = f.receiveMessage(r, req)
+	case "DeleteMessageBatch":
+		resp = f.deleteMessageBatch(req)
+	case "ChangeMessageVisibility":
+		resp = f.changeMessageVisibility(req)
+	case "GetQueueAttributes":
+		resp = f.getQueueAttributes()
+	default:
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "com.amazonaws.sqs#UnsupportedOperation",
+			"message": op,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeSQS) receiveMessage(r *http.Request, req map[string]any) any {
+	maxMessages := intField(req, "MaxNumberOfMessages", 1)
+	waitTime := time.Duration(intField(req, "WaitTimeSeconds", 0)) * time.Second
+	visibilityTimeout := time.Duration(intField(req, "VisibilityTimeout", 30)) * time.Second
+
+	deadline := time.Now().Add(waitTime)
+	for {
+		msgs := f.receiveVisible(maxMessages, visibilityTimeout)
+		if len(msgs) > 0 || time.Now().After(deadline) {
+			return map[string]any{"Messages": msgs}
+		}
+
+		select {
+		case <-r.Context().Done():
+			return map[string]any{}
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func (f *fakeSQS) receiveVisible(maxMessages int, visibilityTimeout time.Duration) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	var msgs []map[string]any
+	for _, m := range f.messages {
+		if len(msgs) == maxMessages {
+			break
+		}
+		if now.Before(m.visibleAt) {
+			continue
+		}
+
+		m.receiveCount++
+		m.receiptHandle = m.id + "-" + strconv.Itoa(m.receiveCount)
+		m.visibleAt = now.Add(visibilityTimeout)
+
+		attrs := make(map[string]any, len(m.attributes))
+		for k, v := range m.attributes {
+			attrs[k] = map[string]string{"DataType": "String", "StringValue": v}
+		}
+
+		msgs = append(msgs, map[string]any{
+			"MessageId":     m.id,
+			"ReceiptHandle": m.receiptHandle,
+			"Body":          m.body,
+			"Attributes": map[string]string{
+				"ApproximateReceiveCount": strconv.Itoa(m.receiveCount),
+				"SentTimestamp":           strconv.FormatInt(m.sentAt.UnixMilli(), 10),
+			},
+			"MessageAttributes": attrs,
+		})
+	}
+	return msgs
+}
+
+func (f *fakeSQS) deleteMessageBatch(req map[string]any) any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.deleteRequests++
+
+	var (
+		successful []map[string]string
+		failed     []map[string]any
+	)
+	for _, e := range req["Entries"].([]any) {
+		entry := e.(map[string]any)
+		id := entry["Id"].(string)
+		handle := entry["ReceiptHandle"].(string)
+
+		idx := -1
+		for i, m := range f.messages {
+			if m.receiptHandle == handle {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			failed = append(failed, map[string]any{
+				"Id":          id,
+				"Code":        "ReceiptHandleIsInvalid",
+				"Message":     "receipt handle is invalid",
+				"SenderFault": true,
+			})
+			continue
+		}
+
+		f.messages = append(f.messages[:idx], f.messages[idx+1:]...)
+		successful = append(successful, map[string]string{"Id": id})
+	}
+	return map[string]any{"Successful": successful, "Failed": failed}
+}
+
+func (f *fakeSQS) changeMessageVisibility(req map[string]any) any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.visibilityChanges++
+
+	handle := req["ReceiptHandle"].(string)
+	timeout := time.Duration(intField(req, "VisibilityTimeout", 0)) * time.Second
+	for _, m := range f.messages {
+		if m.receiptHandle == handle {
+			m.visibleAt = time.Now().Add(timeout)
+		}
+	}
+	return map[string]any{}
+}
+
+func (f *fakeSQS) getQueueAttributes() any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	attrs := map[string]string{}
+	if f.redrivePolicy != "" {
+		attrs["RedrivePolicy"] = f.redrivePolicy
+	}
+	return map[string]any{"Attributes": attrs}
+}
+
+func intField(req map[string]any, name string, def int) int {
+	v, ok := req[name].(float64)
+	if !ok {
+		return def
+	}
+	return int(v)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func consumeBatches[T any](log *slog.Logger, consumer queue.Consumer[T], batchCh chan<- T) func(context.Context) error {
+	return func(ctx context.Context) error {
+		defer close(batchCh)
+
+		for {
+			msgs, err := consumer.Consume(ctx)
+			if errors.Is(err, queue.ErrEndOfQueue) {
+				log.InfoContext(ctx, "encountered end of queue")
+				return nil
+			}
+			if err != nil && ctx.Err() != nil {
+				log.WarnContext(ctx, "context cancelled while receiving messages")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				log.WarnContext(ctx, "context cancelled while receiving messages")
+				return nil
+			case batchCh <- msgs:
+			}
+		}
+	}
+}
+
+type messageProcessor struct {
+	log               *slog.Logger
+	tracer            trace.Tracer
+	queueURL          string
+	queueName         string
+	redrive           redrivePolicy
+	processor         queue.Processor[Message]
+	messagesProcessed metric.Int64Counter
+}
+
+func (mp messageProcessor) process(ctx context.Context, msg types.Message) error {
+	m := toMessage(mp.queueURL, mp.redrive, msg)
+
+	queueAttr := semconv.MessagingDestinationName(mp.queueName)
+	spanOpts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemAWSSQS,
+			semconv.MessagingOperationTypeProcess,
+			queueAttr,
+			semconv.MessagingMessageID(m.ID),
+			attribute.Int("messaging.sqs.receive_count", m.ReceiveCount),
+		),
+	}
+
+	propagated := otel.GetTextMapPropagator().Extract(context.Background(), attributeCarrier(m.Attributes))
+	if s := trace.SpanContextFromContext(propagated); s.IsValid() {
+		spanOpts = append(spanOpts, trace.WithLinks(trace.Link{SpanContext: s}))
+	}
+
+	spanCtx, span := mp.tracer.Start(ctx, "process "+mp.queueName, spanOpts...)
+	defer span.End()
+
+	err := mp.processor.Process(spanCtx, m)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		mp.log.ErrorContext(
+			spanCtx,
+			"failed to process sqs message",
+			MessageIDAttr(m.ID),
+			ReceiveCountAttr(m.ReceiveCount),
+			slog.Any("error", err),
+		)
+
+		if m.MaxReceiveCount > 0 && m.ReceiveCount >= m.MaxReceiveCount {
+			span.SetAttributes(attribute.Bool("messaging.sqs.dead_lettered", true))
+
+			mp.log.WarnContext(
+				spanCtx,
+				"sqs message exceeded max receive count and will be moved to the dead-letter queue",
+				MessageIDAttr(m.ID),
+				ReceiveCountAttr(m.ReceiveCount),
+				slog.String("aws.sqs.dead_letter_target_arn", mp.redrive.deadLetterTargetArn),
+			)
+		}
+	}
+
+	mp.messagesProcessed.Add(spanCtx, 1, metric.WithAttributes(
+		semconv.MessagingSystemAWSSQS,
+		queueAttr,
+		attribute.String("messaging.process.status", processStatus(err)),
+	))
+
+	return err
+}
+
+func processStatus(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+type attributeCarrier map[string]MessageAttribute
+
+func (c attributeCarrier) Get(key string) string {
+	return c[key].StringValue
+}
+
+func (c attributeCarrier) Set(key, value string) {
+	c[key] = MessageAttribute{DataType: "String", StringValue: value}
+}
+
+func (c attributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import "log/slog"
+
+// QueueAttr returns a slog attribute for the SQS queue name.
+func QueueAttr(name string) slog.Attr {
+	return slog.String("messaging.destination.name", name)
+}
+
+// MessageIDAttr returns a slog attribute for the SQS message ID.
+func MessageIDAttr(id string) slog.Attr {
+	return slog.String("messaging.message.id", id)
+}
+
+// ReceiveCountAttr returns a slog attribute for the number of times an SQS message has been received.
+func ReceiveCountAttr(count int) slog.Attr {
+	return slog.Int("messaging.sqs.receive_count", count)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// MessageAttribute represents a user defined SQS message attribute.
+type MessageAttribute struct {
+	DataType    string
+	StringValue string
+	BinaryValue []byte
+}
+
+// Message represents an SQS message.
+type Message struct {
+	ID            string
+	ReceiptHandle string
+	Body          string
+	Attributes    map[string]MessageAttribute
+	SentTimestamp time.Time
+	QueueURL      string
+
+	// ReceiveCount is the number of times the message has been received,
+	// including this delivery.
+	ReceiveCount int
+
+	// MaxReceiveCount is the number of receives after which the queue's redrive
+	// policy moves the message to its dead-letter queue. It is zero if the queue
+	// does not have a redrive policy.
+	MaxReceiveCount int
+}
+
+// Client is the subset of the SQS API used by the runtime. It is implemented
+// by *github.com/aws/aws-sdk-go-v2/service/sqs.Client.
+type Client interface {
+	ReceiveMessage(context.Context, *awssqs.ReceiveMessageInput, ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error)
+	DeleteMessageBatch(context.Context, *awssqs.DeleteMessageBatchInput, ...func(*awssqs.Options)) (*awssqs.DeleteMessageBatchOutput, error)
+	ChangeMessageVisibility(context.Context, *awssqs.ChangeMessageVisibilityInput, ...func(*awssqs.Options)) (*awssqs.ChangeMessageVisibilityOutput, error)
+	GetQueueAttributes(context.Context, *awssqs.GetQueueAttributesInput, ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error)
+}
+
+type orchestration struct {
+	log          *slog.Logger
+	queueName    string
+	consumer     queue.Consumer[[]types.Message]
+	acknowledger queue.Acknowledger[[]types.Message]
+	extender     visibilityExtender
+	redrive      redrivePolicy
+	concurrency  int
+}
+
+type queueOrchestrator interface {
+	Orchestrate(orchestration) queue.Runtime
+}
+
+// Options represents configuration options for the SQS runtime.
+type Options struct {
+	orchestrator      queueOrchestrator
+	maxMessages       int32
+	waitTime          time.Duration
+	visibilityTimeout time.Duration
+	concurrency       int
+}
+
+// Option defines a function type for configuring SQS runtime options.
+type Option func(*Options)
+
+// MaxMessages sets the maximum number of messages returned by a single receive, between 1 and 10.
+// Default is 10 if not set.
+func MaxMessages(n int32) Option {
+	return func(o *Options) {
+		o.maxMessages = n
+	}
+}
+
+// WaitTime sets the long polling duration of a single receive, between 1 and 20
+// seconds. Short polling is not supported since it would continuously receive
+// from an empty queue. Default is 20 seconds if not set.
+func WaitTime(d time.Duration) Option {
+	return func(o *Options) {
+		o.waitTime = d
+	}
+}
+
+// VisibilityTimeout sets how long received messages are hidden from other consumers.
+// Until a received message has been processed with at-least-once semantics, its
+// visibility timeout is periodically extended by this amount so neither long running
+// processors nor messages waiting to be processed cause redeliveries.
+// Default is 30 seconds if not set.
+func VisibilityTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.visibilityTimeout = d
+	}
+}
+
+// Concurrency sets the maximum number of messages from a single receive which
+// are processed concurrently. Default is 10 if not set.
+func Concurrency(n int) Option {
+	return func(o *Options) {
+		o.concurrency = n
+	}
+}
+
+// Runtime represents the SQS runtime for processing messages.
+type Runtime struct {
+	log               *slog.Logger
+	client            Client
+	queueURL          string
+	orchestrator      queueOrchestrator
+	maxMessages       int32
+	waitTime          time.Duration
+	visibilityTimeout time.Duration
+	concurrency       int
+}
+
+// NewRuntime creates a new SQS runtime which consumes from the queue at queueURL.
+func NewRuntime(
+	client Client,
+	queueURL string,
+	opts ...Option,
+) Runtime {
+	cfg := &Options{
+		maxMessages:       10,
+		waitTime:          20 * time.Second,
+		visibilityTimeout: 30 * time.Second,
+		concurrency:       10,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.orchestrator == nil {
+		panic("sqs: a processor must be configured with AtLeastOnce or AtMostOnce")
+	}
+	if cfg.maxMessages < 1 || cfg.maxMessages > 10 {
+		panic(fmt.Sprintf("sqs: max messages must be between 1 and 10, got %d", cfg.maxMessages))
+	}
+	if cfg.waitTime < time.Second || cfg.waitTime > 20*time.Second {
+		panic(fmt.Sprintf("sqs: wait time must be between 1 and 20 seconds, got %s", cfg.waitTime))
+	}
+
+	return Runtime{
+		log:               logger().With(QueueAttr(queueName(queueURL))),
+		client:            client,
+		queueURL:          queueURL,
+		orchestrator:      cfg.orchestrator,
+		maxMessages:       cfg.maxMessages,
+		waitTime:          cfg.waitTime,
+		visibilityTimeout: cfg.visibilityTimeout,
+		concurrency:       cfg.concurrency,
+	}
+}
+
+// ProcessQueue starts processing the SQS queue.
+func (r Runtime) ProcessQueue(ctx context.Context) error {
+	redrive := loadRedrivePolicy(ctx, r.log, r.client, r.queueURL)
+
+	rt := r.orchestrator.Orchestrate(orchestration{
+		log:       r.log,
+		queueName: queueName(r.queueURL),
+		consumer: &receiver{
+			log:               r.log,
+			client:            r.client,
+			queueURL:          r.queueURL,
+			maxMessages:       r.maxMessages,
+			waitTime:          r.waitTime,
+			visibilityTimeout: r.visibilityTimeout,
+		},
+		acknowledger: &batchDeleter{
+			client:   r.client,
+			queueURL: r.queueURL,
+		},
+		extender: visibilityExtender{
+			log:      r.log,
+			client:   r.client,
+			queueURL: r.queueURL,
+			timeout:  r.visibilityTimeout,
+		},
+		redrive:     redrive,
+		concurrency: r.concurrency,
+	})
+
+	return rt.ProcessQueue(ctx)
+}
+
+func queueName(queueURL string) string {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return queueURL
+	}
+	return path.Base(u.Path)
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package sqs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/z5labs/humus/queue"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/require"
+)
+
+func runRuntime(t *testing.T, rt Runtime) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rt.ProcessQueue(ctx)
+	}()
+
+	return func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}
+}
+
+func TestRuntime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete successfully processed messages with at-least-once", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		fake.send("ok-1", nil)
+		failID := fake.send("fail", nil)
+		fake.send("ok-2", nil)
+
+		var (
+			mu        sync.Mutex
+			processed []string
+		)
+		rt := NewRuntime(
+			client,
+			fakeQueueURL,
+			WaitTime(time.Second),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				processed = append(processed, msg.Body)
+				mu.Unlock()
+
+				if msg.Body == "fail" {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			ids := fake.messageIDs()
+			return len(ids) == 1 && ids[0] == failID
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.ElementsMatch(t, []string{"ok-1", "fail", "ok-2"}, processed)
+	})
+
+	t.Run("should delete messages before processing with at-most-once", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		fake.send("fail", nil)
+		fake.send("ok", nil)
+
+		var (
+			mu        sync.Mutex
+			processed []string
+		)
+		rt := NewRuntime(
+			client,
+			fakeQueueURL,
+			WaitTime(time.Second),
+			AtMostOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				require.Empty(t, fake.messageIDs())
+
+				mu.Lock()
+				defer mu.Unlock()
+				processed = append(processed, msg.Body)
+
+				if msg.Body == "fail" {
+					return errors.New("processing failed")
+				}
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(processed) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Empty(t, fake.messageIDs())
+	})
+
+	t.Run("should extend the visibility timeout while processing", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		fake.send("slow", nil)
+
+		done := make(chan struct{})
+		rt := NewRuntime(
+			client,
+			fakeQueueURL,
+			WaitTime(time.Second),
+			VisibilityTimeout(2*time.Second),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				defer close(done)
+				time.Sleep(1500 * time.Millisecond)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		<-done
+		require.Eventually(t, func() bool {
+			return len(fake.messageIDs()) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		stop()
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		require.GreaterOrEqual(t, fake.visibilityChanges, 1)
+	})
+
+	t.Run("should extend the visibility timeout while messages wait to be processed", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		for _, body := range []string{"a", "b", "c", "d"} {
+			fake.send(body, nil)
+		}
+
+		var (
+			mu            sync.Mutex
+			receiveCounts []int
+		)
+		rt := NewRuntime(
+			client,
+			fakeQueueURL,
+			WaitTime(time.Second),
+			VisibilityTimeout(2*time.Second),
+			MaxMessages(2),
+			Concurrency(1),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				mu.Lock()
+				receiveCounts = append(receiveCounts, msg.ReceiveCount)
+				mu.Unlock()
+
+				// The second batch is received while the first is processed,
+				// so its last message waits longer than the visibility timeout.
+				time.Sleep(700 * time.Millisecond)
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		require.Eventually(t, func() bool {
+			return len(fake.messageIDs()) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+		stop()
+
+		require.Equal(t, []int{1, 1, 1, 1}, receiveCounts)
+	})
+
+	t.Run("should expose the redrive policy and propagated attributes", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		fake.redrivePolicy = `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:000000000000:dlq","maxReceiveCount":"3"}`
+		fake.send("hello", map[string]string{"tenant": "acme"})
+
+		msgCh := make(chan Message, 1)
+		rt := NewRuntime(
+			client,
+			fakeQueueURL,
+			WaitTime(time.Second),
+			AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+				msgCh <- msg
+				return nil
+			})),
+		)
+
+		stop := runRuntime(t, rt)
+		msg := <-msgCh
+		stop()
+
+		require.Equal(t, "hello", msg.Body)
+		require.Equal(t, fakeQueueURL, msg.QueueURL)
+		require.Equal(t, 1, msg.ReceiveCount)
+		require.Equal(t, 3, msg.MaxReceiveCount)
+		require.Equal(t, "acme", msg.Attributes["tenant"].StringValue)
+		require.False(t, msg.SentTimestamp.IsZero())
+	})
+
+	t.Run("should panic if no processor is configured", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewRuntime(nil, fakeQueueURL)
+		})
+	})
+
+	t.Run("should panic if the receive options are out of range", func(t *testing.T) {
+		processor := AtLeastOnce(queue.ProcessorFunc[Message](func(ctx context.Context, msg Message) error {
+			return nil
+		}))
+
+		for _, opt := range []Option{MaxMessages(0), MaxMessages(11), WaitTime(0), WaitTime(500 * time.Millisecond), WaitTime(21 * time.Second)} {
+			require.Panics(t, func() {
+				NewRuntime(nil, fakeQueueURL, processor, opt)
+			})
+		}
+		require.NotPanics(t, func() {
+			NewRuntime(nil, fakeQueueURL, processor, MaxMessages(1), WaitTime(time.Second))
+		})
+	})
+}
+
+func TestBatchDeleter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete messages in batches of 10", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		for range 15 {
+			fake.send("msg", nil)
+		}
+
+		rcv := &receiver{client: client, queueURL: fakeQueueURL, maxMessages: 10, visibilityTimeout: 30 * time.Second}
+		first, err := rcv.Consume(t.Context())
+		require.NoError(t, err)
+		second, err := rcv.Consume(t.Context())
+		require.NoError(t, err)
+
+		d := &batchDeleter{client: client, queueURL: fakeQueueURL}
+		err = d.Acknowledge(t.Context(), append(first, second...))
+		require.NoError(t, err)
+
+		require.Empty(t, fake.messageIDs())
+		require.Equal(t, 2, fake.deleteRequests)
+	})
+
+	t.Run("should return a DeleteError for each message which failed to be deleted", func(t *testing.T) {
+		fake, client := newFakeSQS(t)
+		fake.send("msg", nil)
+
+		rcv := &receiver{client: client, queueURL: fakeQueueURL, maxMessages: 10, visibilityTimeout: 30 * time.Second}
+		msgs, err := rcv.Consume(t.Context())
+		require.NoError(t, err)
+
+		msgs = append(msgs, types.Message{
+			MessageId:     aws.String("unknown"),
+			ReceiptHandle: aws.String("invalid"),
+		})
+
+		d := &batchDeleter{client: client, queueURL: fakeQueueURL}
+		err = d.Acknowledge(t.Context(), msgs)
+
+		var de *DeleteError
+		require.ErrorAs(t, err, &de)
+		require.Equal(t, "unknown", de.MessageID)
+		require.Equal(t, map[string]bool{"unknown": true}, undeletedMessages(err))
+	})
+}
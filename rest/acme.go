@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/z5labs/bedrock"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockhttp "github.com/z5labs/bedrock/runtime/http"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ACMEDomains enables automatic certificate provisioning via ACME (e.g. Let's Encrypt)
+// for the given domains. Certificates are obtained on the first TLS handshake for a
+// domain and renewed before they expire. The default is read from
+// HUMUS_REST_ACME_DOMAINS as a comma-separated list. When set, ACME takes precedence
+// over the other TLS certificate sources.
+//
+// Enabling ACME accepts the terms of service of the configured CA.
+func ACMEDomains(r bedrockconfig.Reader[[]string]) Option {
+	return func(o *options) {
+		o.acmeDomains = r
+	}
+}
+
+// ACMEEmail sets the contact email registered with the ACME account.
+// The default is read from HUMUS_REST_ACME_EMAIL.
+func ACMEEmail(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.acmeEmail = r
+	}
+}
+
+// ACMEDirectoryURL sets the ACME directory of the CA to request certificates from.
+// The default is read from HUMUS_REST_ACME_DIRECTORY_URL, falling back to the
+// Let's Encrypt production directory.
+func ACMEDirectoryURL(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.acmeDirectoryURL = r
+	}
+}
+
+// ACMEDirectoryCAFile sets the path to a PEM file of CA certificates trusted when
+// connecting to the ACME directory, e.g. the root of a local Pebble instance.
+// The default is read from HUMUS_REST_ACME_DIRECTORY_CA_FILE. If unset, the
+// system roots are used.
+func ACMEDirectoryCAFile(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.acmeDirectoryCAFile = r
+	}
+}
+
+// ACMECache sets where issued certificates and the ACME account key are stored,
+// so they survive restarts and are not requested again. By default an
+// [autocert.DirCache] is used for the directory in HUMUS_REST_ACME_CACHE_DIR.
+// If no cache is configured, certificates are only kept in memory.
+func ACMECache(r bedrockconfig.Reader[autocert.Cache]) Option {
+	return func(o *options) {
+		o.acmeCache = r
+	}
+}
+
+// ACMEHTTPChallengePort enables the HTTP-01 challenge by serving plain HTTP on the
+// given port, usually 80. Requests other than challenges are redirected to HTTPS.
+// The default is read from HUMUS_REST_ACME_HTTP_PORT. If unset, only the TLS-ALPN-01
+// challenge, which is answered on the HTTPS port, is used.
+func ACMEHTTPChallengePort(r bedrockconfig.Reader[int]) Option {
+	return func(o *options) {
+		o.acmeHTTPPort = r
+	}
+}
+
+// splitList maps a comma-separated value to its non-empty, trimmed elements.
+func splitList(r bedrockconfig.Reader[string]) bedrockconfig.Reader[[]string] {
+	return bedrockconfig.Map(r, func(_ context.Context, s string) ([]string, error) {
+		var vals []string
+		for v := range strings.SplitSeq(s, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				vals = append(vals, v)
+			}
+		}
+		return vals, nil
+	})
+}
+
+// dirCache maps a directory path to an [autocert.DirCache].
+func dirCache(r bedrockconfig.Reader[string]) bedrockconfig.Reader[autocert.Cache] {
+	return bedrockconfig.Map(r, func(_ context.Context, dir string) (autocert.Cache, error) {
+		return autocert.DirCache(dir), nil
+	})
+}
+
+// buildACMEManager returns a memoized builder for the [autocert.Manager] shared by
+// the TLS listener and the HTTP-01 challenge server. It builds a nil manager if
+// no ACME domains are configured.
+func buildACMEManager(o *options) bedrock.Builder[*autocert.Manager] {
+	return bedrock.MemoizeBuilder(bedrock.BuilderFunc[*autocert.Manager](func(ctx context.Context) (*autocert.Manager, error) {
+		domains, err := bedrockconfig.Read(ctx, o.acmeDomains)
+		if err != nil || len(domains) == 0 {
+			return nil, nil
+		}
+
+		client := &acme.Client{
+			DirectoryURL: bedrockconfig.MustOr(ctx, acme.LetsEncryptURL, o.acmeDirectoryURL),
+		}
+		caPath, err := bedrockconfig.Read(ctx, o.acmeDirectoryCAFile)
+		if err == nil && caPath != "" {
+			pool, err := loadCertPool(caPath)
+			if err != nil {
+				return nil, err
+			}
+			client.HTTPClient = &http.Client{
+				Transport: &http.Transport{
+					Proxy:           http.ProxyFromEnvironment,
+					TLSClientConfig: &tls.Config{RootCAs: pool},
+				},
+			}
+		}
+
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Client:     client,
+			Email:      bedrockconfig.MustOr(ctx, "", o.acmeEmail),
+		}
+		if cache, err := bedrockconfig.Read(ctx, o.acmeCache); err == nil {
+			m.Cache = cache
+		}
+		return m, nil
+	}))
+}
+
+// withACME returns a config.Reader[*tls.Config] which serves certificates
+// obtained by the ACME manager, if one is configured, and otherwise reads
+// the TLS config from fallback.
+func withACME(managerB bedrock.Builder[*autocert.Manager], fallback bedrockconfig.Reader[*tls.Config]) bedrockconfig.Reader[*tls.Config] {
+	return bedrockconfig.ReaderFunc[*tls.Config](func(ctx context.Context) (bedrockconfig.Value[*tls.Config], error) {
+		m, err := managerB.Build(ctx)
+		if err != nil {
+			return bedrockconfig.Value[*tls.Config]{}, err
+		}
+		if m == nil {
+			return fallback.Read(ctx)
+		}
+		return bedrockconfig.ValueOf(m.TLSConfig()), nil
+	})
+}
+
+// buildACMEChallengeRuntime returns a builder for the plain HTTP server which
+// answers HTTP-01 challenges. It builds a nil runtime if ACME or the HTTP-01
+// challenge port is not configured.
+func buildACMEChallengeRuntime(o *options, managerB bedrock.Builder[*autocert.Manager]) bedrock.Builder[bedrock.Runtime] {
+	return bedrock.BuilderFunc[bedrock.Runtime](func(ctx context.Context) (bedrock.Runtime, error) {
+		m, err := managerB.Build(ctx)
+		if err != nil || m == nil {
+			return nil, err
+		}
+		if _, err := bedrockconfig.Read(ctx, o.acmeHTTPPort); err != nil {
+			return nil, nil
+		}
+
+		tcpListenerB := bedrockhttp.BuildTCPListener(tcpAddr(o.acmeHTTPPort))
+		listenerB := bedrock.BuilderFunc[net.Listener](func(ctx context.Context) (net.Listener, error) {
+			return tcpListenerB.Build(ctx)
+		})
+
+		rt, err := bedrockhttp.Build(
+			listenerB,
+			bedrock.BuilderOf(m.HTTPHandler(nil)),
+			bedrockhttp.DisableGeneralOptionsHandler(bedrockconfig.ReaderOf(false)),
+			bedrockhttp.ReadTimeout(o.readTimeout),
+			bedrockhttp.ReadHeaderTimeout(o.readHeaderTimeout),
+			bedrockhttp.WriteTimeout(o.writeTimeout),
+			bedrockhttp.IdleTimeout(o.idleTimeout),
+			bedrockhttp.MaxHeaderBytes(o.maxHeaderBytes),
+		).Build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return rt, nil
+	})
+}
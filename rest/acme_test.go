@@ -0,0 +1,614 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const acmeTestDomain = "api.example.test"
+
+// idPeACMEIdentifier is the TLS-ALPN-01 certificate extension, see RFC 8737.
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// acmeStub is a minimal in-process ACME (RFC 8555) server in the spirit of
+// Pebble. It does not verify JWS signatures but validates HTTP-01 and
+// TLS-ALPN-01 challenges against the server under test for real.
+type acmeStub struct {
+	srv *httptest.Server
+	ca  testCA
+
+	// challengeType is the only challenge offered for authorizations.
+	challengeType string
+	httpPort      int
+	tlsPort       int
+
+	mu         sync.Mutex
+	nonce      int
+	thumbprint string
+	orders     map[string]*stubOrder
+}
+
+type stubOrder struct {
+	domain      string
+	status      string
+	authzStatus string
+	certDER     []byte
+}
+
+type jwsRequest struct {
+	protected struct {
+		JWK *struct {
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"jwk"`
+	}
+	payload []byte
+}
+
+func newACMEStub(t *testing.T, challengeType string, httpPort, tlsPort int) *acmeStub {
+	t.Helper()
+
+	s := &acmeStub{
+		ca:            newTestCA(t),
+		challengeType: challengeType,
+		httpPort:      httpPort,
+		tlsPort:       tlsPort,
+		orders:        make(map[string]*stubOrder),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /directory", s.directory)
+	mux.HandleFunc("HEAD /nonce", s.newNonce)
+	mux.HandleFunc("POST /account", s.newAccount)
+	mux.HandleFunc("POST /order", s.newOrder)
+	mux.HandleFunc("POST /order/{id}", s.order)
+	mux.HandleFunc("POST /authz/{id}", s.authz)
+	mux.HandleFunc("POST /chal/{id}", s.challenge)
+	mux.HandleFunc("POST /finalize/{id}", s.finalize)
+	mux.HandleFunc("POST /cert/{id}", s.cert)
+
+	s.srv = httptest.NewTLSServer(mux)
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func (s *acmeStub) directoryURL() string {
+	return s.srv.URL + "/directory"
+}
+
+// writeCAFile writes the certificate of the directory server as PEM to a temp
+// file and returns its path.
+func (s *acmeStub) writeCAFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "acme-ca.crt")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, certPEM, 0600))
+	return path
+}
+
+func (s *acmeStub) write(w http.ResponseWriter, status int, location string, v any) {
+	s.mu.Lock()
+	s.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", s.nonce))
+	s.mu.Unlock()
+
+	if location != "" {
+		w.Header().Set("Location", location)
+	}
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *acmeStub) readJWS(r *http.Request) (jwsRequest, error) {
+	var body struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return jwsRequest{}, err
+	}
+
+	var req jwsRequest
+	protected, err := base64.RawURLEncoding.DecodeString(body.Protected)
+	if err != nil {
+		return jwsRequest{}, err
+	}
+	if err := json.Unmarshal(protected, &req.protected); err != nil {
+		return jwsRequest{}, err
+	}
+	req.payload, err = base64.RawURLEncoding.DecodeString(body.Payload)
+	return req, err
+}
+
+func (s *acmeStub) directory(w http.ResponseWriter, r *http.Request) {
+	s.write(w, http.StatusOK, "", map[string]string{
+		"newNonce":   s.srv.URL + "/nonce",
+		"newAccount": s.srv.URL + "/account",
+		"newOrder":   s.srv.URL + "/order",
+	})
+}
+
+func (s *acmeStub) newNonce(w http.ResponseWriter, r *http.Request) {
+	s.write(w, http.StatusOK, "", nil)
+}
+
+func (s *acmeStub) newAccount(w http.ResponseWriter, r *http.Request) {
+	req, err := s.readJWS(r)
+	if err != nil || req.protected.JWK == nil || req.protected.JWK.Crv != "P-256" {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+
+	x, _ := base64.RawURLEncoding.DecodeString(req.protected.JWK.X)
+	y, _ := base64.RawURLEncoding.DecodeString(req.protected.JWK.Y)
+	thumbprint, err := acme.JWKThumbprint(&ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	})
+	if err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+
+	s.mu.Lock()
+	s.thumbprint = thumbprint
+	s.mu.Unlock()
+
+	s.write(w, http.StatusCreated, s.srv.URL+"/account/1", map[string]string{"status": "valid"})
+}
+
+func (s *acmeStub) newOrder(w http.ResponseWriter, r *http.Request) {
+	req, err := s.readJWS(r)
+	if err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+
+	var payload struct {
+		Identifiers []struct {
+			Value string `json:"value"`
+		} `json:"identifiers"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil || len(payload.Identifiers) != 1 {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+
+	s.mu.Lock()
+	id := fmt.Sprintf("%d", len(s.orders)+1)
+	o := &stubOrder{
+		domain:      payload.Identifiers[0].Value,
+		status:      acme.StatusPending,
+		authzStatus: acme.StatusPending,
+	}
+	s.orders[id] = o
+	body := s.orderJSON(id, o)
+	s.mu.Unlock()
+
+	s.write(w, http.StatusCreated, s.srv.URL+"/order/"+id, body)
+}
+
+func (s *acmeStub) lookup(w http.ResponseWriter, r *http.Request) (string, *stubOrder, jwsRequest, bool) {
+	req, err := s.readJWS(r)
+	if err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return "", nil, req, false
+	}
+
+	id := r.PathValue("id")
+	s.mu.Lock()
+	o, ok := s.orders[id]
+	s.mu.Unlock()
+	if !ok {
+		s.write(w, http.StatusNotFound, "", nil)
+	}
+	return id, o, req, ok
+}
+
+func (s *acmeStub) orderJSON(id string, o *stubOrder) map[string]any {
+	body := map[string]any{
+		"status":         o.status,
+		"identifiers":    []map[string]string{{"type": "dns", "value": o.domain}},
+		"authorizations": []string{s.srv.URL + "/authz/" + id},
+		"finalize":       s.srv.URL + "/finalize/" + id,
+	}
+	if o.certDER != nil {
+		body["certificate"] = s.srv.URL + "/cert/" + id
+	}
+	return body
+}
+
+func (s *acmeStub) challengeJSON(id string, o *stubOrder) map[string]string {
+	return map[string]string{
+		"type":   s.challengeType,
+		"url":    s.srv.URL + "/chal/" + id,
+		"token":  "token-" + id,
+		"status": o.authzStatus,
+	}
+}
+
+func (s *acmeStub) order(w http.ResponseWriter, r *http.Request) {
+	id, o, _, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	body := s.orderJSON(id, o)
+	s.mu.Unlock()
+
+	s.write(w, http.StatusOK, s.srv.URL+"/order/"+id, body)
+}
+
+func (s *acmeStub) authz(w http.ResponseWriter, r *http.Request) {
+	id, o, req, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	if bytes.Contains(req.payload, []byte(acme.StatusDeactivated)) && o.authzStatus == acme.StatusPending {
+		o.authzStatus = acme.StatusDeactivated
+	}
+	body := map[string]any{
+		"status":     o.authzStatus,
+		"identifier": map[string]string{"type": "dns", "value": o.domain},
+		"challenges": []map[string]string{s.challengeJSON(id, o)},
+	}
+	s.mu.Unlock()
+
+	s.write(w, http.StatusOK, "", body)
+}
+
+func (s *acmeStub) challenge(w http.ResponseWriter, r *http.Request) {
+	id, o, _, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	keyAuth := "token-" + id + "." + s.thumbprint
+	s.mu.Unlock()
+
+	err := s.validate(r.Context(), o.domain, "token-"+id, keyAuth)
+
+	s.mu.Lock()
+	o.authzStatus = acme.StatusValid
+	o.status = acme.StatusReady
+	if err != nil {
+		o.authzStatus = acme.StatusInvalid
+		o.status = acme.StatusInvalid
+	}
+	body := s.challengeJSON(id, o)
+	s.mu.Unlock()
+
+	s.write(w, http.StatusOK, "", body)
+}
+
+func (s *acmeStub) validate(ctx context.Context, domain, token, keyAuth string) error {
+	switch s.challengeType {
+	case "http-01":
+		url := fmt.Sprintf("http://127.0.0.1:%d/.well-known/acme-challenge/%s", s.httpPort, token)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Host = domain
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(b)) != keyAuth {
+			return fmt.Errorf("unexpected key authorization: %s", b)
+		}
+		return nil
+	case "tls-alpn-01":
+		conn, err := tls.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", s.tlsPort), &tls.Config{
+			ServerName:         domain,
+			NextProtos:         []string{acme.ALPNProto},
+			InsecureSkipVerify: true, //nolint:gosec
+		})
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		state := conn.ConnectionState()
+		if state.NegotiatedProtocol != acme.ALPNProto {
+			return fmt.Errorf("unexpected ALPN protocol: %s", state.NegotiatedProtocol)
+		}
+
+		expected := sha256.Sum256([]byte(keyAuth))
+		for _, ext := range state.PeerCertificates[0].Extensions {
+			if !ext.Id.Equal(idPeACMEIdentifier) {
+				continue
+			}
+			var digest []byte
+			if _, err := asn1.Unmarshal(ext.Value, &digest); err != nil {
+				return err
+			}
+			if !bytes.Equal(digest, expected[:]) {
+				return fmt.Errorf("unexpected key authorization digest")
+			}
+			return nil
+		}
+		return fmt.Errorf("acmeIdentifier extension missing")
+	default:
+		return fmt.Errorf("unsupported challenge type: %s", s.challengeType)
+	}
+}
+
+func (s *acmeStub) finalize(w http.ResponseWriter, r *http.Request) {
+	id, o, req, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(req.payload, &payload); err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+	csrDER, err := base64.RawURLEncoding.DecodeString(payload.CSR)
+	if err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		s.write(w, http.StatusBadRequest, "", nil)
+		return
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.ca.cert, csr.PublicKey, s.ca.key)
+	if err != nil {
+		s.write(w, http.StatusInternalServerError, "", nil)
+		return
+	}
+
+	s.mu.Lock()
+	if o.status != acme.StatusReady {
+		s.mu.Unlock()
+		s.write(w, http.StatusForbidden, "", nil)
+		return
+	}
+	o.status = acme.StatusValid
+	o.certDER = der
+	body := s.orderJSON(id, o)
+	s.mu.Unlock()
+
+	s.write(w, http.StatusOK, s.srv.URL+"/order/"+id, body)
+}
+
+func (s *acmeStub) cert(w http.ResponseWriter, r *http.Request) {
+	_, o, _, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: o.certDER})
+	s.mu.Unlock()
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.ca.cert.Raw})...)
+
+	s.mu.Lock()
+	s.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", s.nonce))
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/pem-certificate-chain")
+	w.Write(chain)
+}
+
+// memCache is a custom autocert.Cache which keeps entries in memory.
+type memCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (c *memCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.entries[key]
+	if !ok {
+		return nil, autocert.ErrCacheMiss
+	}
+	return b, nil
+}
+
+func (c *memCache) Put(ctx context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = data
+	return nil
+}
+
+func (c *memCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+func (c *memCache) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// acmeTestClient returns an HTTP client which trusts the stub CA and connects
+// to the given local port regardless of the requested host.
+func acmeTestClient(ca testCA, port int) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	dialer := &net.Dialer{}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, fmt.Sprintf("127.0.0.1:%d", port))
+			},
+		},
+	}
+}
+
+func TestRun_ACME(t *testing.T) {
+	ep := bedrockrest.GET("/hello", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{Message: "hello"}, nil
+	})
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	testCases := []struct {
+		name          string
+		challengeType string
+		httpChallenge bool
+	}{
+		{name: "obtains a certificate with the TLS-ALPN-01 challenge", challengeType: "tls-alpn-01"},
+		{name: "obtains a certificate with the HTTP-01 challenge", challengeType: "http-01", httpChallenge: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			port := freePort(t)
+			httpPort := freePort(t)
+
+			stub := newACMEStub(t, tc.challengeType, httpPort, port)
+			cache := &memCache{entries: make(map[string][]byte)}
+
+			opts := []Option{
+				Port(bedrockconfig.ReaderOf(port)),
+				ACMEDomains(bedrockconfig.ReaderOf([]string{acmeTestDomain})),
+				ACMEEmail(bedrockconfig.ReaderOf("ops@example.test")),
+				ACMEDirectoryURL(bedrockconfig.ReaderOf(stub.directoryURL())),
+				ACMEDirectoryCAFile(bedrockconfig.ReaderOf(stub.writeCAFile(t))),
+				ACMECache(bedrockconfig.ReaderOf[autocert.Cache](cache)),
+				Handle(route),
+			}
+			if tc.httpChallenge {
+				opts = append(opts, ACMEHTTPChallengePort(bedrockconfig.ReaderOf(httpPort)))
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- Run(ctx, opts...)
+			}()
+
+			client := acmeTestClient(stub.ca, port)
+			url := fmt.Sprintf("https://%s/hello", acmeTestDomain)
+
+			var resp *http.Response
+			require.Eventually(t, func() bool {
+				var err error
+				resp, err = client.Get(url)
+				return err == nil
+			}, 10*time.Second, 50*time.Millisecond)
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, "Test CA", resp.TLS.PeerCertificates[0].Issuer.CommonName)
+			require.True(t, cache.has(acmeTestDomain))
+
+			cancel()
+			require.NoError(t, <-errCh)
+		})
+	}
+}
+
+func TestBuildACMEManager(t *testing.T) {
+	t.Run("builds no manager without domains", func(t *testing.T) {
+		o := defaultOptions()
+
+		m, err := buildACMEManager(o).Build(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, m)
+	})
+
+	t.Run("reads the domains from a comma-separated list", func(t *testing.T) {
+		o := defaultOptions()
+		o.acmeDomains = splitList(bedrockconfig.ReaderOf(" api.example.test, ,www.example.test"))
+
+		m, err := buildACMEManager(o).Build(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, m)
+		require.Equal(t, acme.LetsEncryptURL, m.Client.DirectoryURL)
+		require.NoError(t, m.HostPolicy(context.Background(), "www.example.test"))
+		require.Error(t, m.HostPolicy(context.Background(), "evil.example.test"))
+	})
+
+	t.Run("uses a directory cache", func(t *testing.T) {
+		dir := t.TempDir()
+		o := defaultOptions()
+		o.acmeDomains = bedrockconfig.ReaderOf([]string{acmeTestDomain})
+		o.acmeCache = dirCache(bedrockconfig.ReaderOf(dir))
+
+		m, err := buildACMEManager(o).Build(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, autocert.DirCache(dir), m.Cache)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// APIKeyAuthScheme is the name of the OpenAPI security scheme for API keys.
+const APIKeyAuthScheme = "apiKeyAuth"
+
+// ErrUnknownAPIKey should be returned by an [APIKeyStore] if the key is not valid.
+var ErrUnknownAPIKey = errors.New("rest: unknown API key")
+
+// APIKeyStore looks up the caller an API key was issued to.
+type APIKeyStore interface {
+	// LookupAPIKey returns the caller the key was issued to, or
+	// [ErrUnknownAPIKey] if the key is not valid.
+	LookupAPIKey(ctx context.Context, key string) (Principal, error)
+}
+
+// APIKeys is an [APIKeyStore] backed by a static set of keys, e.g. loaded
+// from a secret at startup.
+type APIKeys map[string]Principal
+
+// LookupAPIKey implements the [APIKeyStore] interface. Keys are compared in
+// constant time.
+func (keys APIKeys) LookupAPIKey(ctx context.Context, key string) (Principal, error) {
+	var (
+		found bool
+		p     Principal
+	)
+	for k, v := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			found = true
+			p = v
+		}
+	}
+	if !found {
+		return Principal{}, ErrUnknownAPIKey
+	}
+	return p, nil
+}
+
+// APIKeyAuth enables authentication with API keys, which are validated
+// against the given store.
+func APIKeyAuth(store APIKeyStore) Option {
+	return func(o *options) {
+		o.apiKeyStore = store
+	}
+}
+
+// APIKeyHeader sets the request header carrying the API key. The default is
+// read from HUMUS_REST_API_KEY_HEADER (X-API-Key).
+func APIKeyHeader(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.apiKeyHeader = r
+	}
+}
+
+// apiKeyAuthenticator authenticates requests with API keys.
+type apiKeyAuthenticator struct {
+	header string
+	store  APIKeyStore
+}
+
+// newAPIKeyAuthenticator returns nil if no API key store is configured.
+func newAPIKeyAuthenticator(ctx context.Context, o *options) (*apiKeyAuthenticator, error) {
+	if o.apiKeyStore == nil {
+		return nil, nil
+	}
+
+	header, err := bedrockconfig.Read(ctx, o.apiKeyHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKeyAuthenticator{
+		header: header,
+		store:  o.apiKeyStore,
+	}, nil
+}
+
+func (a *apiKeyAuthenticator) securityScheme() (string, map[string]any) {
+	return APIKeyAuthScheme, map[string]any{
+		"type": "apiKey",
+		"in":   "header",
+		"name": a.header,
+	}
+}
+
+func (a *apiKeyAuthenticator) challenge() string {
+	return ""
+}
+
+func (a *apiKeyAuthenticator) authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(a.header)
+	if key == "" {
+		return Principal{}, errNoCredentials
+	}
+
+	p, err := a.store.LookupAPIKey(r.Context(), key)
+	if err != nil {
+		return Principal{}, err
+	}
+	p.Scheme = APIKeyAuthScheme
+	return p, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	keys := APIKeys{
+		"secret-1": {Subject: "billing", Scopes: []string{"invoices:read"}},
+	}
+
+	t.Run("returns the principal the key was issued to", func(t *testing.T) {
+		p, err := keys.LookupAPIKey(context.Background(), "secret-1")
+		require.NoError(t, err)
+		require.Equal(t, "billing", p.Subject)
+	})
+
+	t.Run("returns ErrUnknownAPIKey for unknown keys", func(t *testing.T) {
+		_, err := keys.LookupAPIKey(context.Background(), "secret-2")
+		require.ErrorIs(t, err, ErrUnknownAPIKey)
+	})
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	o := defaultOptions()
+	APIKeyAuth(APIKeys{"secret-1": {Subject: "billing"}})(o)
+
+	a, err := newAPIKeyAuthenticator(context.Background(), o)
+	require.NoError(t, err)
+
+	t.Run("reads the key from the X-API-Key header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/invoices", nil)
+		r.Header.Set("X-API-Key", "secret-1")
+
+		p, err := a.authenticate(r)
+		require.NoError(t, err)
+		require.Equal(t, "billing", p.Subject)
+		require.Equal(t, APIKeyAuthScheme, p.Scheme)
+	})
+
+	t.Run("returns errNoCredentials without a key", func(t *testing.T) {
+		_, err := a.authenticate(httptest.NewRequest(http.MethodGet, "/invoices", nil))
+		require.ErrorIs(t, err, errNoCredentials)
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/z5labs/humus"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller, e.g. the "sub" claim of a JWT.
+	Subject string
+
+	// Scopes granted to the caller, e.g. the "scope" or "scp" claim of a JWT.
+	Scopes []string
+
+	// Roles of the caller, e.g. the "roles" claim of a JWT.
+	Roles []string
+
+	// Scheme is the name of the OpenAPI security scheme which authenticated
+	// the caller, i.e. [BearerAuthScheme] or [APIKeyAuthScheme].
+	Scheme string
+
+	// claims is the raw claim set of the JWT the caller authenticated with.
+	claims json.RawMessage
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the authenticated caller of the request.
+// It returns false if the request did not carry any credentials, which is
+// only possible for routes allowing anonymous callers, or if no authentication
+// is configured for the server.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// ClaimsFromContext decodes the claims of the JWT the caller authenticated
+// with into T, e.g. a struct embedding custom claims. It returns false if the
+// caller did not authenticate with a JWT or the claims can not be decoded into T.
+func ClaimsFromContext[T any](ctx context.Context) (T, bool) {
+	var claims T
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || len(p.claims) == 0 {
+		return claims, false
+	}
+	if err := json.Unmarshal(p.claims, &claims); err != nil {
+		return claims, false
+	}
+	return claims, true
+}
+
+// errNoCredentials is returned by an authenticator if the request does not
+// carry credentials for its security scheme.
+var errNoCredentials = errors.New("rest: no credentials")
+
+// authenticator authenticates requests with a single security scheme.
+type authenticator interface {
+	// securityScheme returns the name and OpenAPI Security Scheme Object.
+	securityScheme() (string, map[string]any)
+
+	// challenge returns the WWW-Authenticate challenge for the scheme, if any.
+	challenge() string
+
+	// authenticate returns errNoCredentials if r carries no credentials
+	// for the scheme.
+	authenticate(r *http.Request) (Principal, error)
+}
+
+// buildAuthenticators returns an authenticator for every configured security scheme.
+func buildAuthenticators(ctx context.Context, o *options) ([]authenticator, error) {
+	var authenticators []authenticator
+
+	jwtAuth, err := newJWTAuthenticator(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	if jwtAuth != nil {
+		authenticators = append(authenticators, jwtAuth)
+	}
+
+	apiKeyAuth, err := newAPIKeyAuthenticator(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	if apiKeyAuth != nil {
+		authenticators = append(authenticators, apiKeyAuth)
+	}
+
+	return authenticators, nil
+}
+
+// authHandler authenticates every request carrying credentials, except for the
+// OpenAPI specs, and adds the resulting [Principal] to the request context.
+// Requests with invalid credentials are rejected, whereas requests without
+// credentials are left to [authzHandler]. It returns next unchanged if there
+// are no authenticators.
+func authHandler(authenticators []authenticator, specPaths []string, next http.Handler) http.Handler {
+	if len(authenticators) == 0 {
+		return next
+	}
+
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.Contains(specPaths, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for _, a := range authenticators {
+			p, err := a.authenticate(r)
+			if errors.Is(err, errNoCredentials) {
+				continue
+			}
+			if err != nil {
+				scheme, _ := a.securityScheme()
+				log.DebugContext(r.Context(), "rejected request credentials", slog.String("scheme", scheme), slog.Any("error", err))
+				writeUnauthorized(w, authenticators, "The request credentials are invalid.")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeUnauthorized(w http.ResponseWriter, authenticators []authenticator, detail string) {
+	for _, a := range authenticators {
+		if c := a.challenge(); c != "" {
+			w.Header().Add("WWW-Authenticate", c)
+		}
+	}
+	writeProblem(w, http.StatusUnauthorized, detail)
+}
+
+// securitySchemes adds the security schemes of the authenticators to the
+// OpenAPI spec and requires one of them for every operation.
+func securitySchemes(authenticators []authenticator) specDecorator {
+	return func(spec map[string]any) error {
+		schemes := specObject(specObject(spec, "components"), "securitySchemes")
+
+		security := make([]any, 0, len(authenticators))
+		for _, a := range authenticators {
+			name, scheme := a.securityScheme()
+			schemes[name] = scheme
+			security = append(security, map[string]any{name: []string{}})
+		}
+		spec["security"] = security
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+type callerResponse struct {
+	Subject string `json:"subject"`
+	Scheme  string `json:"scheme"`
+	Tenant  string `json:"tenant,omitempty"`
+}
+
+type tenantClaims struct {
+	Tenant string `json:"tenant"`
+}
+
+func TestRun_Auth(t *testing.T) {
+	iss := newTestIssuer(t)
+	port := freePort(t)
+
+	ep := bedrockrest.GET("/whoami", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (callerResponse, error) {
+		p, ok := PrincipalFromContext(ctx)
+		if !ok {
+			return callerResponse{}, testError{Message: "no principal"}
+		}
+		resp := callerResponse{Subject: p.Subject, Scheme: p.Scheme}
+		if claims, ok := ClaimsFromContext[tenantClaims](ctx); ok {
+			resp.Tenant = claims.Tenant
+		}
+		return resp, nil
+	})
+	ep = bedrockrest.WriteJSON[callerResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			JWTIssuer(bedrockconfig.ReaderOf(iss.url())),
+			JWTAudience(bedrockconfig.ReaderOf("pets-api")),
+			APIKeyAuth(APIKeys{"secret-1": {Subject: "billing"}}),
+			Readiness(monitorFunc(func(ctx context.Context) (bool, error) {
+				return true, nil
+			})),
+			Handle(route),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	get := func(t *testing.T, path string, header http.Header) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("authenticates bearer tokens", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"tenant": "acme"})
+		resp := get(t, "/whoami", http.Header{"Authorization": {"Bearer " + token}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body callerResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, callerResponse{Subject: "user-1", Scheme: BearerAuthScheme, Tenant: "acme"}, body)
+	})
+
+	t.Run("authenticates API keys", func(t *testing.T) {
+		resp := get(t, "/whoami", http.Header{"X-Api-Key": {"secret-1"}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body callerResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, callerResponse{Subject: "billing", Scheme: APIKeyAuthScheme}, body)
+	})
+
+	t.Run("rejects requests without credentials", func(t *testing.T) {
+		resp := get(t, "/whoami", nil)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+
+		var problem map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		require.Equal(t, float64(http.StatusUnauthorized), problem["status"])
+	})
+
+	t.Run("rejects invalid credentials", func(t *testing.T) {
+		resp := get(t, "/whoami", http.Header{"Authorization": {"Bearer not-a-jwt"}})
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp = get(t, "/whoami", http.Header{"X-Api-Key": {"secret-2"}})
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("serves health checks without credentials", func(t *testing.T) {
+		resp := get(t, readinessPath, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("documents the security schemes in the OpenAPI spec", func(t *testing.T) {
+		resp := get(t, "/openapi.json", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var spec struct {
+			Components struct {
+				SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+			} `json:"components"`
+			Security []map[string][]string `json:"security"`
+			Paths    map[string]any        `json:"paths"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+		require.Equal(t, map[string]map[string]string{
+			BearerAuthScheme: {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			APIKeyAuthScheme: {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		}, spec.Components.SecuritySchemes)
+		require.Equal(t, []map[string][]string{
+			{BearerAuthScheme: {}},
+			{APIKeyAuthScheme: {}},
+		}, spec.Security)
+		require.Contains(t, spec.Paths, "/whoami")
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus"
+)
+
+// PolicyInput is the input a [Policy] decides on.
+type PolicyInput struct {
+	// Principal is the authenticated caller. It is the zero value if
+	// Authenticated is false.
+	Principal Principal
+
+	// Authenticated reports whether the request carried valid credentials.
+	Authenticated bool
+
+	// OperationID is the OpenAPI operationId of the route, if any.
+	OperationID string
+
+	// Method and Pattern identify the route, e.g. "GET" and "/carts/{cartId}".
+	Method  string
+	Pattern string
+
+	// Request is the request being authorized.
+	Request *http.Request
+}
+
+// Policy decides whether a caller may invoke a route. Implementations may
+// delegate the decision to an embedded policy engine, e.g. OPA or Cedar.
+type Policy interface {
+	// Authorize reports whether the request is allowed. An error results in
+	// a 500 response.
+	Authorize(ctx context.Context, in PolicyInput) (bool, error)
+}
+
+// PolicyFunc is a function which implements the [Policy] interface.
+type PolicyFunc func(ctx context.Context, in PolicyInput) (bool, error)
+
+// Authorize implements the [Policy] interface.
+func (f PolicyFunc) Authorize(ctx context.Context, in PolicyInput) (bool, error) {
+	return f(ctx, in)
+}
+
+// RouteOption configures a route registered with [Handle], e.g. its
+// authorization requirements.
+type RouteOption func(*routeOptions)
+
+type routeOptions struct {
+	anonymous bool
+	scopes    []string
+	roles     []string
+	policies  []Policy
+	rateLimit *RateLimit
+
+	maxBodyBytes int64
+	consumes     []Codec
+	produces     []Codec
+
+	deprecated      bool
+	deprecatedSince time.Time
+	sunset          time.Time
+}
+
+// RequireScopes requires the caller to be granted all of the given scopes.
+// The scopes are listed in the security requirements of the operation.
+func RequireScopes(scopes ...string) RouteOption {
+	return func(ro *routeOptions) {
+		ro.scopes = append(ro.scopes, scopes...)
+	}
+}
+
+// RequireRoles requires the caller to have all of the given roles.
+// The roles are listed in the security requirements of the operation.
+func RequireRoles(roles ...string) RouteOption {
+	return func(ro *routeOptions) {
+		ro.roles = append(ro.roles, roles...)
+	}
+}
+
+// RequirePolicy requires the given policy to allow the request. It is
+// evaluated after any scope and role requirements.
+func RequirePolicy(p Policy) RouteOption {
+	return func(ro *routeOptions) {
+		ro.policies = append(ro.policies, p)
+	}
+}
+
+// AllowAnonymous allows callers without credentials to invoke the route, even
+// if authentication is configured. Credentials are still validated if present.
+func AllowAnonymous() RouteOption {
+	return func(ro *routeOptions) {
+		ro.anonymous = true
+	}
+}
+
+// authorizes reports whether the route has any policies to evaluate.
+func (ro routeOptions) authorizes() bool {
+	return len(ro.scopes) > 0 || len(ro.roles) > 0 || len(ro.policies) > 0
+}
+
+// allPolicies returns the scope and role requirements followed by the custom policies.
+func (ro routeOptions) allPolicies() []Policy {
+	var policies []Policy
+	if len(ro.scopes) > 0 {
+		policies = append(policies, grantsAll(ro.scopes, func(p Principal) []string { return p.Scopes }))
+	}
+	if len(ro.roles) > 0 {
+		policies = append(policies, grantsAll(ro.roles, func(p Principal) []string { return p.Roles }))
+	}
+	return append(policies, ro.policies...)
+}
+
+// grantsAll allows authenticated callers who are granted all of the required values.
+func grantsAll(required []string, granted func(Principal) []string) Policy {
+	return PolicyFunc(func(ctx context.Context, in PolicyInput) (bool, error) {
+		if !in.Authenticated {
+			return false, nil
+		}
+		have := granted(in.Principal)
+		for _, v := range required {
+			if !slices.Contains(have, v) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// handledRoute is a route registered with [Handle].
+type handledRoute struct {
+	route bedrockrest.Route
+	authz routeOptions
+}
+
+// routeOperation identifies the OpenAPI operation of a route.
+type routeOperation struct {
+	method      string
+	path        string
+	operationID string
+
+	// consumes and produces are the media types of the request body and
+	// the successful responses.
+	consumes []string
+	produces []string
+
+	// body validates JSON request bodies, if the operation has one.
+	body *schemaValidator
+
+	// deprecated reports whether the route is marked deprecated with bedrock.
+	deprecated bool
+}
+
+// discoverOperation returns the operation of a route by building an API
+// containing only the route and reading its OpenAPI spec, since bedrock
+// does not expose the method and pattern of a route.
+func discoverOperation(ctx context.Context, route bedrockrest.Route) (routeOperation, error) {
+	h, err := bedrockrest.Build(route.Route()).Build(ctx)
+	if err != nil {
+		return routeOperation{}, err
+	}
+	spec, err := readSpec(defaultSpecPath, h)
+	if err != nil {
+		return routeOperation{}, err
+	}
+
+	var ops []routeOperation
+	paths, _ := spec["paths"].(map[string]any)
+	for path, item := range paths {
+		item, _ := item.(map[string]any)
+		for method, op := range item {
+			op, ok := op.(map[string]any)
+			if !ok || !isHTTPMethod(method) {
+				continue
+			}
+			operationID, _ := op["operationId"].(string)
+			deprecated, _ := op["deprecated"].(bool)
+			body, err := newSchemaValidator(spec, op)
+			if err != nil {
+				return routeOperation{}, err
+			}
+			ops = append(ops, routeOperation{
+				method:      strings.ToUpper(method),
+				path:        path,
+				operationID: operationID,
+				consumes:    requestMediaTypes(op),
+				produces:    responseMediaTypes(op),
+				body:        body,
+				deprecated:  deprecated,
+			})
+		}
+	}
+	if len(ops) != 1 {
+		return routeOperation{}, fmt.Errorf("rest: expected route to have exactly one operation, found %d", len(ops))
+	}
+	return ops[0], nil
+}
+
+// requestMediaTypes returns the media types of the request body of an operation.
+func requestMediaTypes(op map[string]any) []string {
+	reqBody, _ := op["requestBody"].(map[string]any)
+	content, _ := reqBody["content"].(map[string]any)
+	return slices.Sorted(maps.Keys(content))
+}
+
+// responseMediaTypes returns the media types of the successful responses of
+// an operation.
+func responseMediaTypes(op map[string]any) []string {
+	var mediaTypes []string
+	responses, _ := op["responses"].(map[string]any)
+	for code, resp := range responses {
+		resp, _ := resp.(map[string]any)
+		content, _ := resp["content"].(map[string]any)
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		for mediaType := range content {
+			if !slices.Contains(mediaTypes, mediaType) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	slices.Sort(mediaTypes)
+	return mediaTypes
+}
+
+func isHTTPMethod(s string) bool {
+	switch strings.ToUpper(s) {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// muxPattern converts a chi route pattern into a [http.ServeMux] pattern,
+// i.e. regular expressions are dropped from parameters and a trailing
+// wildcard matches the remaining path.
+func muxPattern(method, pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, seg := range segments {
+		switch {
+		case seg == "*" && i == len(segments)-1:
+			segments[i] = "{rest...}"
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name, _, _ := strings.Cut(seg[1:len(seg)-1], ":")
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+// authorizedRoute is a route along with its operation and options.
+type authorizedRoute struct {
+	op    routeOperation
+	authz routeOptions
+
+	// handler serves the route instead of the API, e.g. for WebSocket
+	// routes, which bedrock cannot serve.
+	handler http.Handler
+}
+
+// buildAuthorizedRoutes discovers the operation of every route, so requests
+// can be matched to their route before they are routed by bedrock.
+func buildAuthorizedRoutes(ctx context.Context, routes []handledRoute) ([]authorizedRoute, error) {
+	var authorized []authorizedRoute
+	for _, r := range routes {
+		op, err := discoverOperation(ctx, r.route)
+		if err != nil {
+			return nil, err
+		}
+		authz := r.authz
+		if op.deprecated {
+			authz.deprecated = true
+		}
+		authorized = append(authorized, authorizedRoute{op: op, authz: authz})
+	}
+	return authorized, nil
+}
+
+// routesHandler enforces the options of the matched route before calling
+// next, or the handler of the route if it has one. Callers exceeding the rate
+// limit are rejected with a 429 response, callers without credentials with a
+// 401 response and callers which are not allowed with a 403 response. Then
+// the size and media type of the request body are checked, the response media
+// type is negotiated and JSON request bodies are validated against the schema
+// of the operation. Responses of deprecated routes carry Deprecation and
+// Sunset headers and responses of stream endpoints are marked as not
+// cacheable. Requests which do not match any of the routes are passed to next
+// as is. The prefix of the API version of the routes scopes their rate limits.
+func routesHandler(routes []authorizedRoute, authenticators []authenticator, limiter RateLimiter, maxBodyBytes int64, prefix string, next http.Handler) (http.Handler, error) {
+	if len(routes) == 0 {
+		return next, nil
+	}
+
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		limit := maxBodyBytes
+		if route.authz.maxBodyBytes != 0 {
+			limit = route.authz.maxBodyBytes
+		}
+		target := next
+		if route.handler != nil {
+			target = route.handler
+		}
+		h := validateHandler(route.op, target)
+		h = streamHandler(route.op, h)
+		h = negotiateHandler(route.op, route.authz.consumes, route.authz.produces, h)
+		h = bodyLimitHandler(limit, h)
+		h = authorize(route, authenticators, h)
+		if route.authz.rateLimit != nil {
+			h = rateLimitHandler(limiter, route.op.method+" "+prefix+route.op.path, *route.authz.rateLimit, h)
+		}
+		h = deprecationHandler(route.authz, h)
+		if err := handleMux(mux, muxPattern(route.op.method, route.op.path), h); err != nil {
+			return nil, fmt.Errorf("rest: failed to handle route %s %s: %w", route.op.method, route.op.path, err)
+		}
+	}
+	mux.Handle("/", next)
+	return mux, nil
+}
+
+// handleMux registers h with mux, returning an error instead of panicking
+// if the pattern is invalid or conflicts with another route.
+func handleMux(mux *http.ServeMux, pattern string, h http.Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	mux.Handle(pattern, h)
+	return nil
+}
+
+func authorize(route authorizedRoute, authenticators []authenticator, next http.Handler) http.Handler {
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	policies := route.authz.allPolicies()
+	requireAuth := len(authenticators) > 0 && !route.authz.anonymous
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, authenticated := PrincipalFromContext(r.Context())
+		if requireAuth && !authenticated {
+			writeUnauthorized(w, authenticators, "The request does not carry any credentials.")
+			return
+		}
+
+		in := PolicyInput{
+			Principal:     p,
+			Authenticated: authenticated,
+			OperationID:   route.op.operationID,
+			Method:        route.op.method,
+			Pattern:       route.op.path,
+			Request:       r,
+		}
+		for _, policy := range policies {
+			allowed, err := policy.Authorize(r.Context(), in)
+			if err != nil {
+				log.ErrorContext(r.Context(), "failed to evaluate authorization policy", slog.String("method", in.Method), slog.String("pattern", in.Pattern), slog.Any("error", err))
+				writeProblem(w, http.StatusInternalServerError, "The request could not be authorized.")
+				return
+			}
+			if allowed {
+				continue
+			}
+			if !authenticated && len(authenticators) > 0 {
+				writeUnauthorized(w, authenticators, "The request does not carry any credentials.")
+				return
+			}
+			log.DebugContext(r.Context(), "denied request", slog.String("subject", p.Subject), slog.String("method", in.Method), slog.String("pattern", in.Pattern))
+			writeProblem(w, http.StatusForbidden, "The caller is not allowed to perform this operation.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// securityRequirements adds the security requirements of the routes to their
+// operations in the OpenAPI spec, overriding the global requirement.
+func securityRequirements(routes []authorizedRoute, authenticators []authenticator) specDecorator {
+	return func(spec map[string]any) error {
+		paths := specObject(spec, "paths")
+		for _, route := range routes {
+			if !route.authz.authorizes() && !route.authz.anonymous {
+				continue
+			}
+			op := specObject(specObject(paths, route.op.path), strings.ToLower(route.op.method))
+
+			required := slices.Concat(route.authz.scopes, route.authz.roles)
+			security := make([]any, 0, len(authenticators)+1)
+			if route.authz.anonymous {
+				security = append(security, map[string]any{})
+			}
+			for _, a := range authenticators {
+				name, _ := a.securityScheme()
+				security = append(security, map[string]any{name: append([]string{}, required...)})
+			}
+			op["security"] = security
+
+			responses := specObject(op, "responses")
+			if len(authenticators) > 0 {
+				addResponse(responses, http.StatusUnauthorized)
+			}
+			if route.authz.authorizes() {
+				addResponse(responses, http.StatusForbidden)
+			}
+		}
+		return nil
+	}
+}
+
+// addResponse documents a problem details response, unless the operation
+// already documents the status.
+func addResponse(responses map[string]any, status int) {
+	code := fmt.Sprint(status)
+	if _, ok := responses[code]; ok {
+		return
+	}
+	responses[code] = map[string]any{
+		"description": http.StatusText(status),
+		"content": map[string]any{
+			"application/problem+json": map[string]any{
+				"schema": map[string]any{"type": "object"},
+			},
+		},
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestMuxPattern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		method  string
+		pattern string
+		want    string
+	}{
+		{name: "static", method: http.MethodGet, pattern: "/pets", want: "GET /pets"},
+		{name: "parameter", method: http.MethodDelete, pattern: "/pets/{id}", want: "DELETE /pets/{id}"},
+		{name: "regexp parameter", method: http.MethodGet, pattern: "/pets/{id:[0-9]+}", want: "GET /pets/{id}"},
+		{name: "wildcard", method: http.MethodGet, pattern: "/files/*", want: "GET /files/{rest...}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, muxPattern(tc.method, tc.pattern))
+		})
+	}
+}
+
+func TestDiscoverOperation(t *testing.T) {
+	ep := bedrockrest.DELETE("/pets/{id}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{}, nil
+	})
+	ep = bedrockrest.PathParam[string]("id").Read(ep)
+	ep = bedrockrest.OperationID("deletePet", ep)
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	op, err := discoverOperation(context.Background(), route)
+	require.NoError(t, err)
+	require.Equal(t, routeOperation{
+		method:      http.MethodDelete,
+		path:        "/pets/{id}",
+		operationID: "deletePet",
+		produces:    []string{"application/json"},
+	}, op)
+}
+
+func TestRun_Authorization(t *testing.T) {
+	port := freePort(t)
+
+	newRoute := func(pattern, operationID string, params ...bedrockrest.Param[string]) bedrockrest.Route {
+		ep := bedrockrest.GET(pattern, func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+			return testResponse{Message: operationID}, nil
+		})
+		for _, param := range params {
+			ep = param.Read(ep)
+		}
+		ep = bedrockrest.OperationID(operationID, ep)
+		ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+		return bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+			return testError{Message: err.Error()}
+		}, ep)
+	}
+
+	var policyInput PolicyInput
+	ownerOnly := PolicyFunc(func(ctx context.Context, in PolicyInput) (bool, error) {
+		policyInput = in
+		if in.Request.Header.Get("X-Fail") != "" {
+			return false, errors.New("policy engine unavailable")
+		}
+		return in.Principal.Subject == "alice", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			APIKeyAuth(APIKeys{
+				"alice-key": {Subject: "alice", Scopes: []string{"pets:read", "pets:write"}, Roles: []string{"admin"}},
+				"bob-key":   {Subject: "bob", Scopes: []string{"pets:read"}},
+			}),
+			Handle(newRoute("/authenticated", "authenticated")),
+			Handle(newRoute("/public", "public"), AllowAnonymous()),
+			Handle(newRoute("/pets", "writePets"), RequireScopes("pets:read", "pets:write")),
+			Handle(newRoute("/admin", "admin"), RequireRoles("admin")),
+			Handle(newRoute("/owner/{id}", "owner", bedrockrest.PathParam[string]("id")), RequirePolicy(ownerOnly)),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	get := func(t *testing.T, path, key string, header http.Header) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	requireProblem := func(t *testing.T, resp *http.Response, status int) {
+		t.Helper()
+
+		require.Equal(t, status, resp.StatusCode)
+		require.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+		var problem map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		require.Equal(t, float64(status), problem["status"])
+	}
+
+	testCases := []struct {
+		name   string
+		path   string
+		key    string
+		status int
+	}{
+		{name: "requires authentication by default", path: "/authenticated", status: http.StatusUnauthorized},
+		{name: "allows authenticated callers by default", path: "/authenticated", key: "bob-key", status: http.StatusOK},
+		{name: "allows anonymous callers", path: "/public", status: http.StatusOK},
+		{name: "validates credentials of anonymous routes", path: "/public", key: "unknown", status: http.StatusUnauthorized},
+		{name: "requires credentials for scopes", path: "/pets", status: http.StatusUnauthorized},
+		{name: "forbids missing scopes", path: "/pets", key: "bob-key", status: http.StatusForbidden},
+		{name: "allows granted scopes", path: "/pets", key: "alice-key", status: http.StatusOK},
+		{name: "forbids missing roles", path: "/admin", key: "bob-key", status: http.StatusForbidden},
+		{name: "allows granted roles", path: "/admin", key: "alice-key", status: http.StatusOK},
+		{name: "forbids requests denied by the policy", path: "/owner/1", key: "bob-key", status: http.StatusForbidden},
+		{name: "allows requests allowed by the policy", path: "/owner/1", key: "alice-key", status: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := get(t, tc.path, tc.key, nil)
+			if tc.status == http.StatusOK {
+				require.Equal(t, tc.status, resp.StatusCode)
+				return
+			}
+			requireProblem(t, resp, tc.status)
+		})
+	}
+
+	t.Run("passes the route to the policy", func(t *testing.T) {
+		resp := get(t, "/owner/1", "alice-key", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.Equal(t, "owner", policyInput.OperationID)
+		require.Equal(t, http.MethodGet, policyInput.Method)
+		require.Equal(t, "/owner/{id}", policyInput.Pattern)
+		require.True(t, policyInput.Authenticated)
+		require.Equal(t, "alice", policyInput.Principal.Subject)
+	})
+
+	t.Run("returns 500 if the policy fails", func(t *testing.T) {
+		resp := get(t, "/owner/1", "alice-key", http.Header{"X-Fail": {"1"}})
+		requireProblem(t, resp, http.StatusInternalServerError)
+	})
+
+	t.Run("documents the security requirements in the OpenAPI spec", func(t *testing.T) {
+		resp := get(t, "/openapi.json", "", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		type operation struct {
+			Security  []map[string][]string `json:"security"`
+			Responses map[string]any        `json:"responses"`
+		}
+		var spec struct {
+			Paths map[string]map[string]operation `json:"paths"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+		require.Nil(t, spec.Paths["/authenticated"]["get"].Security)
+
+		public := spec.Paths["/public"]["get"]
+		require.Equal(t, []map[string][]string{{}, {APIKeyAuthScheme: {}}}, public.Security)
+
+		pets := spec.Paths["/pets"]["get"]
+		require.Equal(t, []map[string][]string{{APIKeyAuthScheme: {"pets:read", "pets:write"}}}, pets.Security)
+		require.Contains(t, pets.Responses, "401")
+		require.Contains(t, pets.Responses, "403")
+
+		admin := spec.Paths["/admin"]["get"]
+		require.Equal(t, []map[string][]string{{APIKeyAuthScheme: {"admin"}}}, admin.Security)
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// MaxRequestBodyBytes sets the maximum size of request bodies in bytes.
+// Requests with larger bodies are rejected with a 413 problem. A value of 0
+// or less disables the limit. The default is read from
+// HUMUS_REST_MAX_BODY_BYTES (0).
+func MaxRequestBodyBytes(r bedrockconfig.Reader[int64]) Option {
+	return func(o *options) {
+		o.maxBodyBytes = r
+	}
+}
+
+// MaxBodyBytes overrides the maximum size of request bodies for the route,
+// which also limits the route when no [MaxRequestBodyBytes] is configured.
+// A negative value disables the limit for the route.
+func MaxBodyBytes(n int64) RouteOption {
+	return func(ro *routeOptions) {
+		ro.maxBodyBytes = n
+	}
+}
+
+// limitedBody is a request body which fails once more than limit bytes are
+// read. Unlike [http.MaxBytesReader], it records whether the limit was
+// exceeded, so the response can be replaced.
+type limitedBody struct {
+	io.ReadCloser
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	// Read one more byte than allowed to detect bodies exceeding the limit.
+	if remaining := b.limit - b.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		b.exceeded = true
+		n -= int(b.read - b.limit)
+		b.read = b.limit
+		return n, &http.MaxBytesError{Limit: b.limit}
+	}
+	return n, err
+}
+
+// bodyLimitHandler limits the size of request bodies to limit bytes, unless
+// limit is 0 or less. Bodies declaring a larger Content-Length are rejected
+// before next is called. For other bodies, the response of next is replaced
+// with a 413 problem if next reads past the limit.
+func bodyLimitHandler(limit int64, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			writeBodyTooLarge(w, limit)
+			return
+		}
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		b := &limitedBody{ReadCloser: r.Body, limit: limit}
+		r.Body = b
+		next.ServeHTTP(&bodyLimitWriter{ResponseWriter: w, body: b}, r)
+	})
+}
+
+func writeBodyTooLarge(w http.ResponseWriter, limit int64) {
+	writeProblem(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("The request body exceeds the limit of %d bytes.", limit))
+}
+
+// bodyLimitWriter replaces the response with a 413 problem if the request
+// body exceeded its limit before the response was started.
+type bodyLimitWriter struct {
+	http.ResponseWriter
+	body        *limitedBody
+	wroteHeader bool
+	rejected    bool
+}
+
+func (bw *bodyLimitWriter) WriteHeader(status int) {
+	if bw.wroteHeader {
+		return
+	}
+	if isInformational(status) {
+		bw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	bw.wroteHeader = true
+	if bw.body.exceeded {
+		bw.rejected = true
+		writeBodyTooLarge(bw.ResponseWriter, bw.body.limit)
+		return
+	}
+	bw.ResponseWriter.WriteHeader(status)
+}
+
+func (bw *bodyLimitWriter) Write(b []byte) (int, error) {
+	if !bw.wroteHeader {
+		bw.WriteHeader(http.StatusOK)
+	}
+	if bw.rejected {
+		return len(b), nil
+	}
+	return bw.ResponseWriter.Write(b)
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (bw *bodyLimitWriter) Unwrap() http.ResponseWriter {
+	return bw.ResponseWriter
+}
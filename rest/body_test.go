@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+func TestMaxRequestBodyBytes(t *testing.T) {
+	t.Run("is disabled by default", func(t *testing.T) {
+		n, err := bedrockconfig.Read(context.Background(), defaultOptions().maxBodyBytes)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("reads env vars", func(t *testing.T) {
+		t.Setenv("HUMUS_REST_MAX_BODY_BYTES", "1024")
+
+		n, err := bedrockconfig.Read(context.Background(), defaultOptions().maxBodyBytes)
+		require.NoError(t, err)
+		require.Equal(t, int64(1024), n)
+	})
+}
+
+func TestBodyLimitHandler(t *testing.T) {
+	var read string
+	h := bodyLimitHandler(8, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		read = string(b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(body string, contentLength int64) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.ContentLength = contentLength
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("allows bodies within the limit", func(t *testing.T) {
+		w := serve("12345678", 8)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "12345678", read)
+	})
+
+	t.Run("rejects large bodies by content length", func(t *testing.T) {
+		read = ""
+		w := serve("123456789", 9)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		require.Empty(t, read)
+	})
+
+	t.Run("rejects large bodies of unknown length", func(t *testing.T) {
+		w := serve("123456789", -1)
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		require.Equal(t, "12345678", read)
+	})
+
+	t.Run("is disabled by a non-positive limit", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		require.NotNil(t, bodyLimitHandler(0, next))
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456789"))
+		w := httptest.NewRecorder()
+		bodyLimitHandler(-1, next).ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/z5labs/humus/rest"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the backoff before the first retry. It doubles with
+	// every retry and is jittered.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the backoff. A Retry-After longer than MaxBackoff
+	// is not waited for and the response is returned instead.
+	MaxBackoff time.Duration
+}
+
+type options struct {
+	httpClient *http.Client
+	retry      RetryPolicy
+	header     http.Header
+}
+
+// Option configures a [Client].
+type Option func(*options)
+
+// HTTPClient sets the HTTP client used to send requests. Its transport is
+// instrumented with OpenTelemetry. It defaults to a client using
+// [http.DefaultTransport].
+func HTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// Retry sets the retry policy. It defaults to 3 attempts with a backoff of
+// 100ms up to 5s.
+func Retry(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// Header adds a header to every request, e.g. an API key.
+func Header(key, value string) Option {
+	return func(o *options) {
+		o.header.Add(key, value)
+	}
+}
+
+// Client sends requests to a REST API.
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	retry   RetryPolicy
+	header  http.Header
+}
+
+// New returns a client of the API served at baseURL.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("client: base URL must be an http or https URL: %s", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	o := &options{
+		httpClient: &http.Client{},
+		retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     5 * time.Second,
+		},
+		header: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	hc := *o.httpClient
+	transport := hc.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	hc.Transport = otelhttp.NewTransport(transport)
+
+	return &Client{
+		baseURL: u,
+		http:    &hc,
+		retry:   o.retry,
+		header:  o.header,
+	}, nil
+}
+
+// Request is a request of an operation.
+type Request struct {
+	// Operation is the operation ID, which names the span of the request.
+	Operation string
+
+	Method string
+
+	// Path is the escaped path of the operation relative to the base URL.
+	Path string
+
+	Query  url.Values
+	Header http.Header
+
+	// Body is encoded as JSON, unless it is nil.
+	Body any
+}
+
+// Do sends the request and decodes the JSON body of a successful response
+// into out, unless out is nil. Error responses are returned as a
+// [rest.Problem], decoded from the problem details of the response if it
+// carries any.
+//
+// Requests are retried according to the retry policy if the server is
+// unavailable or rate limits the client. Idempotent requests are also
+// retried on network errors and gateway failures.
+func (c *Client) Do(ctx context.Context, req Request, out any) (err error) {
+	spanName := req.Operation
+	if spanName == "" {
+		spanName = req.Method + " " + req.Path
+	}
+	ctx, span := tracer().Start(ctx, spanName, trace.WithAttributes(
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.Path),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var body []byte
+	if req.Body != nil {
+		body, err = json.Marshal(req.Body)
+		if err != nil {
+			return fmt.Errorf("client: failed to encode request body: %w", err)
+		}
+	}
+
+	resp, err := c.send(ctx, span, req, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeProblem(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("client: failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// send sends the request, retrying it according to the retry policy.
+func (c *Client) send(ctx context.Context, span trace.Span, req Request, body []byte) (*http.Response, error) {
+	u, err := url.Parse(c.baseURL.String() + req.Path)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid path %q: %w", req.Path, err)
+	}
+	u.RawQuery = req.Query.Encode()
+
+	for attempt := 1; ; attempt++ {
+		r, err := http.NewRequestWithContext(ctx, req.Method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("client: failed to create request: %w", err)
+		}
+		if body == nil {
+			r.Body = http.NoBody
+		}
+		for key, values := range c.header {
+			r.Header[key] = values
+		}
+		for key, values := range req.Header {
+			r.Header[key] = values
+		}
+		r.Header.Set("Accept", "application/json, "+rest.ProblemContentType)
+		if body != nil {
+			r.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.http.Do(r)
+		wait, retry := c.backoff(attempt, req.Method, resp, err)
+		if !retry || ctx.Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("client: %s %s failed: %w", req.Method, req.Path, err)
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck
+			resp.Body.Close()
+		}
+
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("attempt", attempt+1),
+			attribute.String("backoff", wait.String()),
+		))
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff reports whether the attempt should be retried and how long to
+// wait before the retry.
+func (c *Client) backoff(attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.retry.MaxAttempts {
+		return 0, false
+	}
+
+	switch {
+	case err != nil:
+		if !isIdempotent(method) {
+			return 0, false
+		}
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		if d, ok := retryAfter(resp); ok {
+			if c.retry.MaxBackoff > 0 && d > c.retry.MaxBackoff {
+				return 0, false
+			}
+			return d, true
+		}
+	case resp.StatusCode == http.StatusBadGateway, resp.StatusCode == http.StatusGatewayTimeout:
+		if !isIdempotent(method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	d := c.retry.InitialBackoff << (attempt - 1)
+	if d <= 0 || (c.retry.MaxBackoff > 0 && d > c.retry.MaxBackoff) {
+		d = c.retry.MaxBackoff
+	}
+	if d <= 0 {
+		return 0, true
+	}
+	return d/2 + rand.N(d/2+1), true
+}
+
+// retryAfter returns the delay of the Retry-After header of a response.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// FormatParam formats the value of a path, query or header parameter.
+func FormatParam(v any) string {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// decodeProblem returns the problem of an error response. Responses without
+// problem details are described by their status and body.
+func decodeProblem(resp *http.Response) rest.Problem {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == rest.ProblemContentType || mediaType == "application/json" {
+		var p rest.Problem
+		if err := json.Unmarshal(data, &p); err == nil && (p.Title != "" || p.Status != 0) {
+			if p.Status == 0 {
+				p.Status = resp.StatusCode
+			}
+			return p
+		}
+	}
+
+	detail := strings.TrimSpace(string(data))
+	if len(detail) > 512 {
+		detail = detail[:512]
+	}
+	return rest.NewProblem(resp.StatusCode, detail)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/z5labs/humus/rest"
+)
+
+type pet struct {
+	Name string `json:"name"`
+}
+
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{Retry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})}, opts...)
+	c, err := New(srv.URL+"/api/", opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("ftp://example.com")
+	require.Error(t, err)
+
+	_, err = New("://")
+	require.Error(t, err)
+}
+
+func TestClient_Do(t *testing.T) {
+	t.Run("sends the request and decodes the response", func(t *testing.T) {
+		var got *http.Request
+		var body pet
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name":"rex"}`)) //nolint:errcheck
+		}, Header("X-API-Key", "secret"))
+
+		var out pet
+		err := c.Do(context.Background(), Request{
+			Operation: "createPet",
+			Method:    http.MethodPost,
+			Path:      "/owners/" + url.PathEscape("a/b") + "/pets",
+			Query:     url.Values{"tag": {"good", "dog"}},
+			Header:    http.Header{"X-Trace": {"1"}},
+			Body:      pet{Name: "rex"},
+		}, &out)
+		require.NoError(t, err)
+		require.Equal(t, pet{Name: "rex"}, out)
+
+		require.Equal(t, "/api/owners/a%2Fb/pets", got.URL.EscapedPath())
+		require.Equal(t, []string{"good", "dog"}, got.URL.Query()["tag"])
+		require.Equal(t, "secret", got.Header.Get("X-API-Key"))
+		require.Equal(t, "1", got.Header.Get("X-Trace"))
+		require.Equal(t, "application/json", got.Header.Get("Content-Type"))
+		require.Equal(t, pet{Name: "rex"}, body)
+	})
+
+	t.Run("decodes problem details", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", rest.ProblemContentType)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(rest.Problem{ //nolint:errcheck
+				Title:         "Bad Request",
+				Status:        http.StatusBadRequest,
+				InvalidParams: []rest.InvalidParam{{Name: "name", Reason: "is required"}},
+			})
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodPost, Path: "/pets", Body: pet{}}, nil)
+		var p rest.Problem
+		require.ErrorAs(t, err, &p)
+		require.Equal(t, http.StatusBadRequest, p.Status)
+		require.Equal(t, []rest.InvalidParam{{Name: "name", Reason: "is required"}}, p.InvalidParams)
+	})
+
+	t.Run("describes other error responses", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "no such pet", http.StatusNotFound)
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodGet, Path: "/pets/1"}, nil)
+		var p rest.Problem
+		require.ErrorAs(t, err, &p)
+		require.Equal(t, http.StatusNotFound, p.Status)
+		require.Equal(t, "no such pet", p.Detail)
+	})
+
+	t.Run("retries unavailable servers", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			require.JSONEq(t, `{"name":"rex"}`, string(b))
+			if attempts.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodPost, Path: "/pets", Body: pet{Name: "rex"}}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodGet, Path: "/pets"}, nil)
+		var p rest.Problem
+		require.ErrorAs(t, err, &p)
+		require.Equal(t, http.StatusBadGateway, p.Status)
+		require.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("does not retry non-idempotent requests on gateway failures", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodPost, Path: "/pets"}, nil)
+		require.Error(t, err)
+		require.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("does not wait for a long Retry-After", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		err := c.Do(context.Background(), Request{Method: http.MethodGet, Path: "/pets"}, nil)
+		var p rest.Problem
+		require.ErrorAs(t, err, &p)
+		require.Equal(t, http.StatusTooManyRequests, p.Status)
+		require.Equal(t, int32(1), attempts.Load())
+	})
+}
+
+func TestFormatParam(t *testing.T) {
+	require.Equal(t, "42", FormatParam(42))
+	require.Equal(t, "true", FormatParam(true))
+	require.Equal(t, "2026-01-02T03:04:05Z", FormatParam(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
@@ -0,0 +1,366 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package clientgen generates typed Go clients from the OpenAPI spec of a
+// REST API, e.g. the spec of the routes of a humus REST API returned by
+// [github.com/z5labs/humus/rest.Spec].
+//
+// Every operation becomes a method of the generated Client, named after its
+// operation ID, which takes the path, query and header parameters as a
+// struct, the JSON request body as the type generated from its schema and
+// returns the type generated from the schema of the successful response.
+// Schemas are generated as Go types along with the validation tags of humus.
+// The generated client uses [github.com/z5labs/humus/rest/client] to send
+// requests, which instruments them with OpenTelemetry, retries them and
+// returns error responses as a [github.com/z5labs/humus/rest.Problem].
+//
+// The restclientgen command wraps [Generate] for specs stored in files or
+// served by a running API. Specs can also be generated from the routes with
+// a small program run by go generate:
+//
+//	//go:build ignore
+//
+//	package main
+//
+//	func main() {
+//	    spec, err := rest.Spec(context.Background(), app.Options(nil)...)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    src, err := clientgen.Generate(spec, clientgen.Package("cartclient"))
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    if err := os.WriteFile("client.go", src, 0o644); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}
+package clientgen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/z5labs/humus/internal/openapi"
+)
+
+type options struct {
+	pkg string
+}
+
+// Option configures [Generate].
+type Option func(*options)
+
+// Package sets the name of the package of the generated code. It defaults to
+// "apiclient".
+func Package(name string) Option {
+	return func(o *options) {
+		o.pkg = name
+	}
+}
+
+// Generate returns the formatted source of a client of the API described by
+// the OpenAPI 3.x spec, in JSON or YAML. Operations without a JSON request
+// body, e.g. file uploads, are not supported and are skipped.
+func Generate(spec []byte, opts ...Option) ([]byte, error) {
+	o := &options{pkg: "apiclient"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !token.IsIdentifier(o.pkg) {
+		return nil, fmt.Errorf("clientgen: invalid package name %q", o.pkg)
+	}
+
+	doc, err := openapi.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		doc:     doc,
+		types:   openapi.NewGoTypes(doc, "Client", "New"),
+		imports: map[string]bool{"context": true},
+	}
+	for _, e := range doc.Endpoints() {
+		g.endpoint(e)
+	}
+
+	return g.source(o.pkg)
+}
+
+type generator struct {
+	doc     *openapi.Document
+	types   *openapi.GoTypes
+	imports map[string]bool
+	methods strings.Builder
+	skipped []string
+}
+
+func (g *generator) source(pkg string) ([]byte, error) {
+	var b strings.Builder
+	title := g.doc.Info.Title
+	if title == "" {
+		title = "REST"
+	}
+	if !strings.HasSuffix(title, "API") {
+		title += " API"
+	}
+	fmt.Fprintf(&b, "// Code generated by clientgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// Package %s is a client of the %s.\n", pkg, title)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	imports := slices.Concat(slices.Collect(maps.Keys(g.imports)), g.types.Imports())
+	slices.Sort(imports)
+	imports = slices.Compact(imports)
+	b.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString("\n\trestclient \"github.com/z5labs/humus/rest/client\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// Client is a client of the %s", title)
+	if g.doc.Info.Version != "" {
+		fmt.Fprintf(&b, " %s", g.doc.Info.Version)
+	}
+	b.WriteString(".\n")
+	if g.doc.Info.Description != "" {
+		b.WriteString("//\n" + openapi.Comment(g.doc.Info.Description))
+	}
+	if len(g.skipped) > 0 {
+		b.WriteString("//\n" + openapi.Comment("The client lacks the following operations, which do not have a JSON request body: "+strings.Join(g.skipped, ", ")+"."))
+	}
+	b.WriteString("type Client struct {\n\tc *restclient.Client\n}\n\n")
+	b.WriteString(`// New returns a client of the API served at baseURL.
+func New(baseURL string, opts ...restclient.Option) (*Client, error) {
+	c, err := restclient.New(baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c: c}, nil
+}
+
+`)
+	b.WriteString(g.methods.String())
+	for _, decl := range g.types.Decls() {
+		b.WriteString(decl + "\n")
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("clientgen: failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// param is a parameter of an operation along with its field.
+type param struct {
+	*openapi.Parameter
+	field string
+	typ   string
+}
+
+func (g *generator) endpoint(e openapi.Endpoint) {
+	name := methodName(e)
+	if e.RequestBody != nil && e.JSONRequestBody() == nil {
+		g.skipped = append(g.skipped, name)
+		return
+	}
+
+	var params []param
+	paramsType := ""
+	for _, p := range e.Parameters {
+		if p.In != "path" && p.In != "query" && p.In != "header" {
+			continue
+		}
+		if paramsType == "" {
+			paramsType = g.types.Reserve(name + "Params")
+		}
+		typ := g.types.GoType(p.Schema, paramsType+openapi.GoName(p.Name))
+		if !p.Required && p.In != "path" && !strings.HasPrefix(typ, "*") && !strings.HasPrefix(typ, "[]") {
+			typ = "*" + typ
+		}
+		params = append(params, param{Parameter: p, field: openapi.GoName(p.Name), typ: typ})
+	}
+	for _, seg := range pathParams(e.Path) {
+		if !slices.ContainsFunc(params, func(p param) bool { return p.In == "path" && p.Name == seg }) {
+			if paramsType == "" {
+				paramsType = g.types.Reserve(name + "Params")
+			}
+			params = append(params, param{Parameter: &openapi.Parameter{Name: seg, In: "path", Required: true}, field: openapi.GoName(seg), typ: "string"})
+		}
+	}
+
+	bodyType := ""
+	if s := e.JSONRequestBody(); s != nil {
+		bodyType = g.types.GoType(s, name+"Body")
+	}
+	respType := ""
+	if _, s := e.SuccessResponse(); s != nil {
+		respType = g.types.GoType(s, name+"Response")
+	}
+
+	b := &g.methods
+	if paramsType != "" {
+		fmt.Fprintf(b, "// %s are the parameters of %s.\ntype %s struct {\n", paramsType, name, paramsType)
+		for _, p := range params {
+			if p.Description != "" {
+				b.WriteString(openapi.Comment(p.Description))
+			}
+			fmt.Fprintf(b, "\t%s %s\n", p.field, p.typ)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	fmt.Fprintf(b, "// %s calls %s %s.\n", name, e.Method, e.Path)
+	if e.Summary != "" {
+		b.WriteString("//\n" + openapi.Comment(e.Summary))
+	}
+	if e.Description != "" && e.Description != e.Summary {
+		b.WriteString("//\n" + openapi.Comment(e.Description))
+	}
+	if e.Deprecated {
+		b.WriteString("//\n// Deprecated: the operation is deprecated by the API.\n")
+	}
+	args := []string{"ctx context.Context"}
+	if paramsType != "" {
+		args = append(args, "params "+paramsType)
+	}
+	if bodyType != "" {
+		args = append(args, "body "+bodyType)
+	}
+	results := "error"
+	if respType != "" {
+		results = "(" + respType + ", error)"
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), results)
+
+	g.imports["net/http"] = true
+	fmt.Fprintf(b, "\treq := restclient.Request{\n")
+	if e.OperationID != "" {
+		fmt.Fprintf(b, "\t\tOperation: %q,\n", e.OperationID)
+	}
+	fmt.Fprintf(b, "\t\tMethod: %s,\n", methodConst(e.Method))
+	fmt.Fprintf(b, "\t\tPath: %s,\n", g.pathExpr(e.Path, params))
+	if bodyType != "" {
+		b.WriteString("\t\tBody: body,\n")
+	}
+	b.WriteString("\t}\n")
+
+	for _, in := range []string{"query", "header"} {
+		first := true
+		for _, p := range params {
+			if p.In != in {
+				continue
+			}
+			if first {
+				if in == "query" {
+					g.imports["net/url"] = true
+					b.WriteString("\treq.Query = url.Values{}\n")
+				} else {
+					b.WriteString("\treq.Header = http.Header{}\n")
+				}
+				first = false
+			}
+			target := "req.Query"
+			if in == "header" {
+				target = "req.Header"
+			}
+			value := "params." + p.field
+			switch {
+			case strings.HasPrefix(p.typ, "[]"):
+				fmt.Fprintf(b, "\tfor _, v := range %s {\n\t\t%s.Add(%q, restclient.FormatParam(v))\n\t}\n", value, target, p.Name)
+			case strings.HasPrefix(p.typ, "*"):
+				fmt.Fprintf(b, "\tif %s != nil {\n\t\t%s.Set(%q, restclient.FormatParam(*%s))\n\t}\n", value, target, p.Name, value)
+			default:
+				fmt.Fprintf(b, "\t%s.Set(%q, restclient.FormatParam(%s))\n", target, p.Name, value)
+			}
+		}
+	}
+
+	if respType == "" {
+		b.WriteString("\treturn c.c.Do(ctx, req, nil)\n}\n\n")
+		return
+	}
+	fmt.Fprintf(b, "\tvar out %s\n\terr := c.c.Do(ctx, req, &out)\n\treturn out, err\n}\n\n", respType)
+}
+
+// pathExpr returns the expression of the escaped path of an operation.
+func (g *generator) pathExpr(path string, params []param) string {
+	var parts []string
+	remaining := path
+	for {
+		start := strings.Index(remaining, "{")
+		end := strings.Index(remaining, "}")
+		if start < 0 || end < start {
+			break
+		}
+		if start > 0 {
+			parts = append(parts, strconv.Quote(remaining[:start]))
+		}
+		name, _, _ := strings.Cut(remaining[start+1:end], ":")
+		field := openapi.GoName(name)
+		for _, p := range params {
+			if p.In == "path" && p.Name == name {
+				field = p.field
+			}
+		}
+		g.imports["net/url"] = true
+		parts = append(parts, fmt.Sprintf("url.PathEscape(restclient.FormatParam(params.%s))", field))
+		remaining = remaining[end+1:]
+	}
+	if remaining != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(remaining))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// pathParams returns the names of the parameters of a path.
+func pathParams(path string) []string {
+	var names []string
+	for seg := range strings.SplitSeq(path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name, _, _ := strings.Cut(seg[1:len(seg)-1], ":")
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// methodName returns the name of the method of an operation, which is derived
+// from the method and path of operations without an operation ID.
+func methodName(e openapi.Endpoint) string {
+	if e.OperationID != "" {
+		return openapi.GoName(e.OperationID)
+	}
+	return openapi.GoName(strings.ToLower(e.Method) + " " + e.Path)
+}
+
+func methodConst(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodHead:
+		return "http.MethodHead"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	case http.MethodDelete:
+		return "http.MethodDelete"
+	case http.MethodOptions:
+		return "http.MethodOptions"
+	case http.MethodTrace:
+		return "http.MethodTrace"
+	}
+	return strconv.Quote(method)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package clientgen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const petSpec = `
+openapi: 3.0.3
+info:
+  title: Pet Store
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: tag
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      deprecated: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/{petId}/photo:
+    put:
+      requestBody:
+        content:
+          image/png: {}
+      responses:
+        '204':
+          description: No Content
+  /pets/{petId}:
+    delete:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+            format: int64
+      responses:
+        '204':
+          description: No Content
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+        status:
+          type: string
+          enum: [available, sold]
+        owner:
+          type: object
+          properties:
+            id:
+              type: string
+`
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(petSpec), Package("petclient"))
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "client.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		"package petclient",
+		"func (c *Client) ListPets(ctx context.Context, params ListPetsParams) ([]Pet, error) {",
+		"Limit   *int",
+		"Tag     []string",
+		"XTenant string",
+		`req.Query.Set("limit", restclient.FormatParam(*params.Limit))`,
+		`req.Query.Add("tag", restclient.FormatParam(v))`,
+		`req.Header.Set("X-Tenant", restclient.FormatParam(params.XTenant))`,
+		"// Deprecated: the operation is deprecated by the API.",
+		"func (c *Client) CreatePet(ctx context.Context, body Pet) (Pet, error) {",
+		"func (c *Client) DeletePetsPetID(ctx context.Context, params DeletePetsPetIDParams) error {",
+		`Path:   "/pets/" + url.PathEscape(restclient.FormatParam(params.PetID)),`,
+		"PetID int64",
+		"Name   string    `json:\"name\" required:\"true\" minLength:\"1\"`",
+		"Owner  *PetOwner `json:\"owner,omitempty\"`",
+		"Status PetStatus `json:\"status,omitempty\" enum:\"available,sold\"`",
+		`PetStatusAvailable PetStatus = "available"`,
+		"The client lacks the following operations, which do not have a JSON request body: PutPetsPetIDPhoto.",
+	} {
+		require.Contains(t, string(src), want)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := Generate([]byte(petSpec), Package("pet-client"))
+	require.Error(t, err)
+
+	_, err = Generate([]byte(`swagger: "2.0"`))
+	require.Error(t, err)
+
+	_, err = Generate([]byte(`{`))
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package client is the runtime of typed clients generated from the OpenAPI
+// spec of a REST API by [github.com/z5labs/humus/rest/client/clientgen].
+//
+// Requests are sent with an [http.Client] instrumented with OpenTelemetry, so
+// the trace context is propagated to the API, and every call of an operation
+// is recorded as a span. Requests are retried according to a [RetryPolicy]
+// and error responses are returned as a [rest.Problem]:
+//
+//	item, err := carts.AddCartItem(ctx, cartclient.AddCartItemParams{CartID: id}, body)
+//	var p rest.Problem
+//	if errors.As(err, &p) && p.Status == http.StatusNotFound {
+//	    // ...
+//	}
+package client
@@ -0,0 +1,444 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/z5labs/humus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts JSON request and response bodies to and from another
+// representation, e.g. CBOR. Endpoints only handle JSON, while clients
+// choose the representation via the Content-Type and Accept headers.
+type Codec interface {
+	// MediaType is the media type of the representation.
+	MediaType() string
+
+	// Encode converts a JSON document into the representation.
+	Encode(data []byte) ([]byte, error)
+
+	// Decode converts the representation into a JSON document.
+	Decode(data []byte) ([]byte, error)
+}
+
+// Consumes allows clients to send the request body of the route in the
+// representations of the given codecs, in addition to JSON.
+func Consumes(codecs ...Codec) RouteOption {
+	return func(ro *routeOptions) {
+		ro.consumes = append(ro.consumes, codecs...)
+	}
+}
+
+// Produces allows clients to receive the response body of the route in the
+// representations of the given codecs, in addition to JSON.
+func Produces(codecs ...Codec) RouteOption {
+	return func(ro *routeOptions) {
+		ro.produces = append(ro.produces, codecs...)
+	}
+}
+
+// CBORContentType is the media type of [CBOR] documents.
+const CBORContentType = "application/cbor"
+
+var cborDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeFor[map[string]any](),
+}.DecMode()
+
+type cborCodec struct{}
+
+// CBOR returns a [Codec] for RFC 8949 Concise Binary Object Representation.
+func CBOR() Codec {
+	return cborCodec{}
+}
+
+func (cborCodec) MediaType() string {
+	return CBORContentType
+}
+
+func (cborCodec) Encode(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(fromJSONNumbers(v))
+}
+
+func (cborCodec) Decode(data []byte) ([]byte, error) {
+	var v any
+	if err := cborDecMode.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// fromJSONNumbers replaces the numbers of a decoded JSON document with
+// integers where possible, so they are not encoded as floating point numbers.
+func fromJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = fromJSONNumbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = fromJSONNumbers(e)
+		}
+	}
+	return v
+}
+
+// ProtobufContentType is the media type of [Protobuf] messages.
+const ProtobufContentType = "application/x-protobuf"
+
+type protobufCodec[M proto.Message] struct{}
+
+// Protobuf returns a [Codec] for Protocol Buffers messages of type M. The
+// JSON document of the endpoint must match the JSON mapping of M, where
+// fields are named as in the .proto file.
+func Protobuf[M proto.Message]() Codec {
+	return protobufCodec[M]{}
+}
+
+func (protobufCodec[M]) MediaType() string {
+	return ProtobufContentType
+}
+
+func (protobufCodec[M]) Encode(data []byte) ([]byte, error) {
+	m := newMessage[M]()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(m)
+}
+
+func (protobufCodec[M]) Decode(data []byte) ([]byte, error) {
+	m := newMessage[M]()
+	if err := proto.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return (protojson.MarshalOptions{UseProtoNames: true}).Marshal(m)
+}
+
+func newMessage[M proto.Message]() M {
+	var m M
+	return m.ProtoReflect().Type().New().Interface().(M)
+}
+
+// mediaTypeOf returns the media type of a Content-Type header value.
+func mediaTypeOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// codecFor returns the codec of the media type, if any.
+func codecFor(codecs []Codec, mediaType string) Codec {
+	for _, c := range codecs {
+		if c.MediaType() == mediaType {
+			return c
+		}
+	}
+	return nil
+}
+
+// supportsMediaType reports whether the media type is one of the supported
+// media types. Structured syntax suffixes are accepted for JSON, e.g.
+// application/merge-patch+json.
+func supportsMediaType(supported []string, mediaType string) bool {
+	if slices.Contains(supported, mediaType) {
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+json") && slices.Contains(supported, "application/json")
+}
+
+// negotiateMediaType returns the offered media type preferred by the client
+// according to the Accept header. Offers are preferred in the given order
+// at equal quality.
+func negotiateMediaType(accept string, offers []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	type mediaRange struct {
+		typ, subtype string
+		q            float64
+	}
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, _ := strings.Cut(mediaType, "/")
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, offer := range offers {
+		typ, subtype, _ := strings.Cut(offer, "/")
+
+		// The most specific matching range determines the quality.
+		specificity, q := 0, 0.0
+		for _, r := range ranges {
+			var s int
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 3
+			case r.typ == typ && r.subtype == "*":
+				s = 2
+			case r.typ == "*" && r.subtype == "*":
+				s = 1
+			default:
+				continue
+			}
+			if s > specificity {
+				specificity, q = s, r.q
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// negotiateHandler negotiates the representation of the request and
+// response bodies of a route. Request bodies of an unsupported media type are
+// rejected with 415 and requests accepting none of the response media types
+// with 406. Bodies in the representation of a codec are converted to and from
+// JSON for the endpoint.
+func negotiateHandler(op routeOperation, consumes, produces []Codec, next http.Handler) http.Handler {
+	accepted := slices.Clone(op.consumes)
+	for _, c := range consumes {
+		accepted = append(accepted, c.MediaType())
+	}
+	var offers []string
+	if len(op.produces) > 0 {
+		offers = slices.Clone(op.produces)
+		for _, c := range produces {
+			offers = append(offers, c.MediaType())
+		}
+	}
+	if len(accepted) == 0 && len(offers) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(accepted) > 0 && r.Body != nil && r.Body != http.NoBody && r.Header.Get("Content-Type") != "" {
+			mediaType := mediaTypeOf(r.Header.Get("Content-Type"))
+			if !supportsMediaType(accepted, mediaType) {
+				w.Header().Set("Accept", strings.Join(accepted, ", "))
+				writeProblem(w, http.StatusUnsupportedMediaType, fmt.Sprintf("The request body must be one of %s.", strings.Join(accepted, ", ")))
+				return
+			}
+			if c := codecFor(consumes, mediaType); c != nil && !decodeBody(w, r, c) {
+				return
+			}
+		}
+
+		if len(offers) > 0 {
+			if len(produces) > 0 {
+				w.Header().Add("Vary", "Accept")
+			}
+			mediaType, ok := negotiateMediaType(r.Header.Get("Accept"), offers)
+			if !ok {
+				writeProblem(w, http.StatusNotAcceptable, fmt.Sprintf("The response can only be served as one of %s.", strings.Join(offers, ", ")))
+				return
+			}
+			if c := codecFor(produces, mediaType); c != nil {
+				tw := &transcodeWriter{ResponseWriter: w, codec: c}
+				defer tw.close(r)
+				w = tw
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeBody replaces the request body with its JSON document, reporting
+// whether the body could be decoded.
+func decodeBody(w http.ResponseWriter, r *http.Request, c Codec) bool {
+	data, ok := readBody(w, r)
+	if !ok {
+		return false
+	}
+
+	data, err := c.Decode(data)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, fmt.Sprintf("The request body is not a valid %s document: %v", c.MediaType(), err))
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	r.ContentLength = int64(len(data))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Del("Content-Length")
+	return true
+}
+
+// readBody reads the request body, responding with 413 if it exceeds the
+// body size limit and with 400 if it cannot be read otherwise.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeBodyTooLarge(w, maxBytesErr.Limit)
+			return nil, false
+		}
+		writeProblem(w, http.StatusBadRequest, "The request body could not be read.")
+		return nil, false
+	}
+	return data, true
+}
+
+// transcodeWriter buffers successful JSON responses and converts them with
+// the codec once the handler returns.
+type transcodeWriter struct {
+	http.ResponseWriter
+	codec Codec
+
+	status    int
+	transcode bool
+	buf       bytes.Buffer
+}
+
+func (tw *transcodeWriter) WriteHeader(status int) {
+	if tw.status != 0 {
+		return
+	}
+	if isInformational(status) {
+		tw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	tw.status = status
+	tw.transcode = status >= 200 && status < 300 && mediaTypeOf(tw.Header().Get("Content-Type")) == "application/json"
+	if !tw.transcode {
+		tw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (tw *transcodeWriter) Write(b []byte) (int, error) {
+	if tw.status == 0 {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.transcode {
+		return tw.buf.Write(b)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (tw *transcodeWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+func (tw *transcodeWriter) close(r *http.Request) {
+	if !tw.transcode {
+		return
+	}
+
+	data, err := tw.codec.Encode(tw.buf.Bytes())
+	if err != nil {
+		log := humus.Logger("github.com/z5labs/humus/rest")
+		log.ErrorContext(r.Context(), "failed to encode response", slog.String("media_type", tw.codec.MediaType()), slog.Any("error", err))
+		writeProblem(tw.ResponseWriter, http.StatusInternalServerError, "The response could not be encoded.")
+		return
+	}
+
+	h := tw.Header()
+	h.Set("Content-Type", tw.codec.MediaType())
+	h.Set("Content-Length", strconv.Itoa(len(data)))
+	tw.ResponseWriter.WriteHeader(tw.status)
+	tw.ResponseWriter.Write(data) //nolint:errcheck
+}
+
+// contentTypes documents the media types and the body size limit of the
+// routes in the OpenAPI spec.
+func contentTypes(routes []authorizedRoute, maxBodyBytes int64) specDecorator {
+	return func(spec map[string]any) error {
+		paths := specObject(spec, "paths")
+		for _, route := range routes {
+			op := specObject(specObject(paths, route.op.path), strings.ToLower(route.op.method))
+			responses := specObject(op, "responses")
+
+			if reqBody, ok := op["requestBody"].(map[string]any); ok {
+				addMediaTypes(specObject(reqBody, "content"), route.authz.consumes)
+				addResponse(responses, http.StatusUnsupportedMediaType)
+
+				limit := maxBodyBytes
+				if route.authz.maxBodyBytes != 0 {
+					limit = route.authz.maxBodyBytes
+				}
+				if limit > 0 {
+					addResponse(responses, http.StatusRequestEntityTooLarge)
+				}
+			}
+
+			if len(route.authz.produces) == 0 {
+				continue
+			}
+			for code, resp := range responses {
+				resp, ok := resp.(map[string]any)
+				if !ok || !strings.HasPrefix(code, "2") {
+					continue
+				}
+				if content, ok := resp["content"].(map[string]any); ok {
+					addMediaTypes(content, route.authz.produces)
+				}
+			}
+			addResponse(responses, http.StatusNotAcceptable)
+		}
+		return nil
+	}
+}
+
+// addMediaTypes documents the media types of the codecs with the schema of
+// the JSON media type.
+func addMediaTypes(content map[string]any, codecs []Codec) {
+	jsonMediaType, ok := content["application/json"]
+	if !ok {
+		return
+	}
+	for _, c := range codecs {
+		content[c.MediaType()] = jsonMediaType
+	}
+}
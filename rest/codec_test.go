@@ -0,0 +1,308 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	offers := []string{"application/json", CBORContentType}
+
+	testCases := []struct {
+		name   string
+		accept string
+		want   string
+		ok     bool
+	}{
+		{name: "none", accept: "", want: "application/json", ok: true},
+		{name: "any", accept: "*/*", want: "application/json", ok: true},
+		{name: "exact", accept: "application/cbor", want: CBORContentType, ok: true},
+		{name: "type wildcard", accept: "application/*", want: "application/json", ok: true},
+		{name: "respects quality", accept: "application/json;q=0.5, application/cbor", want: CBORContentType, ok: true},
+		{name: "most specific range wins", accept: "application/*;q=0.1, application/cbor;q=0, */*", want: "application/json", ok: true},
+		{name: "unsupported", accept: "text/html", ok: false},
+		{name: "rejected", accept: "application/json;q=0, application/cbor;q=0", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := negotiateMediaType(tc.accept, offers)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCBOR(t *testing.T) {
+	c := CBOR()
+
+	data, err := c.Encode([]byte(`{"name":"rex","age":3,"weight":4.5,"tags":["dog"]}`))
+	require.NoError(t, err)
+
+	var v map[string]any
+	require.NoError(t, cbor.Unmarshal(data, &v))
+	require.Equal(t, map[string]any{"name": "rex", "age": uint64(3), "weight": 4.5, "tags": []any{"dog"}}, v)
+
+	data, err = c.Decode(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"rex","age":3,"weight":4.5,"tags":["dog"]}`, string(data))
+
+	_, err = c.Decode([]byte{0xff})
+	require.Error(t, err)
+}
+
+func TestProtobuf(t *testing.T) {
+	c := Protobuf[*structpb.Struct]()
+	require.Equal(t, ProtobufContentType, c.MediaType())
+
+	data, err := c.Encode([]byte(`{"name":"rex"}`))
+	require.NoError(t, err)
+
+	var s structpb.Struct
+	require.NoError(t, proto.Unmarshal(data, &s))
+	require.Equal(t, "rex", s.Fields["name"].GetStringValue())
+
+	data, err = c.Decode(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"rex"}`, string(data))
+}
+
+func TestNegotiateHandler(t *testing.T) {
+	op := routeOperation{
+		method:   http.MethodPost,
+		path:     "/pets",
+		consumes: []string{"application/json"},
+		produces: []string{"application/json"},
+	}
+
+	var gotBody string
+	h := negotiateHandler(op, []Codec{CBOR()}, []Codec{CBOR()}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name":"rex"}`)) //nolint:errcheck
+	}))
+
+	serve := func(contentType string, body []byte, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/pets", bytes.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("passes JSON through", func(t *testing.T) {
+		w := serve("application/json", []byte(`{"name":"rex"}`), "application/json")
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, `{"name":"rex"}`, gotBody)
+		require.Equal(t, "Accept", w.Header().Get("Vary"))
+	})
+
+	t.Run("converts CBOR", func(t *testing.T) {
+		body, err := cbor.Marshal(map[string]any{"name": "rex"})
+		require.NoError(t, err)
+
+		w := serve(CBORContentType, body, CBORContentType)
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Equal(t, `{"name":"rex"}`, gotBody)
+		require.Equal(t, CBORContentType, w.Header().Get("Content-Type"))
+
+		var resp map[string]any
+		require.NoError(t, cbor.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, map[string]any{"name": "rex"}, resp)
+	})
+
+	t.Run("rejects unsupported request bodies", func(t *testing.T) {
+		w := serve("text/plain", []byte("rex"), "")
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		require.Equal(t, "application/json, application/cbor", w.Header().Get("Accept"))
+	})
+
+	t.Run("rejects invalid request bodies", func(t *testing.T) {
+		w := serve(CBORContentType, []byte{0xff}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("rejects unacceptable responses", func(t *testing.T) {
+		w := serve("application/json", []byte(`{}`), "application/xml")
+		require.Equal(t, http.StatusNotAcceptable, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+}
+
+func TestNegotiateHandler_Informational(t *testing.T) {
+	op := routeOperation{
+		method:   http.MethodGet,
+		path:     "/pets",
+		produces: []string{"application/json"},
+	}
+
+	h := negotiateHandler(op, nil, []Codec{CBOR()}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name":"rex"}`)) //nolint:errcheck
+	}))
+	srv := httptest.NewServer(bodyLimitHandler(16, h))
+	defer srv.Close()
+
+	var informational []int
+	ctx := httptrace.WithClientTrace(t.Context(), &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			informational = append(informational, code)
+			return nil
+		},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/pets", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", CBORContentType)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, []int{http.StatusEarlyHints}, informational)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, CBORContentType, resp.Header.Get("Content-Type"))
+
+	var pet map[string]any
+	require.NoError(t, cbor.NewDecoder(resp.Body).Decode(&pet))
+	require.Equal(t, map[string]any{"name": "rex"}, pet)
+}
+
+func TestRun_ContentNegotiation(t *testing.T) {
+	port := freePort(t)
+
+	ep := bedrockrest.POST("/pets", func(ctx context.Context, req bedrockrest.Request[testResponse]) (testResponse, error) {
+		return testResponse{Message: "created " + req.Body().Message}, nil
+	})
+	ep = bedrockrest.ReadJSON[testResponse](ep)
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusCreated, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			MaxRequestBodyBytes(bedrockconfig.ReaderOf(int64(16))),
+			Handle(route, Consumes(CBOR()), Produces(CBOR()), MaxBodyBytes(64)),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	post := func(t *testing.T, path, contentType string, body []byte, accept string) *http.Response {
+		t.Helper()
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			req, err := http.NewRequest(http.MethodPost, baseURL+path, bytes.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("Accept", accept)
+
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("serves CBOR", func(t *testing.T) {
+		body, err := cbor.Marshal(map[string]any{"message": "rex the good dog"})
+		require.NoError(t, err)
+
+		resp := post(t, "/pets", CBORContentType, body, CBORContentType)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, CBORContentType, resp.Header.Get("Content-Type"))
+
+		var got testResponse
+		require.NoError(t, cbor.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, "created rex the good dog", got.Message)
+	})
+
+	t.Run("applies the body limit of the route", func(t *testing.T) {
+		body := []byte(`{"message":"` + strings.Repeat("a", 64) + `"}`)
+
+		resp := post(t, "/pets", "application/json", body, "application/json")
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+		var p Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		require.Equal(t, http.StatusRequestEntityTooLarge, p.Status)
+	})
+
+	t.Run("rejects unsupported media types", func(t *testing.T) {
+		resp := post(t, "/pets", "application/xml", []byte(`<pet/>`), "")
+		require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+		resp = post(t, "/pets", "application/json", []byte(`{}`), "application/xml")
+		require.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+	})
+
+	t.Run("documents the media types in the spec", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/openapi.json", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RequestBody struct {
+					Content map[string]any `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content map[string]any `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+		op := spec.Paths["/pets"]["post"]
+		require.Contains(t, op.RequestBody.Content, CBORContentType)
+		require.Contains(t, op.Responses["201"].Content, CBORContentType)
+		require.Equal(t, op.Responses["201"].Content["application/json"], op.Responses["201"].Content[CBORContentType])
+		for _, code := range []string{"406", "413", "415"} {
+			require.Contains(t, op.Responses, code)
+		}
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// minCompressSize is the minimum response size worth compressing.
+const minCompressSize = 1024
+
+// Compression compresses responses with zstd or gzip, as negotiated with the
+// Accept-Encoding request header. Only textual responses of at least 1 KiB are
+// compressed. The default is read from HUMUS_REST_COMPRESSION_ENABLED (false).
+func Compression() Option {
+	return func(o *options) {
+		o.compression = bedrockconfig.ReaderOf(true)
+	}
+}
+
+// encoder is a compressing writer which can be reused.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var (
+	gzipEncoders = sync.Pool{
+		New: func() any {
+			return gzip.NewWriter(io.Discard)
+		},
+	}
+	zstdEncoders = sync.Pool{
+		New: func() any {
+			// The options are valid, so NewWriter does not fail.
+			enc, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+			return enc
+		},
+	}
+)
+
+// negotiateEncoding returns the preferred content coding supported by the
+// client, or an empty string if the response should not be compressed.
+func negotiateEncoding(acceptEncoding string) string {
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "zstd" && coding != "gzip" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		// zstd is preferred over gzip at equal quality.
+		if q > bestQ || (q == bestQ && coding == "zstd") {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressible reports whether responses of the content type benefit from compression.
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-ndjson", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// compressHandler compresses responses with the content coding negotiated
+// with the client.
+func compressHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		coding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if coding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, coding: coding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressWriter buffers the start of the response until it is known
+// whether the response is worth compressing.
+type compressWriter struct {
+	http.ResponseWriter
+	coding string
+
+	status  int
+	decided bool
+	buf     []byte
+	enc     encoder
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status != 0 {
+		return
+	}
+	if isInformational(status) {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	cw.status = status
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		cw.buf = append(cw.buf, b...)
+		if len(cw.buf) < minCompressSize {
+			return len(b), nil
+		}
+		buf := cw.buf
+		cw.buf = nil
+		if err := cw.start(buf); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// start decides whether to compress the response and writes the buffered start.
+func (cw *compressWriter) start(buf []byte) error {
+	h := cw.Header()
+	cw.decide(len(buf) >= minCompressSize && h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")))
+	if cw.enc != nil {
+		_, err := cw.enc.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// decide writes the response headers, which must not change afterwards.
+func (cw *compressWriter) decide(compress bool) {
+	cw.decided = true
+	if compress {
+		h := cw.Header()
+		h.Set("Content-Encoding", cw.coding)
+		h.Del("Content-Length")
+		h.Del("Accept-Ranges")
+		switch cw.coding {
+		case "zstd":
+			cw.enc = zstdEncoders.Get().(encoder)
+		default:
+			cw.enc = gzipEncoders.Get().(encoder)
+		}
+		cw.enc.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// Flush writes any buffered data to the client, e.g. for streaming responses.
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		buf := cw.buf
+		cw.buf = nil
+		cw.decide(false)
+		cw.ResponseWriter.Write(buf) //nolint:errcheck
+	}
+	if cw.enc != nil {
+		cw.enc.Flush() //nolint:errcheck
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush() //nolint:errcheck
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) close() {
+	if cw.status == 0 {
+		// The handler did not write a response, e.g. because it panicked.
+		return
+	}
+	if !cw.decided {
+		buf := cw.buf
+		cw.buf = nil
+		cw.start(buf) //nolint:errcheck
+	}
+	if cw.enc == nil {
+		return
+	}
+	cw.enc.Close() //nolint:errcheck
+	cw.enc.Reset(io.Discard)
+	switch cw.coding {
+	case "zstd":
+		zstdEncoders.Put(cw.enc)
+	default:
+		gzipEncoders.Put(cw.enc)
+	}
+	cw.enc = nil
+}
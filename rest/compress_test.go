@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "none", acceptEncoding: "", want: ""},
+		{name: "unsupported", acceptEncoding: "br, deflate", want: ""},
+		{name: "gzip", acceptEncoding: "gzip, deflate", want: "gzip"},
+		{name: "prefers zstd", acceptEncoding: "gzip, zstd", want: "zstd"},
+		{name: "respects quality", acceptEncoding: "zstd;q=0.5, gzip", want: "gzip"},
+		{name: "rejected", acceptEncoding: "gzip;q=0", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, negotiateEncoding(tc.acceptEncoding))
+		})
+	}
+}
+
+func TestCompressHandler(t *testing.T) {
+	large := strings.Repeat(`{"message":"hello"}`, 100)
+
+	serve := func(contentType, body, acceptEncoding string) *httptest.ResponseRecorder {
+		h := compressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			for i := 0; i < len(body); i += 100 {
+				w.Write([]byte(body[i:min(i+100, len(body))])) //nolint:errcheck
+			}
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("compresses with gzip", func(t *testing.T) {
+		w := serve("application/json", large, "gzip")
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+		zr, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		b, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Equal(t, large, string(b))
+	})
+
+	t.Run("compresses with zstd", func(t *testing.T) {
+		w := serve("application/json", large, "zstd")
+		require.Equal(t, "zstd", w.Header().Get("Content-Encoding"))
+
+		zr, err := zstd.NewReader(w.Body)
+		require.NoError(t, err)
+		defer zr.Close()
+		b, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Equal(t, large, string(b))
+	})
+
+	t.Run("skips small responses", func(t *testing.T) {
+		w := serve("application/json", `{"message":"hello"}`, "gzip")
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, `{"message":"hello"}`, w.Body.String())
+	})
+
+	t.Run("skips incompressible content types", func(t *testing.T) {
+		w := serve("image/png", large, "gzip")
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, large, w.Body.String())
+	})
+
+	t.Run("skips clients without support", func(t *testing.T) {
+		w := serve("application/json", large, "")
+		require.Empty(t, w.Header().Get("Content-Encoding"))
+		require.Equal(t, large, w.Body.String())
+	})
+}
+
+func TestCompressHandler_Informational(t *testing.T) {
+	large := strings.Repeat(`{"message":"hello"}`, 100)
+
+	srv := httptest.NewServer(compressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", "</style.css>; rel=preload; as=style")
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, large) //nolint:errcheck
+	})))
+	defer srv.Close()
+
+	var informational []int
+	ctx := httptrace.WithClientTrace(t.Context(), &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			informational = append(informational, code)
+			return nil
+		},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, []int{http.StatusEarlyHints}, informational)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	zr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	b, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, large, string(b))
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// CORSOption configures the Cross-Origin Resource Sharing policy set with [CORS].
+type CORSOption func(*corsOptions)
+
+type corsOptions struct {
+	allowedOrigins   bedrockconfig.Reader[[]string]
+	allowedMethods   bedrockconfig.Reader[[]string]
+	allowedHeaders   bedrockconfig.Reader[[]string]
+	exposedHeaders   bedrockconfig.Reader[[]string]
+	allowCredentials bedrockconfig.Reader[bool]
+	maxAge           bedrockconfig.Reader[time.Duration]
+}
+
+func defaultCORSOptions() corsOptions {
+	return corsOptions{
+		allowedOrigins: splitList(bedrockconfig.Env("HUMUS_REST_CORS_ALLOWED_ORIGINS")),
+		allowedMethods: bedrockconfig.Default(
+			[]string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+			splitList(bedrockconfig.Env("HUMUS_REST_CORS_ALLOWED_METHODS")),
+		),
+		allowedHeaders: splitList(bedrockconfig.Env("HUMUS_REST_CORS_ALLOWED_HEADERS")),
+		exposedHeaders: splitList(bedrockconfig.Env("HUMUS_REST_CORS_EXPOSED_HEADERS")),
+		allowCredentials: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_CORS_ALLOW_CREDENTIALS")),
+		),
+		maxAge: bedrockconfig.Default(
+			10*time.Minute,
+			bedrockconfig.DurationFromString(bedrockconfig.Env("HUMUS_REST_CORS_MAX_AGE")),
+		),
+	}
+}
+
+// CORS configures the Cross-Origin Resource Sharing policy, which allows
+// browsers to call the API from the allowed origins. CORS is enabled when any
+// origin is allowed, either with [AllowOrigins] or via
+// HUMUS_REST_CORS_ALLOWED_ORIGINS. Preflight requests are answered before
+// routing, authentication and rate limiting.
+func CORS(opts ...CORSOption) Option {
+	return func(o *options) {
+		for _, opt := range opts {
+			opt(&o.cors)
+		}
+	}
+}
+
+// AllowOrigins sets the origins allowed to call the API, e.g.
+// "https://app.example.com". "https://*.example.com" allows any subdomain of
+// example.com and "*" allows any origin. The default is read from
+// HUMUS_REST_CORS_ALLOWED_ORIGINS as a comma-separated list.
+func AllowOrigins(r bedrockconfig.Reader[[]string]) CORSOption {
+	return func(co *corsOptions) {
+		co.allowedOrigins = r
+	}
+}
+
+// AllowMethods sets the methods allowed in cross-origin requests. The default
+// is read from HUMUS_REST_CORS_ALLOWED_METHODS as a comma-separated list
+// (GET, HEAD, POST, PUT, PATCH and DELETE).
+func AllowMethods(r bedrockconfig.Reader[[]string]) CORSOption {
+	return func(co *corsOptions) {
+		co.allowedMethods = r
+	}
+}
+
+// AllowHeaders sets the request headers allowed in cross-origin requests,
+// where "*" allows any header. The default is read from
+// HUMUS_REST_CORS_ALLOWED_HEADERS as a comma-separated list (Accept,
+// Authorization, Content-Type and the API key and request ID headers).
+func AllowHeaders(r bedrockconfig.Reader[[]string]) CORSOption {
+	return func(co *corsOptions) {
+		co.allowedHeaders = r
+	}
+}
+
+// ExposeHeaders sets the response headers browsers expose to cross-origin
+// callers. The default is read from HUMUS_REST_CORS_EXPOSED_HEADERS as a
+// comma-separated list (the request ID, Retry-After and RateLimit-* headers).
+func ExposeHeaders(r bedrockconfig.Reader[[]string]) CORSOption {
+	return func(co *corsOptions) {
+		co.exposedHeaders = r
+	}
+}
+
+// AllowCredentials allows cross-origin requests to carry cookies and
+// credentials. It cannot be combined with allowing any origin, since any
+// website could then make credentialed requests and read the responses. The
+// default is read from HUMUS_REST_CORS_ALLOW_CREDENTIALS (false).
+func AllowCredentials(r bedrockconfig.Reader[bool]) CORSOption {
+	return func(co *corsOptions) {
+		co.allowCredentials = r
+	}
+}
+
+// PreflightMaxAge sets how long browsers may cache the result of a preflight
+// request. The default is read from HUMUS_REST_CORS_MAX_AGE (10m).
+func PreflightMaxAge(r bedrockconfig.Reader[time.Duration]) CORSOption {
+	return func(co *corsOptions) {
+		co.maxAge = r
+	}
+}
+
+// corsPolicy is the resolved Cross-Origin Resource Sharing policy.
+type corsPolicy struct {
+	anyOrigin        bool
+	origins          []string
+	subdomains       []originPattern
+	methods          []string
+	anyHeader        bool
+	headers          []string
+	exposedHeaders   []string
+	allowCredentials bool
+	maxAge           time.Duration
+}
+
+// ErrCORSCredentialsWithAnyOrigin is returned when credentials are allowed
+// along with any origin, which would let any website make credentialed
+// requests and read the responses.
+var ErrCORSCredentialsWithAnyOrigin = errors.New("rest: CORS credentials cannot be allowed for any origin")
+
+// originPattern matches the subdomains of a host, e.g. https://*.example.com.
+type originPattern struct {
+	scheme string
+	suffix string
+}
+
+// buildCORSPolicy reads the CORS policy, returning false if CORS is disabled.
+func buildCORSPolicy(ctx context.Context, o *options) (corsPolicy, bool, error) {
+	origins := bedrockconfig.MustOr(ctx, nil, o.cors.allowedOrigins)
+	if len(origins) == 0 {
+		return corsPolicy{}, false, nil
+	}
+	methods, err := bedrockconfig.Read(ctx, o.cors.allowedMethods)
+	if err != nil {
+		return corsPolicy{}, false, err
+	}
+	headers := bedrockconfig.MustOr(ctx, nil, o.cors.allowedHeaders)
+	exposedHeaders := bedrockconfig.MustOr(ctx, nil, o.cors.exposedHeaders)
+	allowCredentials, err := bedrockconfig.Read(ctx, o.cors.allowCredentials)
+	if err != nil {
+		return corsPolicy{}, false, err
+	}
+	maxAge, err := bedrockconfig.Read(ctx, o.cors.maxAge)
+	if err != nil {
+		return corsPolicy{}, false, err
+	}
+	apiKeyHeader, err := bedrockconfig.Read(ctx, o.apiKeyHeader)
+	if err != nil {
+		return corsPolicy{}, false, err
+	}
+	requestIDHeader, err := bedrockconfig.Read(ctx, o.requestIDHeader)
+	if err != nil {
+		return corsPolicy{}, false, err
+	}
+
+	if len(headers) == 0 {
+		headers = []string{"Accept", "Authorization", "Content-Type", apiKeyHeader, requestIDHeader}
+	}
+	if len(exposedHeaders) == 0 {
+		exposedHeaders = []string{requestIDHeader, "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"}
+	}
+
+	p := corsPolicy{
+		methods:          methods,
+		exposedHeaders:   exposedHeaders,
+		allowCredentials: allowCredentials,
+		maxAge:           maxAge,
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			p.anyOrigin = true
+			continue
+		}
+		if scheme, host, ok := strings.Cut(origin, "://*."); ok {
+			p.subdomains = append(p.subdomains, originPattern{scheme: strings.ToLower(scheme), suffix: "." + strings.ToLower(host)})
+			continue
+		}
+		p.origins = append(p.origins, strings.ToLower(origin))
+	}
+	if p.anyOrigin && p.allowCredentials {
+		return corsPolicy{}, false, ErrCORSCredentialsWithAnyOrigin
+	}
+	for _, h := range headers {
+		if h == "*" {
+			p.anyHeader = true
+			continue
+		}
+		p.headers = append(p.headers, http.CanonicalHeaderKey(h))
+	}
+	return p, true, nil
+}
+
+// allowsOrigin reports whether cross-origin requests from the origin are allowed.
+func (p corsPolicy) allowsOrigin(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if slices.Contains(p.origins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, pattern := range p.subdomains {
+		if u.Scheme == pattern.scheme && strings.HasSuffix(u.Host, pattern.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders reports whether all of the comma-separated headers are allowed.
+func (p corsPolicy) allowsHeaders(headers string) bool {
+	if p.anyHeader {
+		return true
+	}
+	for h := range strings.SplitSeq(headers, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" && !slices.Contains(p.headers, http.CanonicalHeaderKey(h)) {
+			return false
+		}
+	}
+	return true
+}
+
+// corsHandler answers preflight requests and adds the CORS headers to the
+// responses of cross-origin requests from allowed origins. Requests which
+// are not allowed are served without CORS headers, so browsers block them.
+func corsHandler(p corsPolicy, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !preflight {
+			if p.allowsOrigin(origin) {
+				p.allowOrigin(h, origin)
+				if len(p.exposedHeaders) > 0 {
+					h.Set("Access-Control-Expose-Headers", strings.Join(p.exposedHeaders, ", "))
+				}
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+
+		method := r.Header.Get("Access-Control-Request-Method")
+		requestHeaders := r.Header.Get("Access-Control-Request-Headers")
+		if p.allowsOrigin(origin) && slices.Contains(p.methods, method) && p.allowsHeaders(requestHeaders) {
+			p.allowOrigin(h, origin)
+			h.Set("Access-Control-Allow-Methods", strings.Join(p.methods, ", "))
+			if requestHeaders != "" {
+				h.Set("Access-Control-Allow-Headers", requestHeaders)
+			}
+			if p.maxAge > 0 {
+				h.Set("Access-Control-Max-Age", strconv.Itoa(int(p.maxAge.Seconds())))
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (p corsPolicy) allowOrigin(h http.Header, origin string) {
+	if p.anyOrigin {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	// Credentialed requests require the origin to be echoed.
+	h.Set("Access-Control-Allow-Origin", origin)
+	if p.allowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestBuildCORSPolicy(t *testing.T) {
+	t.Run("is disabled without allowed origins", func(t *testing.T) {
+		_, enabled, err := buildCORSPolicy(context.Background(), defaultOptions())
+		require.NoError(t, err)
+		require.False(t, enabled)
+	})
+
+	t.Run("reads env vars", func(t *testing.T) {
+		t.Setenv("HUMUS_REST_CORS_ALLOWED_ORIGINS", "https://app.example.com, https://*.example.org")
+		t.Setenv("HUMUS_REST_CORS_ALLOWED_METHODS", "GET,POST")
+		t.Setenv("HUMUS_REST_CORS_ALLOW_CREDENTIALS", "true")
+		t.Setenv("HUMUS_REST_CORS_MAX_AGE", "1h")
+
+		p, enabled, err := buildCORSPolicy(context.Background(), defaultOptions())
+		require.NoError(t, err)
+		require.True(t, enabled)
+		require.Equal(t, corsPolicy{
+			origins:          []string{"https://app.example.com"},
+			subdomains:       []originPattern{{scheme: "https", suffix: ".example.org"}},
+			methods:          []string{http.MethodGet, http.MethodPost},
+			headers:          []string{"Accept", "Authorization", "Content-Type", "X-Api-Key", "X-Request-Id"},
+			exposedHeaders:   []string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"},
+			allowCredentials: true,
+			maxAge:           time.Hour,
+		}, p)
+	})
+
+	t.Run("rejects credentials for any origin", func(t *testing.T) {
+		t.Setenv("HUMUS_REST_CORS_ALLOWED_ORIGINS", "https://app.example.com,*")
+		t.Setenv("HUMUS_REST_CORS_ALLOW_CREDENTIALS", "true")
+
+		_, _, err := buildCORSPolicy(context.Background(), defaultOptions())
+		require.ErrorIs(t, err, ErrCORSCredentialsWithAnyOrigin)
+	})
+}
+
+func TestCORSPolicy_AllowsOrigin(t *testing.T) {
+	p := corsPolicy{
+		origins:    []string{"https://app.example.com"},
+		subdomains: []originPattern{{scheme: "https", suffix: ".example.org"}},
+	}
+
+	testCases := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://app.example.com", want: true},
+		{origin: "https://APP.example.com", want: true},
+		{origin: "http://app.example.com", want: false},
+		{origin: "https://evil.example.com", want: false},
+		{origin: "https://a.example.org", want: true},
+		{origin: "https://a.b.example.org:8443", want: false},
+		{origin: "https://a.b.example.org", want: true},
+		{origin: "https://example.org", want: false},
+		{origin: "https://evilexample.org", want: false},
+		{origin: "http://a.example.org", want: false},
+		{origin: "null", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.origin, func(t *testing.T) {
+			require.Equal(t, tc.want, p.allowsOrigin(tc.origin))
+		})
+	}
+}
+
+func TestCORSHandler(t *testing.T) {
+	p := corsPolicy{
+		origins:        []string{"https://app.example.com"},
+		methods:        []string{http.MethodGet, http.MethodPost},
+		headers:        []string{"Content-Type", "Authorization"},
+		exposedHeaders: []string{"X-Request-ID"},
+		maxAge:         time.Hour,
+	}
+
+	serve := func(p corsPolicy, method string, header http.Header) *httptest.ResponseRecorder {
+		h := corsHandler(p, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		r := httptest.NewRequest(method, "/pets", nil)
+		r.Header = header
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("answers allowed preflight requests", func(t *testing.T) {
+		w := serve(p, http.MethodOptions, http.Header{
+			"Origin":                         {"https://app.example.com"},
+			"Access-Control-Request-Method":  {http.MethodPost},
+			"Access-Control-Request-Headers": {"content-type, authorization"},
+		})
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "content-type, authorization", w.Header().Get("Access-Control-Allow-Headers"))
+		require.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("rejects disallowed preflight requests", func(t *testing.T) {
+		for name, header := range map[string]http.Header{
+			"origin": {
+				"Origin":                        {"https://evil.example.com"},
+				"Access-Control-Request-Method": {http.MethodGet},
+			},
+			"method": {
+				"Origin":                        {"https://app.example.com"},
+				"Access-Control-Request-Method": {http.MethodDelete},
+			},
+			"header": {
+				"Origin":                         {"https://app.example.com"},
+				"Access-Control-Request-Method":  {http.MethodGet},
+				"Access-Control-Request-Headers": {"X-Custom"},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				w := serve(p, http.MethodOptions, header)
+				require.Equal(t, http.StatusNoContent, w.Code)
+				require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+			})
+		}
+	})
+
+	t.Run("adds headers to cross-origin requests", func(t *testing.T) {
+		w := serve(p, http.MethodGet, http.Header{"Origin": {"https://app.example.com"}})
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+		require.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+	})
+
+	t.Run("serves disallowed origins without headers", func(t *testing.T) {
+		w := serve(p, http.MethodGet, http.Header{"Origin": {"https://evil.example.com"}})
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("allows any origin", func(t *testing.T) {
+		w := serve(corsPolicy{anyOrigin: true}, http.MethodGet, http.Header{"Origin": {"https://app.example.com"}})
+		require.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+}
+
+func TestRun_CORS(t *testing.T) {
+	port := freePort(t)
+
+	ep := bedrockrest.GET("/hello", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{Message: "hello"}, nil
+	})
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			APIKeyAuth(APIKeys{"alice-key": {Subject: "alice"}}),
+			CORS(AllowOrigins(bedrockconfig.ReaderOf([]string{"https://*.example.com"}))),
+			Handle(route),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	do := func(t *testing.T, method string, header http.Header) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(method, baseURL+"/hello", nil)
+		require.NoError(t, err)
+		req.Header = header
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("answers preflight requests before authentication", func(t *testing.T) {
+		resp := do(t, http.MethodOptions, http.Header{
+			"Origin":                         {"https://app.example.com"},
+			"Access-Control-Request-Method":  {http.MethodGet},
+			"Access-Control-Request-Headers": {"X-API-Key"},
+		})
+
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "X-API-Key", resp.Header.Get("Access-Control-Allow-Headers"))
+	})
+
+	t.Run("adds headers to rejected requests", func(t *testing.T) {
+		resp := do(t, http.MethodGet, http.Header{"Origin": {"https://app.example.com"}})
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("adds headers to responses", func(t *testing.T) {
+		resp := do(t, http.MethodGet, http.Header{
+			"Origin":    {"https://app.example.com"},
+			"X-Api-Key": {"alice-key"},
+		})
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -6,8 +6,36 @@
 // Package rest provides an opinionated HTTPS REST server built on top of
 // bedrock's HTTP and OTel runtimes.
 //
-// The server is HTTPS-only. If no TLS certificate is provided, a self-signed
+// The server serves HTTPS by default. If no TLS certificate is provided, a self-signed
 // certificate is generated automatically at startup (suitable for development).
+// PEM certificate files are checked for changes, e.g. when rotated by cert-manager,
+// and reloaded without a restart.
+//
+// Certificates can instead be provisioned automatically from Let's Encrypt or any
+// other ACME CA by listing the served domains with [ACMEDomains]. The TLS-ALPN-01
+// challenge is answered on the HTTPS port; [ACMEHTTPChallengePort] additionally
+// enables the HTTP-01 challenge. Issued certificates are stored in [ACMECache]
+// and renewed before they expire.
+//
+// Setting a client CA file enables mutual TLS. The identity of a verified client
+// certificate (subject, SANs and SPIFFE ID) is available to handlers through
+// [PeerIdentityFromContext].
+//
+// [HTTP3] additionally serves HTTP/3 over QUIC on the same port number (UDP) with
+// the same handler, TLS config and OTel instrumentation. HTTPS responses advertise
+// it to clients with an Alt-Svc header.
+//
+// When TLS is terminated elsewhere, e.g. by a service mesh sidecar, [Insecure]
+// switches to plain HTTP and [H2C] additionally enables HTTP/2 over cleartext.
+//
+// [DocsUI] serves an interactive Swagger UI for the OpenAPI spec, with its assets
+// embedded in the binary. [Spec] returns the spec without running a server,
+// e.g. to generate a typed Go client of the API with the
+// [github.com/z5labs/humus/rest/client/clientgen] package or the
+// restclientgen command. Conversely, teams which write the spec first can
+// generate route constructors and handler interfaces from it with the
+// [github.com/z5labs/humus/rest/servergen] package or the restservergen
+// command.
 //
 // All framework-level configuration is read from environment variables so no
 // config file is required. Options passed to [Run] override the env var defaults.
@@ -20,8 +48,41 @@
 //   - HUMUS_REST_WRITE_TIMEOUT   - Maximum duration for writing a response (default: 10s)
 //   - HUMUS_REST_IDLE_TIMEOUT    - Maximum idle time between keep-alive requests (default: 120s)
 //   - HUMUS_REST_MAX_HEADER_BYTES - Maximum size of request headers in bytes (default: 1048576)
+//   - HUMUS_REST_MAX_BODY_BYTES  - Maximum size of request bodies in bytes, 0 for no limit (default: 0)
+//   - HUMUS_REST_TLS_CERT_FILE   - Path to a PEM encoded certificate (chain)
+//   - HUMUS_REST_TLS_KEY_FILE    - Path to the PEM encoded private key for the certificate
+//   - HUMUS_REST_TLS_CA_FILE     - Path to PEM encoded CA certificates appended to the served chain (optional)
 //   - HUMUS_REST_TLS_PKCS12_FILE     - Path to a DER-encoded PKCS#12 file containing the certificate and private key
 //   - HUMUS_REST_TLS_PKCS12_PASSWORD - Password for the PKCS#12 file (empty string if no password)
+//   - HUMUS_REST_TLS_CLIENT_CA_FILE - Path to PEM encoded CA certificates used to verify client certificates (enables mTLS)
+//   - HUMUS_REST_TLS_CLIENT_AUTH - Client certificate policy: none, request, require, verify_if_given or require_and_verify
+//     (default: require_and_verify if a client CA file is set, otherwise none)
+//   - HUMUS_REST_ACME_DOMAINS    - Comma-separated domains to obtain certificates for via ACME (enables ACME)
+//   - HUMUS_REST_ACME_EMAIL      - Contact email of the ACME account (optional)
+//   - HUMUS_REST_ACME_DIRECTORY_URL - ACME directory URL (default: Let's Encrypt production)
+//   - HUMUS_REST_ACME_DIRECTORY_CA_FILE - Path to PEM encoded CA certificates trusted for the ACME directory (optional)
+//   - HUMUS_REST_ACME_CACHE_DIR  - Directory to store issued certificates and the account key in (default: memory only)
+//   - HUMUS_REST_ACME_HTTP_PORT  - Port to answer HTTP-01 challenges on, e.g. 80 (optional)
+//   - HUMUS_REST_JWT_ISSUER      - Issuer of accepted JWT bearer tokens (enables JWT authentication)
+//   - HUMUS_REST_JWT_AUDIENCE    - Audience required in JWT bearer tokens (optional)
+//   - HUMUS_REST_JWT_JWKS_URL    - URL of the JWKS to verify tokens with (default: located via OpenID Connect discovery)
+//   - HUMUS_REST_JWT_CLOCK_SKEW  - Leeway when checking token expiry (default: 1m)
+//   - HUMUS_REST_JWT_JWKS_REFRESH_INTERVAL - How long fetched signing keys are cached (default: 1h)
+//   - HUMUS_REST_API_KEY_HEADER  - Request header carrying API keys (default: X-API-Key)
+//   - HUMUS_REST_TLS_DISABLED    - Serve plain HTTP instead of HTTPS (default: false)
+//   - HUMUS_REST_H2C_ENABLED     - Serve HTTP/2 over cleartext when TLS is disabled (default: false)
+//   - HUMUS_REST_HTTP3_ENABLED   - Additionally serve HTTP/3 over QUIC on the UDP port matching HUMUS_REST_PORT (default: false)
+//   - HUMUS_REST_REQUEST_ID_HEADER - Header carrying the request ID (default: X-Request-ID)
+//   - HUMUS_REST_ACCESS_LOG_ENABLED - Log a record for every handled request (default: false)
+//   - HUMUS_REST_COMPRESSION_ENABLED - Compress responses with zstd or gzip (default: false)
+//   - HUMUS_REST_CORS_ALLOWED_ORIGINS - Comma-separated origins allowed to call the API, e.g. https://*.example.com (enables CORS)
+//   - HUMUS_REST_CORS_ALLOWED_METHODS - Comma-separated methods allowed in cross-origin requests (default: GET,HEAD,POST,PUT,PATCH,DELETE)
+//   - HUMUS_REST_CORS_ALLOWED_HEADERS - Comma-separated request headers allowed in cross-origin requests
+//     (default: Accept, Authorization, Content-Type and the API key and request ID headers)
+//   - HUMUS_REST_CORS_EXPOSED_HEADERS - Comma-separated response headers exposed to cross-origin callers
+//     (default: the request ID, Retry-After and RateLimit-* headers)
+//   - HUMUS_REST_CORS_ALLOW_CREDENTIALS - Allow cross-origin requests to carry credentials (default: false)
+//   - HUMUS_REST_CORS_MAX_AGE    - How long browsers may cache preflight results (default: 10m)
 //
 // # OpenTelemetry
 //
@@ -31,6 +92,187 @@
 // collector instead.
 //
 // Every HTTP request is automatically wrapped in an OTel span via otelhttp.
+// Requests to the health endpoints are excluded.
+//
+// # Health Checks
+//
+// [Readiness] and [Liveness] mount GET /health/readiness and GET /health/liveness,
+// which respond with 200 when all registered [health.Monitor]s are healthy and
+// 503 otherwise. The JSON body reports the result of every check:
+//
+//	{"status":"unhealthy","checks":[{"name":"database","healthy":false,"error":"connection refused"}]}
+//
+// # Authentication
+//
+// Setting [JWTIssuer] or [JWKSURL] requires requests to carry a JWT bearer token.
+// Tokens are verified against the issuer's JSON Web Key Set, located via OpenID
+// Connect discovery unless a JWKS URL is given, and their issuer, audience and
+// expiry are checked. [APIKeyAuth] accepts API keys validated against an
+// [APIKeyStore] instead or in addition. Requests without valid credentials are
+// rejected with 401; the health endpoints and the OpenAPI spec are exempt.
+//
+// Handlers access the caller with [PrincipalFromContext] and custom JWT claims
+// with [ClaimsFromContext]. The security schemes are added to the OpenAPI spec.
+//
+// # Authorization
+//
+// Routes declare who may invoke them when registered with [Handle]:
+//
+//	rest.Handle(route, rest.RequireScopes("pets:write"), rest.RequireRoles("admin"))
+//
+// [RequireScopes] and [RequireRoles] check the [Principal] of the caller, while
+// [RequirePolicy] delegates the decision to a [Policy], e.g. an embedded policy
+// engine. [AllowAnonymous] exempts a route from authentication. Requirements are
+// evaluated before the handler; callers which are not allowed are rejected with
+// a 403 problem details response. Required scopes and roles are listed in the
+// security requirements of the operation in the OpenAPI spec.
+//
+// # CORS
+//
+// [CORS] allows browsers to call the API from other origins:
+//
+//	rest.CORS(
+//	    rest.AllowOrigins(bedrockconfig.ReaderOf([]string{"https://*.example.com"})),
+//	    rest.AllowCredentials(bedrockconfig.ReaderOf(true)),
+//	)
+//
+// Preflight requests are answered before routing, so they are neither
+// authenticated nor rate limited, and responses to cross-origin requests from
+// allowed origins carry the CORS headers, including error responses.
+// Credentials cannot be allowed along with any origin ("*").
+//
+// # Rate Limiting
+//
+// [LimitRate] limits the requests every client may send to a route,
+// [GlobalRateLimit] the requests across all routes and [AuthRateLimit] the
+// requests of every IP address before authentication, which limits clients
+// guessing credentials:
+//
+//	rest.Handle(route, rest.LimitRate(rest.RateLimit{
+//	    Requests: 100,
+//	    Period:   time.Minute,
+//	    Key:      rest.KeyBySubject,
+//	}))
+//
+// Limits are enforced with a [TokenBucket] or a [SlidingWindow] and clients are
+// identified by IP address, API key or JWT subject. Requests exceeding a limit
+// are rejected with a 429 problem carrying a Retry-After header, and every
+// response of a limited route reports the quota of the client in RateLimit-*
+// headers. Limits are documented in the OpenAPI spec. By default they are
+// enforced per replica by a [MemoryRateLimiter]; [RateLimitBackend] with a
+// [PostgresRateLimiter] enforces them across all replicas.
+//
+// # Request Bodies and Content Negotiation
+//
+// Request bodies are not limited by default. [MaxRequestBodyBytes] limits all
+// routes and [MaxBodyBytes] overrides the limit per route. Larger bodies are
+// rejected with a 413 problem.
+//
+// Endpoints read and write JSON. [Consumes] and [Produces] additionally accept
+// and serve other representations, e.g. [CBOR] or [Protobuf], which are
+// converted to and from JSON for the endpoint:
+//
+//	rest.Handle(route, rest.Consumes(rest.CBOR()), rest.Produces(rest.CBOR(), rest.Protobuf[*petv1.Pet]()))
+//
+// The response representation is negotiated with the Accept header. Requests
+// with a body of an unsupported media type are rejected with 415 and requests
+// accepting none of the response media types with 406. The additional media
+// types are listed in the OpenAPI spec.
+//
+// # Validation
+//
+// JSON request bodies are validated against the schema of the operation in
+// the OpenAPI spec before the handler is called. Constraints are declared with
+// struct tags, which are reflected in the schema:
+//
+//	type AddItemRequest struct {
+//	    ProductID string  `json:"productId" required:"true" pattern:"^[a-z0-9-]+$"`
+//	    Quantity  int     `json:"quantity" required:"true" minimum:"1" maximum:"100"`
+//	    UnitPrice float64 `json:"unitPrice" minimum:"0" exclusiveMinimum:"0"`
+//	    Currency  string  `json:"currency,omitempty" enum:"EUR,USD"`
+//	}
+//
+// Further tags include minLength, maxLength, minItems, maxItems and
+// multipleOf. Invalid bodies are rejected with a 400 problem whose
+// invalid-params member lists every violated field path along with the reason,
+// e.g. "items[0].quantity". A null property is treated as absent.
+//
+// # Versioning
+//
+// Several versions of an API are served by one process by grouping their
+// routes with [APIVersion]. Versions are selected by path prefix, or by a
+// request header with [VersionHeader], and each has its own OpenAPI spec:
+//
+//	rest.Run(ctx,
+//	    rest.APIVersion("v1",
+//	        rest.Handle(v1.ListPets(store)),
+//	        rest.DeprecatedAPI(since, sunset),
+//	    ),
+//	    rest.APIVersion("v2", rest.Handle(v2.ListPets(store))),
+//	)
+//
+// Routes marked with [Deprecated] or [DeprecatedAPI], or with bedrock's
+// MarkDeprecated, are marked deprecated in the spec and their responses carry
+// a Deprecation header, along with a Sunset header if [Sunset] is given.
+//
+// # Streaming
+//
+// [SSE] creates endpoints which stream typed Server-Sent Events and [NDJSON]
+// endpoints which stream newline-delimited JSON. Handlers return the events
+// as an iterator, which is written and flushed as it yields:
+//
+//	ep := rest.SSE("/prices", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[rest.Event[Price], error], error) {
+//	    return prices.Subscribe(ctx, bedrockrest.ParamFrom(req, rest.LastEventID)), nil
+//	}, rest.Heartbeat(3*time.Second))
+//
+// Reconnecting clients send the [LastEventID] header to resume the stream.
+// Idle SSE streams send heartbeat comments and every write is bounded by
+// [StreamWriteTimeout] instead of the write timeout of the server, which would
+// end long streams. Stream responses are documented in the OpenAPI spec with
+// the schema of their items.
+//
+// # WebSockets
+//
+// [WebSocket] creates routes which upgrade requests to WebSocket connections
+// exchanging typed JSON messages, registered with [HandleWebSocket] and the
+// same route options as other routes:
+//
+//	route := rest.WebSocket("/rooms/{room}", func(ctx context.Context, conn *rest.WebSocketConn[ChatMessage, ChatEvent]) error {
+//	    msg, err := conn.Read(ctx)
+//	    ...
+//	    return conn.Write(ctx, event)
+//	}, rest.PingInterval(30*time.Second))
+//	rest.HandleWebSocket(route, rest.RequireScopes("chat"))
+//
+// Peers are pinged to keep connections alive and disconnected if they stop
+// answering. Every connection is traced by its own span. When the server shuts
+// down, open connections are closed with status 1001 and [Run] waits for their
+// handlers to return.
+//
+// # Errors
+//
+// Error responses are RFC 7807 problem details served as application/problem+json.
+// Handlers return a [Problem], e.g. [NotFound], or any error mapped to a status
+// code by an [ErrorRegistry], and complete the endpoint with [CatchProblems]:
+//
+//	errs := rest.ErrorRegistry{
+//	    rest.MapError(store.ErrNotFound, http.StatusNotFound, "The pet does not exist."),
+//	}
+//	route := rest.CatchProblems(errs, ep)
+//
+// Mapped errors are described by the detail of their mapping, or the status text,
+// and unmapped errors result in a 500 problem. Only a [Problem] discloses its
+// message to clients.
+// The instance of every problem defaults to the request path and the trace ID
+// of the request is included.
+//
+// # Middleware
+//
+// Every request is assigned an ID, available via [RequestIDFromContext], and
+// logged once handled if [AccessLog] is given. Panics are turned into
+// 500 problems and [Compression] enables zstd and gzip compression of responses.
+// [Middleware] adds custom middleware, which is applied in registration order
+// after the built-in middleware and before authentication.
 //
 // # Basic Usage
 //
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/swgui"
+	"github.com/swaggest/swgui/v5emb"
+)
+
+// DocsUI serves an interactive Swagger UI for the OpenAPI spec under the given
+// path, e.g. "/docs". The assets of the UI are embedded in the binary, so the
+// UI works without internet access. Like the spec, the UI is exempt from
+// authentication; requests made from the UI carry the credentials entered
+// via its Authorize dialog. If the API has versions, the UI lets readers pick
+// the spec of each version.
+func DocsUI(path string) Option {
+	return func(o *options) {
+		o.docsPath = path
+	}
+}
+
+// mountDocs serves the docs UI in front of next. Requests for the path
+// without a trailing slash are redirected to the UI.
+func mountDocs(o *options, next http.Handler) http.Handler {
+	if o.docsPath == "" {
+		return next
+	}
+
+	base := strings.TrimSuffix(o.docsPath, "/") + "/"
+	cfg := swgui.Config{
+		Title:       o.title,
+		SwaggerJSON: specPathOf(o),
+		BasePath:    base,
+	}
+	if len(o.versions) > 0 {
+		cfg.ShowTopBar = true
+		cfg.SettingsUI = map[string]string{"urls": docsURLs(o)}
+	}
+	ui := v5emb.NewHandlerWithConfig(cfg)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+base, ui)
+	mux.Handle("/", next)
+	return mux
+}
+
+// docsURLs returns the specs listed by the docs UI, as a JavaScript array:
+// the spec of every version, preceded by the spec of the routes registered
+// outside of any version, if any.
+func docsURLs(o *options) string {
+	var urls []map[string]string
+	if len(o.routes) > 0 {
+		urls = append(urls, map[string]string{"name": o.title, "url": specPathOf(o)})
+	}
+	for i, path := range versionSpecPaths(o) {
+		urls = append(urls, map[string]string{"name": o.versions[i].name, "url": path})
+	}
+	b, _ := json.Marshal(urls)
+	return string(b)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+func TestRun_DocsUI(t *testing.T) {
+	port := freePort(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			Title("Pet Store"),
+			SpecPath("/spec.json"),
+			DocsUI("/docs"),
+			APIKeyAuth(APIKeys{"alice-key": {Subject: "alice"}}),
+		)
+	}()
+
+	client := insecureClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	get := func(t *testing.T, path string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("serves the UI without credentials", func(t *testing.T) {
+		resp := get(t, "/docs/")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html"))
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(b), "Pet Store")
+		require.Contains(t, string(b), "/spec.json")
+	})
+
+	t.Run("serves embedded assets", func(t *testing.T) {
+		resp := get(t, "/docs/swagger-ui-bundle.js")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, resp.Header.Get("Content-Type"), "javascript")
+	})
+
+	t.Run("redirects to the UI", func(t *testing.T) {
+		resp := get(t, "/docs")
+		require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		require.Equal(t, "/docs/", resp.Header.Get("Location"))
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
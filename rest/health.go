@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/z5labs/humus/health"
+)
+
+const (
+	readinessPath = "/health/readiness"
+	livenessPath  = "/health/liveness"
+)
+
+// Readiness registers a monitor which is checked by the GET /health/readiness
+// endpoint. The endpoint responds with 200 when every registered monitor is
+// healthy and 503 otherwise. May be given multiple times to register several
+// checks; wrap monitors with [health.Named] to identify them in the response.
+func Readiness(monitor health.Monitor) Option {
+	return func(o *options) {
+		o.readiness = append(o.readiness, monitor)
+	}
+}
+
+// Liveness registers a monitor which is checked by the GET /health/liveness
+// endpoint. The endpoint responds with 200 when every registered monitor is
+// healthy and 503 otherwise. May be given multiple times to register several
+// checks; wrap monitors with [health.Named] to identify them in the response.
+func Liveness(monitor health.Monitor) Option {
+	return func(o *options) {
+		o.liveness = append(o.liveness, monitor)
+	}
+}
+
+// healthReport is the JSON body returned by the health endpoints.
+type healthReport struct {
+	Status string        `json:"status"`
+	Checks []checkReport `json:"checks"`
+}
+
+type checkReport struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// healthHandler checks every monitor and reports the results as JSON.
+type healthHandler []health.Monitor
+
+func (h healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := healthReport{
+		Status: "healthy",
+		Checks: make([]checkReport, 0, len(h)),
+	}
+	for i, m := range h {
+		check := checkReport{Name: checkName(i, m)}
+
+		healthy, err := m.Healthy(r.Context())
+		check.Healthy = healthy && err == nil
+		if err != nil {
+			check.Error = err.Error()
+		}
+		if !check.Healthy {
+			report.Status = "unhealthy"
+		}
+
+		report.Checks = append(report.Checks, check)
+	}
+
+	status := http.StatusOK
+	if report.Status != "healthy" {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+func checkName(i int, m health.Monitor) string {
+	switch nm := m.(type) {
+	case health.NamedMonitor:
+		return nm.Name
+	case *health.NamedMonitor:
+		if nm != nil {
+			return nm.Name
+		}
+	}
+	return fmt.Sprintf("check-%d", i)
+}
+
+// mountHealth serves the configured health endpoints in front of next.
+// It returns next unchanged if no monitors are configured.
+func mountHealth(o *options, next http.Handler) http.Handler {
+	if len(o.readiness) == 0 && len(o.liveness) == 0 {
+		return next
+	}
+
+	mux := http.NewServeMux()
+	if len(o.readiness) > 0 {
+		mux.Handle("GET "+readinessPath, healthHandler(o.readiness))
+	}
+	if len(o.liveness) > 0 {
+		mux.Handle("GET "+livenessPath, healthHandler(o.liveness))
+	}
+	mux.Handle("/", next)
+	return mux
+}
+
+// isHealthCheck reports whether r targets one of the health endpoints,
+// which are probed frequently and so are excluded from tracing.
+func isHealthCheck(r *http.Request) bool {
+	return r.URL.Path == readinessPath || r.URL.Path == livenessPath
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/z5labs/humus/health"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+type monitorFunc func(context.Context) (bool, error)
+
+func (f monitorFunc) Healthy(ctx context.Context) (bool, error) {
+	return f(ctx)
+}
+
+func TestHealthHandler(t *testing.T) {
+	t.Run("responds 200 when all monitors are healthy", func(t *testing.T) {
+		var db health.Binary
+		db.MarkHealthy()
+
+		var cache health.Binary
+		cache.MarkHealthy()
+
+		h := healthHandler{health.Named("database", &db), &cache}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var report healthReport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		require.Equal(t, healthReport{
+			Status: "healthy",
+			Checks: []checkReport{
+				{Name: "database", Healthy: true},
+				{Name: "check-1", Healthy: true},
+			},
+		}, report)
+	})
+
+	t.Run("names checks of named monitor pointers", func(t *testing.T) {
+		var db health.Binary
+		db.MarkHealthy()
+
+		named := health.Named("database", &db)
+		h := healthHandler{&named}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+
+		var report healthReport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		require.Equal(t, []checkReport{{Name: "database", Healthy: true}}, report.Checks)
+	})
+
+	t.Run("responds 503 when a monitor is unhealthy or fails", func(t *testing.T) {
+		var db health.Binary
+		db.MarkHealthy()
+
+		var cache health.Binary
+
+		queue := monitorFunc(func(ctx context.Context) (bool, error) {
+			return true, errors.New("connection refused")
+		})
+
+		h := healthHandler{
+			health.Named("database", &db),
+			health.Named("cache", &cache),
+			health.Named("queue", queue),
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, livenessPath, nil))
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		var report healthReport
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		require.Equal(t, healthReport{
+			Status: "unhealthy",
+			Checks: []checkReport{
+				{Name: "database", Healthy: true},
+				{Name: "cache", Healthy: false},
+				{Name: "queue", Healthy: false, Error: "connection refused"},
+			},
+		}, report)
+	})
+}
+
+func TestRun_Health(t *testing.T) {
+	t.Run("serves the readiness and liveness endpoints", func(t *testing.T) {
+		port := freePort(t)
+
+		var ready health.Binary
+		var alive health.Binary
+		alive.MarkHealthy()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Run(
+				ctx,
+				Port(bedrockconfig.ReaderOf(port)),
+				Readiness(health.Named("startup", &ready)),
+				Liveness(&alive),
+			)
+		}()
+
+		client := insecureClient()
+		get := func(path string) int {
+			resp, err := client.Get(fmt.Sprintf("https://localhost:%d%s", port, path))
+			if err != nil {
+				return 0
+			}
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+
+		require.Eventually(t, func() bool {
+			return get(livenessPath) == http.StatusOK
+		}, 5*time.Second, 50*time.Millisecond)
+
+		require.Equal(t, http.StatusServiceUnavailable, get(readinessPath))
+
+		ready.MarkHealthy()
+		require.Equal(t, http.StatusOK, get(readinessPath))
+
+		// Other routes are still served.
+		require.Equal(t, http.StatusOK, get("/openapi.json"))
+
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	t.Run("does not mount the endpoints without monitors", func(t *testing.T) {
+		h, err := buildHandler(defaultOptions()).Build(context.Background())
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
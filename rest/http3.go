@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/z5labs/bedrock"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// altSvcMaxAge is how long, in seconds, clients may remember that HTTP/3 is available.
+const altSvcMaxAge = 30 * 24 * 60 * 60
+
+// HTTP3 additionally serves HTTP/3 over QUIC on the UDP port matching the HTTPS
+// port. Both servers share the same handler and TLS config, and HTTPS responses
+// advertise HTTP/3 to clients with an Alt-Svc header. It has no effect when TLS
+// is disabled with [Insecure]. The default is read from HUMUS_REST_HTTP3_ENABLED (false).
+func HTTP3() Option {
+	return func(o *options) {
+		o.http3 = bedrockconfig.ReaderOf(true)
+	}
+}
+
+// http3Enabled reports whether the HTTP/3 server should be started.
+func http3Enabled(ctx context.Context, o *options) (bool, error) {
+	enabled, err := bedrockconfig.Read(ctx, o.http3)
+	if err != nil || !enabled {
+		return false, err
+	}
+	tlsDisabled, err := bedrockconfig.Read(ctx, o.tlsDisabled)
+	if err != nil {
+		return false, err
+	}
+	return !tlsDisabled, nil
+}
+
+// altSvcHandler advertises the HTTP/3 server listening on the given UDP port.
+func altSvcHandler(port int, next http.Handler) http.Handler {
+	altSvc := fmt.Sprintf(`h3=":%d"; ma=%d`, port, altSvcMaxAge)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", altSvc)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withAltSvc wraps the HTTPS handler with [altSvcHandler] if HTTP/3 is enabled.
+func withAltSvc(o *options, handlerB bedrock.Builder[http.Handler]) bedrock.Builder[http.Handler] {
+	return bedrock.Map(handlerB, func(ctx context.Context, h http.Handler) (http.Handler, error) {
+		enabled, err := http3Enabled(ctx, o)
+		if err != nil || !enabled {
+			return h, err
+		}
+		port, err := bedrockconfig.Read(ctx, o.port)
+		if err != nil {
+			return nil, err
+		}
+		return altSvcHandler(port, h), nil
+	})
+}
+
+// http3Runtime serves HTTP/3 on a UDP connection.
+type http3Runtime struct {
+	conn net.PacketConn
+	srv  *http3.Server
+}
+
+// Run serves HTTP/3 until ctx is cancelled, then gracefully shuts down the server.
+func (r http3Runtime) Run(ctx context.Context) error {
+	defer r.conn.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.srv.Serve(r.conn)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := r.srv.Shutdown(context.Background()); err != nil {
+		return err
+	}
+	err := <-errCh
+	if err == nil || errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// buildHTTP3Runtime returns a builder for the HTTP/3 server. It builds a nil
+// runtime if HTTP/3 is not enabled.
+func buildHTTP3Runtime(o *options, handlerB bedrock.Builder[http.Handler], tlsConfigB bedrock.Builder[*tls.Config]) bedrock.Builder[bedrock.Runtime] {
+	return bedrock.BuilderFunc[bedrock.Runtime](func(ctx context.Context) (bedrock.Runtime, error) {
+		enabled, err := http3Enabled(ctx, o)
+		if err != nil || !enabled {
+			return nil, err
+		}
+
+		port, err := bedrockconfig.Read(ctx, o.port)
+		if err != nil {
+			return nil, err
+		}
+		h, err := handlerB.Build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig, err := tlsConfigB.Build(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+		if err != nil {
+			return nil, err
+		}
+
+		return http3Runtime{
+			conn: conn,
+			srv: &http3.Server{
+				Handler:        h,
+				TLSConfig:      http3.ConfigureTLSConfig(tlsConfig),
+				IdleTimeout:    bedrockconfig.MustOr(ctx, 0, o.idleTimeout),
+				MaxHeaderBytes: bedrockconfig.MustOr(ctx, 0, o.maxHeaderBytes),
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestRun_HTTP3(t *testing.T) {
+	port := freePort(t)
+
+	ep := bedrockrest.GET("/hello", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{Message: "hello"}, nil
+	})
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			HTTP3(),
+			Handle(route),
+		)
+	}()
+
+	url := fmt.Sprintf("https://localhost:%d/hello", port)
+
+	t.Run("advertises HTTP/3 on HTTPS responses", func(t *testing.T) {
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			var err error
+			resp, err = insecureClient().Get(url)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, fmt.Sprintf(`h3=":%d"; ma=2592000`, port), resp.Header.Get("Alt-Svc"))
+	})
+
+	t.Run("serves the route over HTTP/3", func(t *testing.T) {
+		transport := &http3.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}
+		defer transport.Close()
+		client := &http.Client{Transport: transport}
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			var err error
+			resp, err = client.Get(url)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 3, resp.ProtoMajor)
+
+		var body testResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, "hello", body.Message)
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestAltSvcHandler(t *testing.T) {
+	h := altSvcHandler(8443, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, `h3=":8443"; ma=2592000`, rec.Header().Get("Alt-Svc"))
+}
+
+func TestBuildHTTP3Runtime(t *testing.T) {
+	t.Run("builds no runtime unless enabled", func(t *testing.T) {
+		o := defaultOptions()
+
+		rt, err := buildHTTP3Runtime(o, nil, nil).Build(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, rt)
+	})
+
+	t.Run("builds no runtime when TLS is disabled", func(t *testing.T) {
+		o := defaultOptions()
+		HTTP3()(o)
+		Insecure()(o)
+
+		rt, err := buildHTTP3Runtime(o, nil, nil).Build(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, rt)
+	})
+}
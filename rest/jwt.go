@@ -0,0 +1,377 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// BearerAuthScheme is the name of the OpenAPI security scheme for JWT bearer tokens.
+const BearerAuthScheme = "bearerAuth"
+
+const (
+	// jwksMinRefreshInterval limits how often the JWKS is fetched again
+	// when a token is signed with an unknown key.
+	jwksMinRefreshInterval = 30 * time.Second
+
+	// maxJWKSBytes limits the size of JWKS and discovery documents.
+	maxJWKSBytes = 1 << 20
+
+	// jwksFetchTimeout limits how long fetching the JWKS, including
+	// discovering it, may take.
+	jwksFetchTimeout = 10 * time.Second
+)
+
+var jwtSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTIssuer enables authentication with JWT bearer tokens issued by the given
+// issuer, which must match the "iss" claim. Unless [JWKSURL] is set, the signing
+// keys are located via OpenID Connect discovery of the issuer. The default is
+// read from HUMUS_REST_JWT_ISSUER.
+func JWTIssuer(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.jwtIssuer = r
+	}
+}
+
+// JWTAudience sets the audience which must be contained in the "aud" claim of
+// bearer tokens. The default is read from HUMUS_REST_JWT_AUDIENCE. If unset, the
+// audience is not checked.
+func JWTAudience(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.jwtAudience = r
+	}
+}
+
+// JWKSURL enables authentication with JWT bearer tokens signed by one of the
+// keys in the JSON Web Key Set at the given URL. The default is read from
+// HUMUS_REST_JWT_JWKS_URL.
+func JWKSURL(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.jwksURL = r
+	}
+}
+
+// JWTClockSkew sets the leeway allowed when checking the "exp", "nbf" and "iat"
+// claims of bearer tokens. The default is read from HUMUS_REST_JWT_CLOCK_SKEW (1m).
+func JWTClockSkew(r bedrockconfig.Reader[time.Duration]) Option {
+	return func(o *options) {
+		o.jwtClockSkew = r
+	}
+}
+
+// JWKSRefreshInterval sets how long fetched signing keys are cached before the
+// JWKS is fetched again. Tokens signed with an unknown key also cause the JWKS
+// to be fetched again, at most every 30 seconds. The default is read from
+// HUMUS_REST_JWT_JWKS_REFRESH_INTERVAL (1h).
+func JWKSRefreshInterval(r bedrockconfig.Reader[time.Duration]) Option {
+	return func(o *options) {
+		o.jwksRefreshInterval = r
+	}
+}
+
+// jwtAuthenticator authenticates requests with JWT bearer tokens.
+type jwtAuthenticator struct {
+	issuer    string
+	audience  string
+	clockSkew time.Duration
+	keys      *jwksCache
+	now       func() time.Time
+}
+
+// newJWTAuthenticator returns nil if neither an issuer nor a JWKS URL is configured.
+func newJWTAuthenticator(ctx context.Context, o *options) (*jwtAuthenticator, error) {
+	issuer := bedrockconfig.MustOr(ctx, "", o.jwtIssuer)
+	jwksURL := bedrockconfig.MustOr(ctx, "", o.jwksURL)
+	if issuer == "" && jwksURL == "" {
+		return nil, nil
+	}
+
+	clockSkew, err := bedrockconfig.Read(ctx, o.jwtClockSkew)
+	if err != nil {
+		return nil, err
+	}
+	refreshInterval, err := bedrockconfig.Read(ctx, o.jwksRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwtAuthenticator{
+		issuer:    issuer,
+		audience:  bedrockconfig.MustOr(ctx, "", o.jwtAudience),
+		clockSkew: clockSkew,
+		keys: &jwksCache{
+			issuer:             issuer,
+			url:                jwksURL,
+			refreshInterval:    refreshInterval,
+			minRefreshInterval: jwksMinRefreshInterval,
+			client: &http.Client{
+				Transport: otelhttp.NewTransport(http.DefaultTransport),
+				Timeout:   jwksFetchTimeout,
+			},
+		},
+		now: time.Now,
+	}, nil
+}
+
+func (a *jwtAuthenticator) securityScheme() (string, map[string]any) {
+	return BearerAuthScheme, map[string]any{
+		"type":         "http",
+		"scheme":       "bearer",
+		"bearerFormat": "JWT",
+	}
+}
+
+func (a *jwtAuthenticator) challenge() string {
+	return "Bearer"
+}
+
+func (a *jwtAuthenticator) authenticate(r *http.Request) (Principal, error) {
+	raw, ok := bearerToken(r)
+	if !ok {
+		return Principal{}, errNoCredentials
+	}
+
+	tok, err := jwt.ParseSigned(raw, jwtSignatureAlgorithms)
+	if err != nil {
+		return Principal{}, err
+	}
+	key, err := a.keys.key(r.Context(), tok.Headers[0].KeyID)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	var (
+		claims    jwt.Claims
+		access    accessClaims
+		rawClaims json.RawMessage
+	)
+	if err := tok.Claims(key, &claims, &access, &rawClaims); err != nil {
+		return Principal{}, err
+	}
+
+	expected := jwt.Expected{
+		Issuer: a.issuer,
+		Time:   a.now(),
+	}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, a.clockSkew); err != nil {
+		return Principal{}, err
+	}
+	if claims.Expiry == nil {
+		return Principal{}, errors.New("rest: JWT has no expiry")
+	}
+
+	return Principal{
+		Subject: claims.Subject,
+		Scopes:  append(access.Scope, access.Scp...),
+		Roles:   access.Roles,
+		Scheme:  BearerAuthScheme,
+		claims:  rawClaims,
+	}, nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// accessClaims are the claims granting scopes and roles to the caller.
+type accessClaims struct {
+	Scope scopeList `json:"scope"`
+	Scp   scopeList `json:"scp"`
+	Roles []string  `json:"roles"`
+}
+
+// scopeList decodes scopes given either as a space-separated string
+// (RFC 8693) or as an array of strings.
+type scopeList []string
+
+func (s *scopeList) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err == nil {
+		*s = strings.Fields(str)
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// jwksCache fetches and caches the signing keys of the token issuer.
+type jwksCache struct {
+	issuer             string
+	url                string
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	client             *http.Client
+
+	// fetches deduplicates concurrent fetches, which happen without
+	// holding mu, so requests are not blocked while the JWKS is fetched.
+	fetches singleflight.Group
+
+	mu        sync.Mutex
+	keys      jose.JSONWebKeySet
+	fetched   bool
+	checkedAt time.Time
+}
+
+// key returns the signing key with the given ID. The JWKS is fetched again if
+// the cached keys are older than the refresh interval or the key is unknown.
+// If fetching fails, the previously fetched keys continue to be used.
+func (c *jwksCache) key(ctx context.Context, kid string) (jose.JSONWebKey, error) {
+	var err error
+	if c.due(c.refreshInterval) {
+		err = c.refresh(ctx)
+	}
+	if key, ok := c.lookup(kid); ok {
+		return key, nil
+	}
+	if err == nil && c.due(c.minRefreshInterval) {
+		err = c.refresh(ctx)
+		if key, ok := c.lookup(kid); ok {
+			return key, nil
+		}
+	}
+	if err != nil {
+		return jose.JSONWebKey{}, err
+	}
+	return jose.JSONWebKey{}, fmt.Errorf("rest: unknown JWT signing key: %q", kid)
+}
+
+// due reports whether the keys have not been fetched within the interval.
+func (c *jwksCache) due(interval time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.fetched || time.Since(c.checkedAt) >= interval
+}
+
+func (c *jwksCache) lookup(kid string) (jose.JSONWebKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if kid == "" && len(c.keys.Keys) == 1 {
+		return c.keys.Keys[0], true
+	}
+	for _, key := range c.keys.Key(kid) {
+		if key.Use == "" || key.Use == "sig" {
+			return key, true
+		}
+	}
+	return jose.JSONWebKey{}, false
+}
+
+// refresh waits for the JWKS to be fetched, joining a fetch which is already
+// in flight. The fetch is detached from ctx, so it completes for the other
+// callers waiting for it even if ctx is cancelled.
+func (c *jwksCache) refresh(ctx context.Context) error {
+	fetched := c.fetches.DoChan("jwks", func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jwksFetchTimeout)
+		defer cancel()
+		return nil, c.fetch(ctx)
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-fetched:
+		return res.Err
+	}
+}
+
+func (c *jwksCache) fetch(ctx context.Context) error {
+	c.mu.Lock()
+	c.checkedAt = time.Now()
+	jwksURL := c.url
+	c.mu.Unlock()
+
+	if jwksURL == "" {
+		var err error
+		jwksURL, err = c.discover(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := c.get(ctx, jwksURL, &keys); err != nil {
+		return fmt.Errorf("rest: failed to fetch JWKS: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.url = jwksURL
+	c.keys = keys
+	c.fetched = true
+	return nil
+}
+
+// discover locates the JWKS of the issuer via OpenID Connect discovery.
+func (c *jwksCache) discover(ctx context.Context) (string, error) {
+	var config struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(c.issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.get(ctx, discoveryURL, &config); err != nil {
+		return "", fmt.Errorf("rest: failed to discover OpenID configuration: %w", err)
+	}
+	if config.Issuer != c.issuer {
+		return "", fmt.Errorf("rest: OpenID configuration issuer %q does not match %q", config.Issuer, c.issuer)
+	}
+	if config.JWKSURI == "" {
+		return "", errors.New("rest: OpenID configuration does not include a jwks_uri")
+	}
+	return config.JWKSURI, nil
+}
+
+func (c *jwksCache) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(v)
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// testIssuer is an OpenID Connect provider serving a discovery document and
+// a JWKS with the keys it signs tokens with.
+type testIssuer struct {
+	srv        *httptest.Server
+	jwksServed atomic.Int32
+
+	mu  sync.Mutex
+	kid int
+	key *ecdsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	iss := &testIssuer{}
+	iss.rotate(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   iss.url(),
+			"jwks_uri": iss.url() + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		iss.jwksServed.Add(1)
+
+		iss.mu.Lock()
+		defer iss.mu.Unlock()
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &iss.key.PublicKey,
+			KeyID:     fmt.Sprintf("key-%d", iss.kid),
+			Algorithm: string(jose.ES256),
+			Use:       "sig",
+		}}})
+	})
+
+	iss.srv = httptest.NewServer(mux)
+	t.Cleanup(iss.srv.Close)
+	return iss
+}
+
+func (iss *testIssuer) url() string {
+	return iss.srv.URL
+}
+
+// rotate replaces the signing key.
+func (iss *testIssuer) rotate(t *testing.T) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	iss.kid++
+	iss.key = key
+}
+
+// sign issues a token with the given claims, which are merged into a set of
+// valid registered claims.
+func (iss *testIssuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: iss.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", fmt.Sprintf("key-%d", iss.kid)),
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	registered := jwt.Claims{
+		Issuer:   iss.url(),
+		Subject:  "user-1",
+		Audience: jwt.Audience{"pets-api"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	token, err := jwt.Signed(signer).Claims(registered).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func newTestJWTAuthenticator(t *testing.T, opts ...Option) *jwtAuthenticator {
+	t.Helper()
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	a, err := newJWTAuthenticator(context.Background(), o)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	return a
+}
+
+func bearerRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	iss := newTestIssuer(t)
+
+	a := newTestJWTAuthenticator(
+		t,
+		JWTIssuer(bedrockconfig.ReaderOf(iss.url())),
+		JWTAudience(bedrockconfig.ReaderOf("pets-api")),
+	)
+
+	t.Run("accepts a valid token located via OIDC discovery", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{
+			"scope": "pets:read pets:write",
+			"roles": []string{"admin"},
+		})
+
+		p, err := a.authenticate(bearerRequest(token))
+		require.NoError(t, err)
+		require.Equal(t, "user-1", p.Subject)
+		require.Equal(t, []string{"pets:read", "pets:write"}, p.Scopes)
+		require.Equal(t, []string{"admin"}, p.Roles)
+		require.Equal(t, BearerAuthScheme, p.Scheme)
+	})
+
+	t.Run("reads scopes given as an array", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"scp": []string{"pets:read"}})
+
+		p, err := a.authenticate(bearerRequest(token))
+		require.NoError(t, err)
+		require.Equal(t, []string{"pets:read"}, p.Scopes)
+	})
+
+	t.Run("returns errNoCredentials without a bearer token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+		r.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+
+		_, err := a.authenticate(r)
+		require.ErrorIs(t, err, errNoCredentials)
+	})
+
+	t.Run("rejects tokens from another issuer", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"iss": "https://evil.example.test"})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.ErrorIs(t, err, jwt.ErrInvalidIssuer)
+	})
+
+	t.Run("rejects tokens for another audience", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"aud": "orders-api"})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.ErrorIs(t, err, jwt.ErrInvalidAudience)
+	})
+
+	t.Run("rejects expired tokens beyond the clock skew", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"exp": time.Now().Add(-2 * time.Minute).Unix()})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.ErrorIs(t, err, jwt.ErrExpired)
+	})
+
+	t.Run("accepts expired tokens within the clock skew", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"exp": time.Now().Add(-30 * time.Second).Unix()})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects tokens without an expiry", func(t *testing.T) {
+		token := iss.sign(t, map[string]any{"exp": nil})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects tokens with an invalid signature", func(t *testing.T) {
+		other := newTestIssuer(t)
+		token := other.sign(t, map[string]any{"iss": iss.url()})
+
+		_, err := a.authenticate(bearerRequest(token))
+		require.Error(t, err)
+	})
+}
+
+func TestJWKSCache(t *testing.T) {
+	t.Run("caches the key set", func(t *testing.T) {
+		iss := newTestIssuer(t)
+		a := newTestJWTAuthenticator(t, JWKSURL(bedrockconfig.ReaderOf(iss.url()+"/jwks")))
+
+		for range 3 {
+			_, err := a.authenticate(bearerRequest(iss.sign(t, nil)))
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(1), iss.jwksServed.Load())
+	})
+
+	t.Run("fetches the key set again for unknown keys", func(t *testing.T) {
+		iss := newTestIssuer(t)
+		a := newTestJWTAuthenticator(t, JWKSURL(bedrockconfig.ReaderOf(iss.url()+"/jwks")))
+		a.keys.minRefreshInterval = 0
+
+		_, err := a.authenticate(bearerRequest(iss.sign(t, nil)))
+		require.NoError(t, err)
+
+		iss.rotate(t)
+		_, err = a.authenticate(bearerRequest(iss.sign(t, nil)))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), iss.jwksServed.Load())
+	})
+
+	t.Run("limits how often the key set is fetched for unknown keys", func(t *testing.T) {
+		iss := newTestIssuer(t)
+		a := newTestJWTAuthenticator(t, JWKSURL(bedrockconfig.ReaderOf(iss.url()+"/jwks")))
+
+		_, err := a.authenticate(bearerRequest(iss.sign(t, nil)))
+		require.NoError(t, err)
+
+		iss.rotate(t)
+		_, err = a.authenticate(bearerRequest(iss.sign(t, nil)))
+		require.Error(t, err)
+		require.Equal(t, int32(1), iss.jwksServed.Load())
+	})
+
+	t.Run("does not block requests while fetching the key set", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		var served atomic.Int32
+		fetching := make(chan struct{}, 1)
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if served.Add(1) > 1 {
+				fetching <- struct{}{}
+				<-release
+			}
+			json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+				Key:       &key.PublicKey,
+				KeyID:     "key-1",
+				Algorithm: string(jose.ES256),
+				Use:       "sig",
+			}}})
+		}))
+		defer srv.Close()
+
+		a := newTestJWTAuthenticator(t, JWKSURL(bedrockconfig.ReaderOf(srv.URL)))
+		a.keys.minRefreshInterval = 0
+		_, err = a.keys.key(context.Background(), "key-1")
+		require.NoError(t, err)
+
+		// The caller starting the fetch gives up before it completes.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled := make(chan error, 1)
+		go func() {
+			_, err := a.keys.key(ctx, "key-2")
+			cancelled <- err
+		}()
+		<-fetching
+
+		waiting := make(chan error, 1)
+		go func() {
+			_, err := a.keys.key(context.Background(), "key-2")
+			waiting <- err
+		}()
+
+		_, err = a.keys.key(context.Background(), "key-1")
+		require.NoError(t, err)
+
+		cancel()
+		require.ErrorIs(t, <-cancelled, context.Canceled)
+
+		// Give the waiting caller a chance to join the fetch.
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		require.ErrorContains(t, <-waiting, "unknown JWT signing key")
+		require.Equal(t, int32(2), served.Load())
+	})
+
+	t.Run("returns an error if the discovered issuer does not match", func(t *testing.T) {
+		iss := newTestIssuer(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":   iss.url(),
+				"jwks_uri": iss.url() + "/jwks",
+			})
+		}))
+		defer srv.Close()
+
+		a := newTestJWTAuthenticator(t, JWTIssuer(bedrockconfig.ReaderOf(srv.URL)))
+
+		_, err := a.keys.key(context.Background(), "key-1")
+		require.ErrorContains(t, err, "does not match")
+		require.Zero(t, iss.jwksServed.Load())
+	})
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	"github.com/z5labs/humus"
+)
+
+// maxRequestIDLength limits the length of request IDs accepted from clients.
+const maxRequestIDLength = 128
+
+// Middleware adds HTTP middleware in front of the routes. Middleware is applied
+// in the order it is registered, i.e. the first registered middleware sees the
+// request first. All middleware runs after the built-in tracing, peer identity,
+// request ID, access log, CORS, compression, problem details and panic recovery
+// middleware and before rate limiting and authentication, so requests are
+// traced and carry a request ID. Health and documentation endpoints are
+// served before the middleware.
+func Middleware(mw func(http.Handler) http.Handler) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, mw)
+	}
+}
+
+// RequestIDHeader sets the header carrying the request ID. Request IDs sent
+// by clients are kept if valid, otherwise a new one is generated, and the ID
+// is returned in the same header of the response. The default is read from
+// HUMUS_REST_REQUEST_ID_HEADER (X-Request-ID).
+func RequestIDHeader(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.requestIDHeader = r
+	}
+}
+
+// AccessLog enables logging a record for every handled request. The default
+// is read from HUMUS_REST_ACCESS_LOG_ENABLED (false).
+func AccessLog() Option {
+	return func(o *options) {
+		o.accessLog = bedrockconfig.ReaderOf(true)
+	}
+}
+
+// applyMiddleware wraps h with the middleware, such that the first middleware
+// is the outermost.
+func applyMiddleware(h http.Handler, middleware []func(http.Handler) http.Handler) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// requestIDHandler adds the request ID to the request context and response headers.
+func requestIDHandler(header string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(header)
+		if !validRequestID(id) {
+			id = rand.Text()
+		}
+		w.Header().Set(header, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// validRequestID only accepts IDs which are safe to log and echo back.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':', c == '+', c == '/', c == '=':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isInformational reports whether status is an informational 1xx status, e.g.
+// 103 Early Hints, which precedes the final status of the response. Unlike the
+// others, 101 Switching Protocols is final.
+func isInformational(status int) bool {
+	return status >= 100 && status < 200 && status != http.StatusSwitchingProtocols
+}
+
+// statusWriter records the status code and size of the response.
+type statusWriter struct {
+	http.ResponseWriter
+
+	status  int
+	written int64
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 && !isInformational(status) {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	n, err := sw.ResponseWriter.Write(b)
+	sw.written += int64(n)
+	return n, err
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// accessLogHandler logs a record for every handled request, except for
+// health checks.
+func accessLogHandler(next http.Handler) http.Handler {
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isHealthCheck(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			requestID, _ := RequestIDFromContext(r.Context())
+			log.InfoContext(
+				r.Context(),
+				"handled request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("proto", r.Proto),
+				slog.Int("status", status),
+				slog.Int64("bytes", sw.written),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.String("user_agent", r.UserAgent()),
+				slog.String("request_id", requestID),
+			)
+		}()
+		next.ServeHTTP(sw, r)
+	})
+}
+
+// recoverHandler turns panics into 500 problem responses. If the response has
+// already been started, the connection is aborted instead.
+func recoverHandler(next http.Handler) http.Handler {
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			log.ErrorContext(
+				r.Context(),
+				"recovered from panic",
+				slog.String("panic", fmt.Sprint(v)),
+				slog.String("stack", string(debug.Stack())),
+			)
+			if sw.status != 0 {
+				panic(http.ErrAbortHandler)
+			}
+			writeProblem(sw, http.StatusInternalServerError, "An unexpected error occurred.")
+		}()
+		next.ServeHTTP(sw, r)
+	})
+}
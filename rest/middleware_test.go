@@ -0,0 +1,209 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestApplyMiddleware(t *testing.T) {
+	var calls []string
+	mw := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := applyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}), []func(http.Handler) http.Handler{mw("first"), mw("second")})
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	var got string
+	h := requestIDHandler("X-Request-ID", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = RequestIDFromContext(r.Context())
+	}))
+
+	t.Run("keeps valid request IDs", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "abc-123")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, "abc-123", got)
+		require.Equal(t, "abc-123", w.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("replaces invalid request IDs", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "abc\n123")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.NotEqual(t, "abc\n123", got)
+		require.True(t, validRequestID(got))
+		require.Equal(t, got, w.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("generates missing request IDs", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.NotEmpty(t, got)
+		require.Equal(t, got, w.Header().Get("X-Request-ID"))
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Run("is disabled by default", func(t *testing.T) {
+		enabled, err := bedrockconfig.Read(context.Background(), defaultOptions().accessLog)
+		require.NoError(t, err)
+		require.False(t, enabled)
+	})
+
+	t.Run("is enabled by the option", func(t *testing.T) {
+		o := defaultOptions()
+		AccessLog()(o)
+
+		enabled, err := bedrockconfig.Read(context.Background(), o.accessLog)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+}
+
+func TestRecoverHandler(t *testing.T) {
+	t.Run("responds with a 500 problem", func(t *testing.T) {
+		h := recoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("aborts started responses", func(t *testing.T) {
+		h := recoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			panic("boom")
+		}))
+
+		require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestRun_Middleware(t *testing.T) {
+	port := freePort(t)
+
+	ep := bedrockrest.GET("/hello", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		id, _ := RequestIDFromContext(ctx)
+		return testResponse{Message: id}, nil
+	})
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var order []string
+	mw := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/panic" {
+					panic("boom")
+				}
+				order = append(order, name)
+				w.Header().Add("X-Middleware", name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			Middleware(mw("first")),
+			Middleware(mw("second")),
+			Handle(route),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	get := func(t *testing.T, path string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Request-ID", "req-1")
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("applies middleware in order", func(t *testing.T) {
+		order = nil
+		resp := get(t, "/hello")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []string{"first", "second"}, order)
+		require.Equal(t, []string{"first", "second"}, resp.Header.Values("X-Middleware"))
+	})
+
+	t.Run("passes the request ID to handlers", func(t *testing.T) {
+		resp := get(t, "/hello")
+		require.Equal(t, "req-1", resp.Header.Get("X-Request-ID"))
+
+		var body testResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, "req-1", body.Message)
+	})
+
+	t.Run("recovers from panics in middleware", func(t *testing.T) {
+		resp := get(t, "/panic")
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		require.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), ProblemContentType))
+
+		var p Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		require.Equal(t, "/panic", p.Instance)
+		require.NotEmpty(t, p.TraceID)
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
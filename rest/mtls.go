@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+// ClientCAFile sets the path to a PEM file of CA certificates used to verify
+// client certificates. The default is read from HUMUS_REST_TLS_CLIENT_CA_FILE.
+// When set, clients must present a certificate signed by one of the CAs unless
+// [ClientAuth] says otherwise.
+func ClientCAFile(r bedrockconfig.Reader[string]) Option {
+	return func(o *options) {
+		o.clientCAFile = r
+	}
+}
+
+// ClientAuth sets the policy for TLS client authentication. The default is read
+// from HUMUS_REST_TLS_CLIENT_AUTH, which accepts none, request, require,
+// verify_if_given and require_and_verify. If unset, require_and_verify is used
+// when a client CA file is configured and none otherwise.
+func ClientAuth(r bedrockconfig.Reader[tls.ClientAuthType]) Option {
+	return func(o *options) {
+		o.clientAuth = r
+	}
+}
+
+// parseClientAuth maps a HUMUS_REST_TLS_CLIENT_AUTH value to a [tls.ClientAuthType].
+func parseClientAuth(r bedrockconfig.Reader[string]) bedrockconfig.Reader[tls.ClientAuthType] {
+	return bedrockconfig.Map(r, func(_ context.Context, s string) (tls.ClientAuthType, error) {
+		switch strings.ToLower(s) {
+		case "none":
+			return tls.NoClientCert, nil
+		case "request":
+			return tls.RequestClientCert, nil
+		case "require":
+			return tls.RequireAnyClientCert, nil
+		case "verify_if_given":
+			return tls.VerifyClientCertIfGiven, nil
+		case "require_and_verify":
+			return tls.RequireAndVerifyClientCert, nil
+		default:
+			return tls.NoClientCert, fmt.Errorf("rest: unknown TLS client auth mode: %s", s)
+		}
+	})
+}
+
+// withClientAuth returns a config.Reader[*tls.Config] which configures client
+// certificate authentication on a copy of the TLS config read from base.
+func withClientAuth(
+	base bedrockconfig.Reader[*tls.Config],
+	clientCAFile bedrockconfig.Reader[string],
+	clientAuth bedrockconfig.Reader[tls.ClientAuthType],
+) bedrockconfig.Reader[*tls.Config] {
+	return bedrockconfig.Map(base, func(ctx context.Context, cfg *tls.Config) (*tls.Config, error) {
+		caPath, err := bedrockconfig.Read(ctx, clientCAFile)
+		if err != nil {
+			caPath = ""
+		}
+
+		authType := tls.NoClientCert
+		if caPath != "" {
+			authType = tls.RequireAndVerifyClientCert
+		}
+
+		val, err := clientAuth.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := val.Value(); ok {
+			authType = v
+		}
+
+		if caPath == "" && authType == tls.NoClientCert {
+			return cfg, nil
+		}
+		if caPath == "" && (authType == tls.VerifyClientCertIfGiven || authType == tls.RequireAndVerifyClientCert) {
+			return nil, fmt.Errorf("rest: a TLS client CA file is required to verify client certificates")
+		}
+
+		cfg = cfg.Clone()
+		cfg.ClientAuth = authType
+		if caPath != "" {
+			pool, err := loadCertPool(caPath)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ClientCAs = pool
+		}
+		return cfg, nil
+	})
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("rest: no CA certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// PeerIdentity is the identity of a client which authenticated
+// with a verified TLS client certificate.
+type PeerIdentity struct {
+	Subject        pkix.Name
+	DNSNames       []string
+	EmailAddresses []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+
+	// SPIFFEID is the spiffe:// URI SAN of the certificate, if present.
+	SPIFFEID string
+
+	// Certificate is the verified leaf certificate presented by the client.
+	Certificate *x509.Certificate
+}
+
+type peerIdentityKey struct{}
+
+// PeerIdentityFromContext returns the identity of the client certificate which
+// was verified for the request. It returns false if the client did not present
+// a certificate or the certificate was not verified, e.g. because the client
+// auth mode is request or require.
+func PeerIdentityFromContext(ctx context.Context) (PeerIdentity, bool) {
+	id, ok := ctx.Value(peerIdentityKey{}).(PeerIdentity)
+	return id, ok
+}
+
+func newPeerIdentity(cert *x509.Certificate) PeerIdentity {
+	id := PeerIdentity{
+		Subject:        cert.Subject,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		IPAddresses:    cert.IPAddresses,
+		URIs:           cert.URIs,
+		Certificate:    cert,
+	}
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			id.SPIFFEID = uri.String()
+			break
+		}
+	}
+	return id
+}
+
+// peerIdentityHandler adds the verified client certificate identity, if any,
+// to the request context.
+func peerIdentityHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id := newPeerIdentity(r.TLS.VerifiedChains[0][0])
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), peerIdentityKey{}, id)))
+	})
+}
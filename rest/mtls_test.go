@@ -0,0 +1,271 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCA{cert: cert, key: key}
+}
+
+// writeFile writes the CA certificate as PEM to a temp file and returns its path.
+func (ca testCA) writeFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	require.NoError(t, os.WriteFile(path, certPEM, 0600))
+	return path
+}
+
+// issueClientCert issues a client certificate for the given common name and URI SANs.
+func (ca testCA) issueClientCert(t *testing.T, cn string, uris ...string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{cn},
+	}
+	for _, raw := range uris {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		template.URIs = append(template.URIs, u)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+type identityResponse struct {
+	CommonName string   `json:"common_name"`
+	DNSNames   []string `json:"dns_names"`
+	SPIFFEID   string   `json:"spiffe_id"`
+}
+
+func TestRun_MutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	caFile := ca.writeFile(t)
+
+	port := freePort(t)
+
+	ep := bedrockrest.GET("/whoami", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (identityResponse, error) {
+		id, ok := PeerIdentityFromContext(ctx)
+		if !ok {
+			return identityResponse{}, testError{Message: "no peer identity"}
+		}
+		return identityResponse{
+			CommonName: id.Subject.CommonName,
+			DNSNames:   id.DNSNames,
+			SPIFFEID:   id.SPIFFEID,
+		}, nil
+	})
+	ep = bedrockrest.WriteJSON[identityResponse](http.StatusOK, ep)
+	route := bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			ClientCAFile(bedrockconfig.ReaderOf(caFile)),
+			Handle(route),
+		)
+	}()
+
+	url := fmt.Sprintf("https://localhost:%d/whoami", port)
+
+	t.Run("exposes the verified client identity", func(t *testing.T) {
+		clientCert := ca.issueClientCert(t, "orders", "spiffe://example.org/ns/default/sa/orders")
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, //nolint:gosec
+					Certificates:       []tls.Certificate{clientCert},
+				},
+			},
+		}
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			var err error
+			resp, err = client.Get(url)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body identityResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, identityResponse{
+			CommonName: "orders",
+			DNSNames:   []string{"orders"},
+			SPIFFEID:   "spiffe://example.org/ns/default/sa/orders",
+		}, body)
+	})
+
+	t.Run("rejects clients without a certificate", func(t *testing.T) {
+		_, err := insecureClient().Get(url)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects certificates from an unknown CA", func(t *testing.T) {
+		other := newTestCA(t)
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, //nolint:gosec
+					Certificates:       []tls.Certificate{other.issueClientCert(t, "intruder")},
+				},
+			},
+		}
+
+		_, err := client.Get(url)
+		require.Error(t, err)
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestParseClientAuth(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected tls.ClientAuthType
+	}{
+		{value: "none", expected: tls.NoClientCert},
+		{value: "request", expected: tls.RequestClientCert},
+		{value: "require", expected: tls.RequireAnyClientCert},
+		{value: "verify_if_given", expected: tls.VerifyClientCertIfGiven},
+		{value: "REQUIRE_AND_VERIFY", expected: tls.RequireAndVerifyClientCert},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			authType, err := bedrockconfig.Read(context.Background(), parseClientAuth(bedrockconfig.ReaderOf(tc.value)))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, authType)
+		})
+	}
+
+	t.Run("returns error for unknown modes", func(t *testing.T) {
+		_, err := bedrockconfig.Read(context.Background(), parseClientAuth(bedrockconfig.ReaderOf("always")))
+		require.Error(t, err)
+	})
+}
+
+func TestWithClientAuth(t *testing.T) {
+	base := bedrockconfig.ReaderOf(&tls.Config{})
+
+	t.Run("leaves the config unchanged without client auth", func(t *testing.T) {
+		cfg, err := bedrockconfig.Read(context.Background(), withClientAuth(
+			base,
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[tls.ClientAuthType](),
+		))
+		require.NoError(t, err)
+		require.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+		require.Nil(t, cfg.ClientCAs)
+	})
+
+	t.Run("requires and verifies client certs when a CA file is set", func(t *testing.T) {
+		caFile := newTestCA(t).writeFile(t)
+
+		cfg, err := bedrockconfig.Read(context.Background(), withClientAuth(
+			base,
+			bedrockconfig.ReaderOf(caFile),
+			bedrockconfig.EmptyReader[tls.ClientAuthType](),
+		))
+		require.NoError(t, err)
+		require.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+		require.NotNil(t, cfg.ClientCAs)
+	})
+
+	t.Run("uses the configured client auth mode", func(t *testing.T) {
+		caFile := newTestCA(t).writeFile(t)
+
+		cfg, err := bedrockconfig.Read(context.Background(), withClientAuth(
+			base,
+			bedrockconfig.ReaderOf(caFile),
+			bedrockconfig.ReaderOf(tls.VerifyClientCertIfGiven),
+		))
+		require.NoError(t, err)
+		require.Equal(t, tls.VerifyClientCertIfGiven, cfg.ClientAuth)
+	})
+
+	t.Run("returns error when verifying without a CA file", func(t *testing.T) {
+		_, err := bedrockconfig.Read(context.Background(), withClientAuth(
+			base,
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.ReaderOf(tls.RequireAndVerifyClientCert),
+		))
+		require.Error(t, err)
+	})
+}
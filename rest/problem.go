@@ -0,0 +1,362 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. It implements error, so
+// handlers can return it to respond with the problem.
+type Problem struct {
+	// Type is a URI identifying the problem type. It defaults to "about:blank".
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the problem type.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+
+	// Detail explains this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies this occurrence of the problem. It defaults to the
+	// request path.
+	Instance string `json:"instance,omitempty"`
+
+	// TraceID is the ID of the trace the request is part of, if any.
+	TraceID string `json:"traceId,omitempty"`
+
+	// InvalidParams lists the parts of the request which failed validation.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// NewProblem returns a problem of type "about:blank" with the given status.
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// BadRequest returns a 400 problem.
+func BadRequest(detail string) Problem {
+	return NewProblem(http.StatusBadRequest, detail)
+}
+
+// Unauthorized returns a 401 problem.
+func Unauthorized(detail string) Problem {
+	return NewProblem(http.StatusUnauthorized, detail)
+}
+
+// Forbidden returns a 403 problem.
+func Forbidden(detail string) Problem {
+	return NewProblem(http.StatusForbidden, detail)
+}
+
+// NotFound returns a 404 problem.
+func NotFound(detail string) Problem {
+	return NewProblem(http.StatusNotFound, detail)
+}
+
+// Conflict returns a 409 problem.
+func Conflict(detail string) Problem {
+	return NewProblem(http.StatusConflict, detail)
+}
+
+// UnprocessableEntity returns a 422 problem.
+func UnprocessableEntity(detail string) Problem {
+	return NewProblem(http.StatusUnprocessableEntity, detail)
+}
+
+// InternalServerError returns a 500 problem.
+func InternalServerError(detail string) Problem {
+	return NewProblem(http.StatusInternalServerError, detail)
+}
+
+// Error implements the error interface.
+func (p Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + ": " + p.Detail
+}
+
+// ErrorMapping maps matching errors to a status code and detail.
+type ErrorMapping struct {
+	status int
+	detail string
+	match  func(error) bool
+}
+
+// MapError maps errors matching target, as reported by [errors.Is], to the
+// status. The detail describes the problem to clients and defaults to the
+// status text when empty.
+func MapError(target error, status int, detail string) ErrorMapping {
+	return ErrorMapping{
+		status: status,
+		detail: detail,
+		match: func(err error) bool {
+			return errors.Is(err, target)
+		},
+	}
+}
+
+// MapErrorType maps errors of type E, as reported by [errors.As], to the
+// status. The detail describes the problem to clients and defaults to the
+// status text when empty.
+func MapErrorType[E error](status int, detail string) ErrorMapping {
+	return ErrorMapping{
+		status: status,
+		detail: detail,
+		match: func(err error) bool {
+			var target E
+			return errors.As(err, &target)
+		},
+	}
+}
+
+// ErrorRegistry maps errors returned by handlers to problems. The first
+// matching mapping wins.
+type ErrorRegistry []ErrorMapping
+
+// Problem returns the problem for err. Problems are returned as is, errors
+// matching a mapping are described by the detail of the mapping and any other
+// error results in a 500 problem. Only problems disclose their message, since
+// other errors may wrap internal details.
+func (reg ErrorRegistry) Problem(err error) Problem {
+	var p Problem
+	if errors.As(err, &p) {
+		return p
+	}
+	for _, m := range reg {
+		if m.match(err) {
+			return m.problem()
+		}
+	}
+
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	log.Error("unhandled error", slog.Any("error", err))
+	return InternalServerError("An unexpected error occurred.")
+}
+
+// problem returns the problem described by the mapping.
+func (m ErrorMapping) problem() Problem {
+	detail := m.detail
+	if detail == "" {
+		detail = http.StatusText(m.status)
+	}
+	return NewProblem(m.status, detail)
+}
+
+// statuses returns the distinct status codes of the mappings.
+func (reg ErrorRegistry) statuses() []int {
+	var statuses []int
+	for _, m := range reg {
+		if !slices.Contains(statuses, m.status) {
+			statuses = append(statuses, m.status)
+		}
+	}
+	return statuses
+}
+
+// CatchProblems completes the endpoint by mapping every error returned by the
+// handler to a [Problem] with reg. The status codes of the mappings are
+// documented in the OpenAPI spec.
+//
+// Since bedrock writes every error response of a type with the same status
+// code, the status code of the response is set to that of the problem by
+// [ProblemDetails], which [Run] applies to all routes.
+func CatchProblems(reg ErrorRegistry, ep bedrockrest.Endpoint) bedrockrest.Route {
+	for _, status := range reg.statuses() {
+		ep = bedrockrest.ErrorJSON[Problem](status, ep)
+	}
+	return bedrockrest.CatchAll(http.StatusInternalServerError, reg.Problem, ep)
+}
+
+// ProblemDetails rewrites error responses carrying problem details, e.g. a
+// [Problem] returned by a handler, so that the response status code matches
+// the status of the problem and the content type is application/problem+json.
+// The instance of the problem defaults to the request path and the trace ID
+// is set from the span of the request. [Run] applies it to all routes.
+func ProblemDetails(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pw := &problemWriter{ResponseWriter: w, r: r}
+		next.ServeHTTP(pw, r)
+		pw.finish()
+	})
+}
+
+// problemWriter buffers JSON error responses, which may carry problem
+// details, until the handler returns.
+type problemWriter struct {
+	http.ResponseWriter
+	r *http.Request
+
+	wroteHeader bool
+	intercept   bool
+	status      int
+	body        bytes.Buffer
+}
+
+func (pw *problemWriter) WriteHeader(status int) {
+	if pw.wroteHeader {
+		return
+	}
+	if isInformational(status) {
+		pw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	pw.wroteHeader = true
+	if status >= http.StatusBadRequest && isJSON(pw.Header().Get("Content-Type")) {
+		pw.intercept = true
+		pw.status = status
+		return
+	}
+	pw.ResponseWriter.WriteHeader(status)
+}
+
+func (pw *problemWriter) Write(b []byte) (int, error) {
+	if !pw.wroteHeader {
+		pw.WriteHeader(http.StatusOK)
+	}
+	if pw.intercept {
+		return pw.body.Write(b)
+	}
+	return pw.ResponseWriter.Write(b)
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying writer.
+func (pw *problemWriter) Unwrap() http.ResponseWriter {
+	return pw.ResponseWriter
+}
+
+func (pw *problemWriter) finish() {
+	if !pw.intercept {
+		return
+	}
+
+	body := pw.body.Bytes()
+	status := pw.status
+	if p, ok := pw.problem(body); ok {
+		b, err := json.Marshal(p)
+		if err == nil {
+			body = append(b, '\n')
+			status = p["status"].(int)
+			pw.Header().Set("Content-Type", ProblemContentType)
+		}
+	}
+	pw.Header().Del("Content-Length")
+	pw.ResponseWriter.WriteHeader(status)
+	pw.ResponseWriter.Write(body) //nolint:errcheck
+}
+
+// problem decodes the response body as problem details, retaining any
+// extension members, and fills in the instance and trace ID.
+func (pw *problemWriter) problem(body []byte) (map[string]any, bool) {
+	var p map[string]any
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, false
+	}
+	if _, ok := p["title"].(string); !ok {
+		return nil, false
+	}
+	status, ok := p["status"].(float64)
+	if !ok || status < http.StatusBadRequest || status > 599 || status != float64(int(status)) {
+		return nil, false
+	}
+	p["status"] = int(status)
+
+	if instance, _ := p["instance"].(string); instance == "" {
+		p["instance"] = pw.r.URL.Path
+	}
+	if traceID, _ := p["traceId"].(string); traceID == "" {
+		if sc := trace.SpanContextFromContext(pw.r.Context()); sc.HasTraceID() {
+			p["traceId"] = sc.TraceID().String()
+		}
+	}
+	return p, true
+}
+
+// isJSON reports whether the content type is JSON, including problem details.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || mediaType == ProblemContentType
+}
+
+// writeProblem writes an RFC 7807 problem details response.
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(NewProblem(status, detail)) //nolint:errcheck
+}
+
+// problemContentType documents error responses carrying problem details with
+// the application/problem+json content type in the OpenAPI spec.
+func problemContentType(spec map[string]any) error {
+	schemas, _ := specObject(spec, "components")["schemas"].(map[string]any)
+
+	paths, _ := spec["paths"].(map[string]any)
+	for _, item := range paths {
+		item, _ := item.(map[string]any)
+		for _, op := range item {
+			op, _ := op.(map[string]any)
+			responses, _ := op["responses"].(map[string]any)
+			for code, resp := range responses {
+				status, err := strconv.Atoi(code)
+				if err != nil || status < http.StatusBadRequest {
+					continue
+				}
+				resp, _ := resp.(map[string]any)
+				content, _ := resp["content"].(map[string]any)
+				media, ok := content["application/json"].(map[string]any)
+				if !ok || !isProblemSchema(schemas, media["schema"]) {
+					continue
+				}
+				delete(content, "application/json")
+				content[ProblemContentType] = media
+			}
+		}
+	}
+	return nil
+}
+
+// isProblemSchema reports whether the schema, or the component schema it
+// refers to, has the members of problem details.
+func isProblemSchema(schemas map[string]any, schema any) bool {
+	s, _ := schema.(map[string]any)
+	if ref, ok := s["$ref"].(string); ok {
+		s, _ = schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+	}
+	props, _ := s["properties"].(map[string]any)
+	for _, member := range []string{"type", "title", "status"} {
+		if _, ok := props[member]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errPetNotFound = errors.New("pet not found")
+
+type conflictError struct {
+	name string
+}
+
+func (e conflictError) Error() string {
+	return fmt.Sprintf("pet %q already exists", e.name)
+}
+
+func TestErrorRegistry_Problem(t *testing.T) {
+	reg := ErrorRegistry{
+		MapError(errPetNotFound, http.StatusNotFound, "The pet does not exist."),
+		MapErrorType[conflictError](http.StatusConflict, ""),
+	}
+
+	testCases := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "returns problems as is",
+			err:  fmt.Errorf("wrapped: %w", Forbidden("not your pet")),
+			want: Forbidden("not your pet"),
+		},
+		{
+			name: "maps errors by value",
+			err:  fmt.Errorf("get pet: %w", errPetNotFound),
+			want: NotFound("The pet does not exist."),
+		},
+		{
+			name: "maps errors by type with the status text",
+			err:  conflictError{name: "rex"},
+			want: Conflict("Conflict"),
+		},
+		{
+			name: "hides unmapped errors",
+			err:  errors.New("connection refused"),
+			want: InternalServerError("An unexpected error occurred."),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, reg.Problem(tc.err))
+		})
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+
+	testCases := []struct {
+		name        string
+		handler     http.HandlerFunc
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name: "rewrites the status and content type of problems",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(Conflict("pet exists")) //nolint:errcheck
+			},
+			status:      http.StatusConflict,
+			contentType: ProblemContentType,
+			body:        fmt.Sprintf(`{"type":"about:blank","title":"Conflict","status":409,"detail":"pet exists","instance":"/pets/1","traceId":"%s"}`, traceID),
+		},
+		{
+			name: "keeps extension members and the instance",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", ProblemContentType)
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"title":"Bad Request","status":400,"instance":"urn:request:1","balance":30}`)) //nolint:errcheck
+			},
+			status:      http.StatusBadRequest,
+			contentType: ProblemContentType,
+			body:        fmt.Sprintf(`{"title":"Bad Request","status":400,"instance":"urn:request:1","balance":30,"traceId":"%s"}`, traceID),
+		},
+		{
+			name: "passes through other JSON errors",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"bad"}`)) //nolint:errcheck
+			},
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error":"bad"}`,
+		},
+		{
+			name: "passes through successful responses",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"title":"Dune","status":200}`)) //nolint:errcheck
+			},
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"title":"Dune","status":200}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/pets/1", nil)
+			r = r.WithContext(trace.ContextWithSpanContext(r.Context(), spanCtx))
+			w := httptest.NewRecorder()
+
+			ProblemDetails(tc.handler).ServeHTTP(w, r)
+
+			require.Equal(t, tc.status, w.Code)
+			require.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			require.JSONEq(t, tc.body, w.Body.String())
+		})
+	}
+}
+
+func TestProblemDetails_Informational(t *testing.T) {
+	srv := httptest.NewServer(ProblemDetails(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(Conflict("pet exists")) //nolint:errcheck
+	})))
+	defer srv.Close()
+
+	var informational []int
+	ctx := httptrace.WithClientTrace(t.Context(), &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			informational = append(informational, code)
+			return nil
+		},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/pets/1", nil)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, []int{http.StatusEarlyHints}, informational)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	require.Equal(t, ProblemContentType, resp.Header.Get("Content-Type"))
+
+	var p Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+	require.Equal(t, "pet exists", p.Detail)
+	require.Equal(t, "/pets/1", p.Instance)
+}
+
+func TestCatchProblems(t *testing.T) {
+	petID := bedrockrest.PathParam[string]("id")
+	ep := bedrockrest.GET("/pets/{id}", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		switch bedrockrest.ParamFrom(req, petID) {
+		case "1":
+			return testResponse{Message: "rex"}, nil
+		case "2":
+			return testResponse{}, fmt.Errorf("get pet: %w", errPetNotFound)
+		case "3":
+			return testResponse{}, Conflict("pet is being adopted")
+		default:
+			return testResponse{}, errors.New("connection refused")
+		}
+	})
+	ep = petID.Read(ep)
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	route := CatchProblems(ErrorRegistry{MapError(errPetNotFound, http.StatusNotFound, "The pet does not exist.")}, ep)
+
+	h, err := bedrockrest.Build(route.Route()).Build(context.Background())
+	require.NoError(t, err)
+	h, err = decorateSpec(defaultSpecPath, h, problemContentType)
+	require.NoError(t, err)
+	h = ProblemDetails(h)
+
+	testCases := []struct {
+		name   string
+		id     string
+		status int
+		detail string
+	}{
+		{name: "maps registered errors", id: "2", status: http.StatusNotFound, detail: "The pet does not exist."},
+		{name: "returns problems", id: "3", status: http.StatusConflict, detail: "pet is being adopted"},
+		{name: "hides unregistered errors", id: "4", status: http.StatusInternalServerError, detail: "An unexpected error occurred."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/"+tc.id, nil))
+
+			require.Equal(t, tc.status, w.Code)
+			require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+			var p Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			require.Equal(t, tc.status, p.Status)
+			require.Equal(t, tc.detail, p.Detail)
+			require.Equal(t, "/pets/"+tc.id, p.Instance)
+		})
+	}
+
+	t.Run("returns successful responses", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("documents problems in the OpenAPI spec", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, defaultSpecPath, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]struct {
+					Content map[string]any `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+
+		responses := spec.Paths["/pets/{id}"]["get"].Responses
+		require.Contains(t, responses["200"].Content, "application/json")
+		for _, code := range []string{"404", "500"} {
+			require.Contains(t, responses[code].Content, ProblemContentType)
+			require.NotContains(t, responses[code].Content, "application/json")
+		}
+	})
+}
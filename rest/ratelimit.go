@@ -0,0 +1,429 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/z5labs/humus"
+)
+
+// RateLimitAlgorithm is the algorithm a [RateLimit] is enforced with.
+type RateLimitAlgorithm int
+
+const (
+	// TokenBucket allows bursts of up to Requests requests, with tokens
+	// being refilled evenly over the Period.
+	TokenBucket RateLimitAlgorithm = iota
+
+	// SlidingWindow allows Requests requests within any Period, estimated
+	// from the request counts of the current and previous fixed windows.
+	SlidingWindow
+)
+
+func (a RateLimitAlgorithm) String() string {
+	switch a {
+	case TokenBucket:
+		return "token_bucket"
+	case SlidingWindow:
+		return "sliding_window"
+	default:
+		return fmt.Sprintf("RateLimitAlgorithm(%d)", int(a))
+	}
+}
+
+// RateLimitKey identifies the client a request is counted against. An empty
+// key falls back to the IP address of the client.
+type RateLimitKey func(r *http.Request) string
+
+// KeyByIP counts requests against the IP address of the client. The address
+// of the connection is used, so servers behind a proxy should use a key
+// derived from a trusted forwarding header instead.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// KeyByAPIKey counts requests against the caller an API key was issued to.
+func KeyByAPIKey(r *http.Request) string {
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok || p.Scheme != APIKeyAuthScheme {
+		return ""
+	}
+	return "apikey:" + p.Subject
+}
+
+// KeyBySubject counts requests against the subject of the JWT bearer token.
+func KeyBySubject(r *http.Request) string {
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok || p.Scheme != BearerAuthScheme {
+		return ""
+	}
+	return "sub:" + p.Subject
+}
+
+// KeyByPrincipal counts requests against the authenticated caller, regardless
+// of the security scheme it authenticated with.
+func KeyByPrincipal(r *http.Request) string {
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		return ""
+	}
+	return p.Scheme + ":" + p.Subject
+}
+
+// RateLimit limits how many requests a client may send.
+type RateLimit struct {
+	// Requests is the number of requests allowed per Period.
+	Requests int
+
+	// Period is the duration over which Requests are allowed.
+	Period time.Duration
+
+	// Algorithm is the algorithm the limit is enforced with. It defaults to [TokenBucket].
+	Algorithm RateLimitAlgorithm
+
+	// Key identifies the client. It defaults to [KeyByIP].
+	Key RateLimitKey
+}
+
+func (l RateLimit) validate() error {
+	if l.Requests < 1 || l.Period <= 0 {
+		return fmt.Errorf("rest: invalid rate limit of %d requests per %s", l.Requests, l.Period)
+	}
+	if l.Algorithm != TokenBucket && l.Algorithm != SlidingWindow {
+		return fmt.Errorf("rest: unknown rate limit algorithm: %s", l.Algorithm)
+	}
+	return nil
+}
+
+// RateLimitResult is the outcome of counting a request against a [RateLimit].
+type RateLimitResult struct {
+	// Allowed reports whether the request is within the limit.
+	Allowed bool
+
+	// Remaining is the number of requests the client may still send now.
+	Remaining int
+
+	// Reset is the time until the quota of the client is fully restored.
+	Reset time.Duration
+
+	// RetryAfter is the time until the client may send the next request, if
+	// the request was not allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimiter stores the state of rate limits, e.g. in memory or in a
+// database shared by all replicas of the server.
+type RateLimiter interface {
+	// Take counts a request of the client identified by key against the limit.
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitBackend sets where the state of rate limits is stored. It defaults
+// to a [MemoryRateLimiter], which only limits requests per replica.
+func RateLimitBackend(l RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = l
+	}
+}
+
+// GlobalRateLimit limits the requests of every client across all routes, in
+// addition to the limits of individual routes.
+func GlobalRateLimit(limit RateLimit) Option {
+	return func(o *options) {
+		o.globalRateLimit = &limit
+	}
+}
+
+// AuthRateLimit limits the requests of every client before they are
+// authenticated, so clients guessing API keys or tokens are limited even
+// though their requests are rejected. Clients are identified by [KeyByIP],
+// unless the Key of the limit is set, e.g. to a key derived from a trusted
+// forwarding header. Keys derived from the principal fall back to the IP
+// address, since the caller is not authenticated yet.
+func AuthRateLimit(limit RateLimit) Option {
+	return func(o *options) {
+		o.authRateLimit = &limit
+	}
+}
+
+// LimitRate limits the requests of every client to the route.
+func LimitRate(limit RateLimit) RouteOption {
+	return func(ro *routeOptions) {
+		ro.rateLimit = &limit
+	}
+}
+
+// rateLimitHandler counts every request against the limit before calling
+// next, and rejects requests exceeding it with a 429 problem. The state of
+// the limit is stored under the given scope, e.g. the route. If the rate
+// limiter fails, the request is allowed.
+func rateLimitHandler(limiter RateLimiter, scope string, limit RateLimit, next http.Handler) http.Handler {
+	log := humus.Logger("github.com/z5labs/humus/rest")
+	key := limit.Key
+	if key == nil {
+		key = KeyByIP
+	}
+	prefix := fmt.Sprintf("%s|%s|%d/%s|", scope, limit.Algorithm, limit.Requests, limit.Period)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := key(r)
+		if client == "" {
+			client = KeyByIP(r)
+		}
+
+		res, err := limiter.Take(r.Context(), prefix+client, limit)
+		if err != nil {
+			log.ErrorContext(r.Context(), "failed to apply rate limit", slog.String("scope", scope), slog.Any("error", err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		setRateLimitHeaders(w.Header(), limit, res)
+		if !res.Allowed {
+			retryAfter := ceilSeconds(res.RetryAfter)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeProblem(w, http.StatusTooManyRequests, fmt.Sprintf("The rate limit of %d requests per %s is exceeded. Retry after %d seconds.", limit.Requests, limit.Period, retryAfter))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setRateLimitHeaders sets the RateLimit header fields, unless a more
+// restrictive limit has already set them.
+func setRateLimitHeaders(h http.Header, limit RateLimit, res RateLimitResult) {
+	if v := h.Get("RateLimit-Remaining"); v != "" {
+		if remaining, err := strconv.Atoi(v); err == nil && remaining < res.Remaining {
+			return
+		}
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Period)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// tokenBucketResult describes the state of a token bucket after a request.
+func tokenBucketResult(limit RateLimit, tokens float64, allowed bool) RateLimitResult {
+	rate := float64(limit.Requests) / limit.Period.Seconds()
+	res := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: max(0, int(math.Floor(tokens))),
+		Reset:     seconds((float64(limit.Requests) - tokens) / rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return res
+}
+
+// slidingWindowResult describes the state of a sliding window after a
+// request, where elapsed is the fraction of the current window which has
+// elapsed.
+func slidingWindowResult(limit RateLimit, previous, current, elapsed float64, allowed bool) RateLimitResult {
+	period := limit.Period.Seconds()
+	estimate := previous*(1-elapsed) + current
+	res := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: max(0, int(math.Floor(float64(limit.Requests)-estimate))),
+		Reset:     seconds((1 - elapsed) * period),
+	}
+	if !allowed {
+		// The estimate decreases as the previous window slides out, until the
+		// current window ends.
+		res.RetryAfter = res.Reset
+		if previous > 0 {
+			if wait := (estimate + 1 - float64(limit.Requests)) / previous; wait < 1-elapsed {
+				res.RetryAfter = seconds(wait * period)
+			}
+		}
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(0, s) * float64(time.Second))
+}
+
+// rateLimitState is the state of a rate limit for a single client.
+type rateLimitState struct {
+	// tokens is the number of available tokens of a token bucket or the
+	// number of requests in the current window of a sliding window.
+	tokens float64
+
+	// previous is the number of requests in the previous window of a sliding window.
+	previous float64
+
+	// window is the index of the current window of a sliding window.
+	window int64
+
+	// updated is the time of the last request.
+	updated time.Time
+
+	// period is the period of the limit, after which idle state may be discarded.
+	period time.Duration
+}
+
+func (s *rateLimitState) take(now time.Time, limit RateLimit, fresh bool) RateLimitResult {
+	defer func() {
+		s.updated = now
+		s.period = limit.Period
+	}()
+
+	switch limit.Algorithm {
+	case SlidingWindow:
+		window := now.UnixNano() / int64(limit.Period)
+		switch {
+		case fresh || s.window < window-1:
+			s.previous, s.tokens = 0, 0
+		case s.window == window-1:
+			s.previous, s.tokens = s.tokens, 0
+		}
+		s.window = window
+
+		elapsed := float64(now.UnixNano()-window*int64(limit.Period)) / float64(limit.Period)
+		allowed := s.previous*(1-elapsed)+s.tokens+1 <= float64(limit.Requests)
+		if allowed {
+			s.tokens++
+		}
+		return slidingWindowResult(limit, s.previous, s.tokens, elapsed, allowed)
+	default:
+		capacity := float64(limit.Requests)
+		if fresh {
+			s.tokens = capacity
+		} else {
+			rate := capacity / limit.Period.Seconds()
+			s.tokens = math.Min(capacity, s.tokens+now.Sub(s.updated).Seconds()*rate)
+		}
+		allowed := s.tokens >= 1
+		if allowed {
+			s.tokens--
+		}
+		return tokenBucketResult(limit, s.tokens, allowed)
+	}
+}
+
+// memoryRateLimiterSweepInterval is how often idle clients are removed.
+const memoryRateLimiterSweepInterval = time.Minute
+
+// MemoryRateLimiter is a [RateLimiter] which stores the state of rate limits
+// in memory. Limits are therefore enforced per replica of the server.
+type MemoryRateLimiter struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	states    map[string]*rateLimitState
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimiter returns an empty [MemoryRateLimiter].
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		now:    time.Now,
+		states: make(map[string]*rateLimitState),
+	}
+}
+
+// Take implements the [RateLimiter] interface.
+func (m *MemoryRateLimiter) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	state, ok := m.states[key]
+	if !ok {
+		state = &rateLimitState{}
+		m.states[key] = state
+	}
+	return state.take(now, limit, !ok), nil
+}
+
+// sweep removes clients which have been idle for long enough that their
+// quota is fully restored.
+func (m *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < memoryRateLimiterSweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for key, state := range m.states {
+		if now.Sub(state.updated) > 2*state.period {
+			delete(m.states, key)
+		}
+	}
+}
+
+// rateLimitResponses documents the rate limits of the routes in the OpenAPI
+// spec. Operations which are rate limited may respond with 429 and carry the
+// most specific limit in the x-rate-limit extension.
+func rateLimitResponses(routes []authorizedRoute, auth, global *RateLimit) specDecorator {
+	return func(spec map[string]any) error {
+		limited := func(op map[string]any, limit RateLimit) {
+			op["x-rate-limit"] = map[string]any{
+				"requests":  limit.Requests,
+				"period":    limit.Period.String(),
+				"algorithm": limit.Algorithm.String(),
+			}
+			responses := specObject(op, "responses")
+			addResponse(responses, http.StatusTooManyRequests)
+			resp := responses[strconv.Itoa(http.StatusTooManyRequests)].(map[string]any)
+			headers := specObject(resp, "headers")
+			for name, description := range map[string]string{
+				"Retry-After":         "Seconds until the client may send the next request.",
+				"RateLimit-Limit":     "Number of requests allowed per period.",
+				"RateLimit-Remaining": "Number of requests the client may still send.",
+				"RateLimit-Reset":     "Seconds until the quota of the client is fully restored.",
+			} {
+				headers[name] = map[string]any{
+					"description": description,
+					"schema":      map[string]any{"type": "integer"},
+				}
+			}
+		}
+
+		paths := specObject(spec, "paths")
+		for _, limit := range []*RateLimit{auth, global} {
+			if limit == nil {
+				continue
+			}
+			for _, item := range paths {
+				item, _ := item.(map[string]any)
+				for method, op := range item {
+					if op, ok := op.(map[string]any); ok && isHTTPMethod(method) {
+						limited(op, *limit)
+					}
+				}
+			}
+		}
+		for _, route := range routes {
+			if route.authz.rateLimit == nil {
+				continue
+			}
+			op := specObject(specObject(paths, route.op.path), strings.ToLower(route.op.method))
+			limited(op, *route.authz.rateLimit)
+		}
+		return nil
+	}
+}
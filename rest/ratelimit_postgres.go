@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultRateLimitTable is the name of the rate limit table used by
+// [NewPostgresRateLimiter] when none is configured.
+const DefaultRateLimitTable = "rate_limits"
+
+// Querier is implemented by *pgx.Conn and *pgxpool.Pool.
+type Querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// PostgresRateLimiter is a [RateLimiter] which stores the state of rate
+// limits in a Postgres table, so limits are enforced across all replicas of
+// the server. Every request is counted with a single atomic upsert, using
+// the clock of the database. The table must have the following schema:
+//
+//	CREATE TABLE rate_limits (
+//	    key          TEXT PRIMARY KEY,
+//	    tokens       DOUBLE PRECISION NOT NULL,
+//	    previous     DOUBLE PRECISION NOT NULL,
+//	    window_index BIGINT NOT NULL,
+//	    updated_at   TIMESTAMPTZ NOT NULL,
+//	    allowed      BOOLEAN NOT NULL
+//	);
+//
+// Rows of idle clients are not removed automatically. They can be deleted
+// periodically once their quota is restored, e.g. with
+//
+//	DELETE FROM rate_limits WHERE updated_at < now() - interval '1 day';
+//
+// given no limit has a period longer than half a day.
+type PostgresRateLimiter struct {
+	db                 Querier
+	tokenBucketQuery   string
+	slidingWindowQuery string
+}
+
+// NewPostgresRateLimiter initializes a [PostgresRateLimiter] for the given
+// table. The table name may be schema qualified, e.g. "api.rate_limits".
+func NewPostgresRateLimiter(db Querier, table string) *PostgresRateLimiter {
+	table = pgx.Identifier(strings.Split(table, ".")).Sanitize()
+
+	// $1 is the key, $2 the capacity of the bucket and $3 the refill rate
+	// in tokens per second.
+	refilled := `LEAST($2::float8, t.tokens + EXTRACT(EPOCH FROM now() - t.updated_at)::float8 * $3::float8)`
+	tokenBucketQuery := fmt.Sprintf(`INSERT INTO %[1]s AS t (key, tokens, previous, window_index, updated_at, allowed)
+VALUES ($1, $2::float8 - 1, 0, 0, now(), true)
+ON CONFLICT (key) DO UPDATE SET
+	tokens = CASE WHEN %[2]s >= 1 THEN %[2]s - 1 ELSE %[2]s END,
+	updated_at = now(),
+	allowed = %[2]s >= 1
+RETURNING t.tokens, t.previous, 0::float8, t.allowed`, table, refilled)
+
+	// $1 is the key, $2 the number of requests allowed per window and $3
+	// the length of a window in seconds.
+	position := `EXTRACT(EPOCH FROM now())::float8 / $3::float8`
+	window := fmt.Sprintf(`floor(%s)::bigint`, position)
+	elapsed := fmt.Sprintf(`(%[1]s - floor(%[1]s))`, position)
+	previous := fmt.Sprintf(`(CASE WHEN t.window_index = %[1]s THEN t.previous WHEN t.window_index = %[1]s - 1 THEN t.tokens ELSE 0 END)`, window)
+	current := fmt.Sprintf(`(CASE WHEN t.window_index = %s THEN t.tokens ELSE 0 END)`, window)
+	allowed := fmt.Sprintf(`(%s * (1 - %s) + %s + 1 <= $2::float8)`, previous, elapsed, current)
+	slidingWindowQuery := fmt.Sprintf(`INSERT INTO %[1]s AS t (key, tokens, previous, window_index, updated_at, allowed)
+VALUES ($1, 1, 0, %[2]s, now(), true)
+ON CONFLICT (key) DO UPDATE SET
+	tokens = %[4]s + CASE WHEN %[5]s THEN 1 ELSE 0 END,
+	previous = %[3]s,
+	window_index = %[2]s,
+	updated_at = now(),
+	allowed = %[5]s
+RETURNING t.tokens, t.previous, %[6]s, t.allowed`, table, window, previous, current, allowed, elapsed)
+
+	return &PostgresRateLimiter{
+		db:                 db,
+		tokenBucketQuery:   tokenBucketQuery,
+		slidingWindowQuery: slidingWindowQuery,
+	}
+}
+
+// Take implements the [RateLimiter] interface.
+func (p *PostgresRateLimiter) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	var (
+		tokens, previous, elapsed float64
+		allowed                   bool
+	)
+	switch limit.Algorithm {
+	case SlidingWindow:
+		err := p.db.QueryRow(ctx, p.slidingWindowQuery, key, float64(limit.Requests), limit.Period.Seconds()).Scan(&tokens, &previous, &elapsed, &allowed)
+		if err != nil {
+			return RateLimitResult{}, fmt.Errorf("rest: failed to take from sliding window: %w", err)
+		}
+		return slidingWindowResult(limit, previous, tokens, elapsed, allowed), nil
+	default:
+		rate := float64(limit.Requests) / limit.Period.Seconds()
+		err := p.db.QueryRow(ctx, p.tokenBucketQuery, key, float64(limit.Requests), rate).Scan(&tokens, &previous, &elapsed, &allowed)
+		if err != nil {
+			return RateLimitResult{}, fmt.Errorf("rest: failed to take from token bucket: %w", err)
+		}
+		return tokenBucketResult(limit, tokens, allowed), nil
+	}
+}
@@ -0,0 +1,85 @@
+//go:build testcontainers
+
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/z5labs/humus/internal/pgtest"
+)
+
+func TestPostgresRateLimiter_Container(t *testing.T) {
+	db, cleanup := pgtest.Start(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE SCHEMA api;
+CREATE TABLE api.rate_limits (
+    key          TEXT PRIMARY KEY,
+    tokens       DOUBLE PRECISION NOT NULL,
+    previous     DOUBLE PRECISION NOT NULL,
+    window_index BIGINT NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    allowed      BOOLEAN NOT NULL
+)`)
+	require.NoError(t, err)
+
+	l := NewPostgresRateLimiter(db, "api.rate_limits")
+
+	for _, algorithm := range []RateLimitAlgorithm{TokenBucket, SlidingWindow} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			limit := RateLimit{Requests: 2, Period: time.Hour, Algorithm: algorithm}
+			key := algorithm.String() + "|alice"
+
+			res, err := l.Take(ctx, key, limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, 1, res.Remaining)
+
+			res, err = l.Take(ctx, key, limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, 0, res.Remaining)
+
+			res, err = l.Take(ctx, key, limit)
+			require.NoError(t, err)
+			require.False(t, res.Allowed)
+			require.Positive(t, res.RetryAfter)
+
+			// Other clients have their own quota.
+			res, err = l.Take(ctx, algorithm.String()+"|bob", limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+		})
+	}
+
+	t.Run("counts concurrent requests atomically", func(t *testing.T) {
+		limit := RateLimit{Requests: 10, Period: time.Hour}
+
+		var (
+			wg      sync.WaitGroup
+			allowed atomic.Int64
+		)
+		for range 30 {
+			wg.Go(func() {
+				res, err := l.Take(ctx, "concurrent", limit)
+				if err == nil && res.Allowed {
+					allowed.Add(1)
+				}
+			})
+		}
+		wg.Wait()
+
+		require.Equal(t, int64(10), allowed.Load())
+	})
+}
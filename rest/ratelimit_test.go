@@ -0,0 +1,345 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestRateLimitKeys(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	require.Equal(t, "ip:192.0.2.1", KeyByIP(r))
+	require.Empty(t, KeyByAPIKey(r))
+	require.Empty(t, KeyBySubject(r))
+	require.Empty(t, KeyByPrincipal(r))
+
+	r = r.WithContext(context.WithValue(r.Context(), principalKey{}, Principal{Subject: "alice", Scheme: APIKeyAuthScheme}))
+	require.Equal(t, "apikey:alice", KeyByAPIKey(r))
+	require.Empty(t, KeyBySubject(r))
+	require.Equal(t, APIKeyAuthScheme+":alice", KeyByPrincipal(r))
+
+	r = r.WithContext(context.WithValue(r.Context(), principalKey{}, Principal{Subject: "bob", Scheme: BearerAuthScheme}))
+	require.Empty(t, KeyByAPIKey(r))
+	require.Equal(t, "sub:bob", KeyBySubject(r))
+}
+
+func TestMemoryRateLimiter(t *testing.T) {
+	start := time.Unix(1000, 0)
+	take := func(t *testing.T, m *MemoryRateLimiter, at time.Duration, limit RateLimit) RateLimitResult {
+		t.Helper()
+
+		m.now = func() time.Time { return start.Add(at) }
+		res, err := m.Take(context.Background(), "client", limit)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("token bucket", func(t *testing.T) {
+		m := NewMemoryRateLimiter()
+		limit := RateLimit{Requests: 2, Period: time.Second, Algorithm: TokenBucket}
+
+		require.Equal(t, RateLimitResult{Allowed: true, Remaining: 1, Reset: 500 * time.Millisecond}, take(t, m, 0, limit))
+		require.Equal(t, RateLimitResult{Allowed: true, Remaining: 0, Reset: time.Second}, take(t, m, 0, limit))
+		require.Equal(t, RateLimitResult{Allowed: false, Remaining: 0, Reset: time.Second, RetryAfter: 500 * time.Millisecond}, take(t, m, 0, limit))
+		require.True(t, take(t, m, 500*time.Millisecond, limit).Allowed)
+		require.False(t, take(t, m, 500*time.Millisecond, limit).Allowed)
+	})
+
+	t.Run("sliding window", func(t *testing.T) {
+		m := NewMemoryRateLimiter()
+		limit := RateLimit{Requests: 2, Period: time.Second, Algorithm: SlidingWindow}
+
+		require.Equal(t, RateLimitResult{Allowed: true, Remaining: 1, Reset: time.Second}, take(t, m, 0, limit))
+		require.Equal(t, RateLimitResult{Allowed: true, Remaining: 0, Reset: time.Second}, take(t, m, 0, limit))
+		require.Equal(t, RateLimitResult{Allowed: false, Remaining: 0, Reset: time.Second, RetryAfter: time.Second}, take(t, m, 0, limit))
+
+		// A quarter into the next window, three quarters of the previous
+		// window still count.
+		require.Equal(t, RateLimitResult{Allowed: false, Remaining: 0, Reset: 750 * time.Millisecond, RetryAfter: 250 * time.Millisecond}, take(t, m, 1250*time.Millisecond, limit))
+		require.True(t, take(t, m, 1500*time.Millisecond, limit).Allowed)
+
+		// Windows older than the previous one are forgotten.
+		require.Equal(t, 1, take(t, m, 5*time.Second, limit).Remaining)
+	})
+
+	t.Run("removes idle clients", func(t *testing.T) {
+		m := NewMemoryRateLimiter()
+		limit := RateLimit{Requests: 2, Period: time.Second}
+
+		take(t, m, 0, limit)
+		take(t, m, 2*memoryRateLimiterSweepInterval, limit)
+		require.Len(t, m.states, 1)
+
+		m.now = func() time.Time { return start.Add(4 * memoryRateLimiterSweepInterval) }
+		m.sweep(m.now())
+		require.Empty(t, m.states)
+	})
+}
+
+type rateLimiterFunc func(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+
+func (f rateLimiterFunc) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	return f(ctx, key, limit)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	limit := RateLimit{Requests: 10, Period: time.Minute}
+	serve := func(limiter RateLimiter) *httptest.ResponseRecorder {
+		h := rateLimitHandler(limiter, "GET /pets", limit, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets", nil))
+		return w
+	}
+
+	t.Run("allows requests within the limit", func(t *testing.T) {
+		var gotKey string
+		w := serve(rateLimiterFunc(func(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+			gotKey = key
+			return RateLimitResult{Allowed: true, Remaining: 9, Reset: 6 * time.Second}, nil
+		}))
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "GET /pets|token_bucket|10/1m0s|ip:192.0.2.1", gotKey)
+		require.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+		require.Equal(t, "9", w.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "6", w.Header().Get("RateLimit-Reset"))
+		require.Equal(t, "10;w=60", w.Header().Get("RateLimit-Policy"))
+		require.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("rejects requests exceeding the limit", func(t *testing.T) {
+		w := serve(rateLimiterFunc(func(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+			return RateLimitResult{Reset: 6 * time.Second, RetryAfter: 5500 * time.Millisecond}, nil
+		}))
+
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		require.Equal(t, "6", w.Header().Get("Retry-After"))
+		require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("allows requests if the limiter fails", func(t *testing.T) {
+		w := serve(rateLimiterFunc(func(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+			return RateLimitResult{}, errors.New("database unavailable")
+		}))
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	ep := bedrockrest.GET("/pets", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{Message: "pets"}, nil
+	})
+	route := CatchProblems(nil, bedrockrest.WriteJSON[testResponse](http.StatusOK, ep))
+	h := newVersionedHandler(t,
+		APIKeyAuth(APIKeys{"secret": {Subject: "alice"}}),
+		AuthRateLimit(RateLimit{Requests: 2, Period: time.Hour}),
+		Handle(route),
+	)
+
+	// Requests with unknown API keys count against the limit of the IP address.
+	for range 2 {
+		w := serve(h, "/pets", http.Header{"X-Api-Key": {"guess"}})
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+	w := serve(h, "/pets", http.Header{"X-Api-Key": {"guess"}})
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	w = serve(h, "/pets", http.Header{"X-Api-Key": {"secret"}})
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+type queryRowFunc func(ctx context.Context, sql string, args ...any) pgx.Row
+
+func (f queryRowFunc) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return f(ctx, sql, args...)
+}
+
+type rowFunc func(dest ...any) error
+
+func (f rowFunc) Scan(dest ...any) error {
+	return f(dest...)
+}
+
+func TestPostgresRateLimiter(t *testing.T) {
+	t.Run("takes from a token bucket", func(t *testing.T) {
+		var (
+			gotSQL  string
+			gotArgs []any
+		)
+		db := queryRowFunc(func(ctx context.Context, sql string, args ...any) pgx.Row {
+			gotSQL, gotArgs = sql, args
+			return rowFunc(func(dest ...any) error {
+				*dest[0].(*float64) = 0.5
+				*dest[1].(*float64) = 0
+				*dest[2].(*float64) = 0
+				*dest[3].(*bool) = false
+				return nil
+			})
+		})
+
+		l := NewPostgresRateLimiter(db, "api.rate_limits")
+		res, err := l.Take(context.Background(), "client", RateLimit{Requests: 2, Period: time.Second})
+		require.NoError(t, err)
+
+		require.True(t, strings.HasPrefix(gotSQL, `INSERT INTO "api"."rate_limits" AS t`))
+		require.Equal(t, []any{"client", 2.0, 2.0}, gotArgs)
+		require.Equal(t, RateLimitResult{Allowed: false, Remaining: 0, Reset: 750 * time.Millisecond, RetryAfter: 250 * time.Millisecond}, res)
+	})
+
+	t.Run("takes from a sliding window", func(t *testing.T) {
+		var gotArgs []any
+		db := queryRowFunc(func(ctx context.Context, sql string, args ...any) pgx.Row {
+			gotArgs = args
+			require.Contains(t, sql, "window_index")
+			return rowFunc(func(dest ...any) error {
+				*dest[0].(*float64) = 1
+				*dest[1].(*float64) = 2
+				*dest[2].(*float64) = 0.5
+				*dest[3].(*bool) = true
+				return nil
+			})
+		})
+
+		l := NewPostgresRateLimiter(db, DefaultRateLimitTable)
+		res, err := l.Take(context.Background(), "client", RateLimit{Requests: 4, Period: time.Minute, Algorithm: SlidingWindow})
+		require.NoError(t, err)
+
+		require.Equal(t, []any{"client", 4.0, 60.0}, gotArgs)
+		require.Equal(t, RateLimitResult{Allowed: true, Remaining: 2, Reset: 30 * time.Second}, res)
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		db := queryRowFunc(func(ctx context.Context, sql string, args ...any) pgx.Row {
+			return rowFunc(func(dest ...any) error {
+				return errors.New("connection refused")
+			})
+		})
+
+		l := NewPostgresRateLimiter(db, DefaultRateLimitTable)
+		_, err := l.Take(context.Background(), "client", RateLimit{Requests: 2, Period: time.Second})
+		require.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestRun_RateLimit(t *testing.T) {
+	port := freePort(t)
+
+	newRoute := func(pattern string) bedrockrest.Route {
+		ep := bedrockrest.GET(pattern, func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+			return testResponse{Message: pattern}, nil
+		})
+		ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+		return bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+			return testError{Message: err.Error()}
+		}, ep)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			APIKeyAuth(APIKeys{
+				"alice-key": {Subject: "alice"},
+				"bob-key":   {Subject: "bob"},
+			}),
+			Handle(newRoute("/limited"), LimitRate(RateLimit{Requests: 1, Period: time.Hour, Key: KeyByAPIKey})),
+			Handle(newRoute("/unlimited")),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	get := func(t *testing.T, path, key string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", key)
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("limits requests per client", func(t *testing.T) {
+		resp := get(t, "/limited", "alice-key")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "1", resp.Header.Get("RateLimit-Limit"))
+		require.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+
+		resp = get(t, "/limited", "alice-key")
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+		var p Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		require.Equal(t, http.StatusTooManyRequests, p.Status)
+
+		resp = get(t, "/limited", "bob-key")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("does not limit other routes", func(t *testing.T) {
+		resp := get(t, "/unlimited", "alice-key")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("RateLimit-Limit"))
+	})
+
+	t.Run("documents the limit in the spec", func(t *testing.T) {
+		resp := get(t, "/openapi.json", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RateLimit map[string]any `json:"x-rate-limit"`
+				Responses map[string]struct {
+					Headers map[string]any `json:"headers"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+		limited := spec.Paths["/limited"]["get"]
+		require.Equal(t, map[string]any{"requests": 1.0, "period": "1h0m0s", "algorithm": "token_bucket"}, limited.RateLimit)
+		require.Contains(t, limited.Responses, "429")
+		require.Contains(t, limited.Responses["429"].Headers, "Retry-After")
+		require.Contains(t, limited.Responses["429"].Headers, "RateLimit-Remaining")
+
+		require.NotContains(t, spec.Paths["/unlimited"]["get"].Responses, "429")
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -12,9 +12,12 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"syscall"
 	"time"
 
+	"github.com/z5labs/humus/health"
+
 	"github.com/z5labs/bedrock"
 	bedrockconfig "github.com/z5labs/bedrock/config"
 	bedrockhttp "github.com/z5labs/bedrock/runtime/http"
@@ -27,10 +30,13 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -44,7 +50,15 @@ type options struct {
 	version     string
 	description string
 	specPath    string
-	routes      []bedrockrest.Route
+	docsPath    string
+	routes      []handledRoute
+	webSockets  []handledWebSocket
+	deprecation *deprecation
+
+	// Versioning options
+	versions        []apiVersion
+	versionHeader   string
+	fallbackVersion string
 
 	// Server options
 	port              bedrockconfig.Reader[int]
@@ -54,6 +68,46 @@ type options struct {
 	idleTimeout       bedrockconfig.Reader[time.Duration]
 	maxHeaderBytes    bedrockconfig.Reader[int]
 	tlsConfig         bedrockconfig.Reader[*tls.Config]
+	tlsDisabled       bedrockconfig.Reader[bool]
+	clientCAFile      bedrockconfig.Reader[string]
+	clientAuth        bedrockconfig.Reader[tls.ClientAuthType]
+	h2c               bedrockconfig.Reader[bool]
+	http3             bedrockconfig.Reader[bool]
+	webSocketConns    *webSocketConns
+
+	// ACME options
+	acmeDomains         bedrockconfig.Reader[[]string]
+	acmeEmail           bedrockconfig.Reader[string]
+	acmeDirectoryURL    bedrockconfig.Reader[string]
+	acmeDirectoryCAFile bedrockconfig.Reader[string]
+	acmeCache           bedrockconfig.Reader[autocert.Cache]
+	acmeHTTPPort        bedrockconfig.Reader[int]
+
+	// Auth options
+	jwtIssuer           bedrockconfig.Reader[string]
+	jwtAudience         bedrockconfig.Reader[string]
+	jwksURL             bedrockconfig.Reader[string]
+	jwtClockSkew        bedrockconfig.Reader[time.Duration]
+	jwksRefreshInterval bedrockconfig.Reader[time.Duration]
+	apiKeyStore         APIKeyStore
+	apiKeyHeader        bedrockconfig.Reader[string]
+
+	// Middleware options
+	middleware      []func(http.Handler) http.Handler
+	requestIDHeader bedrockconfig.Reader[string]
+	accessLog       bedrockconfig.Reader[bool]
+	compression     bedrockconfig.Reader[bool]
+	cors            corsOptions
+	maxBodyBytes    bedrockconfig.Reader[int64]
+
+	// Rate limit options
+	rateLimiter     RateLimiter
+	globalRateLimit *RateLimit
+	authRateLimit   *RateLimit
+
+	// Health options
+	readiness []health.Monitor
+	liveness  []health.Monitor
 
 	// OTel option
 	otlpTarget bedrockconfig.Reader[string]
@@ -64,6 +118,8 @@ func defaultOptions() *options {
 		title:   "API",
 		version: "0.0.0",
 
+		webSocketConns: &webSocketConns{},
+
 		port: bedrockconfig.Default(
 			8443,
 			bedrockconfig.IntFromString(bedrockconfig.Env("HUMUS_REST_PORT")),
@@ -89,9 +145,64 @@ func defaultOptions() *options {
 			bedrockconfig.IntFromString(bedrockconfig.Env("HUMUS_REST_MAX_HEADER_BYTES")),
 		),
 		tlsConfig: buildTLSConfig(
+			bedrockconfig.Env("HUMUS_REST_TLS_CERT_FILE"),
+			bedrockconfig.Env("HUMUS_REST_TLS_KEY_FILE"),
+			bedrockconfig.Env("HUMUS_REST_TLS_CA_FILE"),
 			bedrockconfig.Env("HUMUS_REST_TLS_PKCS12_FILE"),
 			bedrockconfig.Env("HUMUS_REST_TLS_PKCS12_PASSWORD"),
 		),
+		clientCAFile: bedrockconfig.Env("HUMUS_REST_TLS_CLIENT_CA_FILE"),
+		clientAuth:   parseClientAuth(bedrockconfig.Env("HUMUS_REST_TLS_CLIENT_AUTH")),
+		tlsDisabled: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_TLS_DISABLED")),
+		),
+		h2c: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_H2C_ENABLED")),
+		),
+		http3: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_HTTP3_ENABLED")),
+		),
+		acmeDomains:         splitList(bedrockconfig.Env("HUMUS_REST_ACME_DOMAINS")),
+		acmeEmail:           bedrockconfig.Env("HUMUS_REST_ACME_EMAIL"),
+		acmeDirectoryURL:    bedrockconfig.Env("HUMUS_REST_ACME_DIRECTORY_URL"),
+		acmeDirectoryCAFile: bedrockconfig.Env("HUMUS_REST_ACME_DIRECTORY_CA_FILE"),
+		acmeCache:           dirCache(bedrockconfig.Env("HUMUS_REST_ACME_CACHE_DIR")),
+		acmeHTTPPort:        bedrockconfig.IntFromString(bedrockconfig.Env("HUMUS_REST_ACME_HTTP_PORT")),
+		jwtIssuer:           bedrockconfig.Env("HUMUS_REST_JWT_ISSUER"),
+		jwtAudience:         bedrockconfig.Env("HUMUS_REST_JWT_AUDIENCE"),
+		jwksURL:             bedrockconfig.Env("HUMUS_REST_JWT_JWKS_URL"),
+		jwtClockSkew: bedrockconfig.Default(
+			time.Minute,
+			bedrockconfig.DurationFromString(bedrockconfig.Env("HUMUS_REST_JWT_CLOCK_SKEW")),
+		),
+		jwksRefreshInterval: bedrockconfig.Default(
+			time.Hour,
+			bedrockconfig.DurationFromString(bedrockconfig.Env("HUMUS_REST_JWT_JWKS_REFRESH_INTERVAL")),
+		),
+		apiKeyHeader: bedrockconfig.Default(
+			"X-API-Key",
+			bedrockconfig.Env("HUMUS_REST_API_KEY_HEADER"),
+		),
+		requestIDHeader: bedrockconfig.Default(
+			"X-Request-ID",
+			bedrockconfig.Env("HUMUS_REST_REQUEST_ID_HEADER"),
+		),
+		accessLog: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_ACCESS_LOG_ENABLED")),
+		),
+		compression: bedrockconfig.Default(
+			false,
+			bedrockconfig.BoolFromString(bedrockconfig.Env("HUMUS_REST_COMPRESSION_ENABLED")),
+		),
+		cors: defaultCORSOptions(),
+		maxBodyBytes: bedrockconfig.Default(
+			int64(0),
+			bedrockconfig.Int64FromString(bedrockconfig.Env("HUMUS_REST_MAX_BODY_BYTES")),
+		),
 	}
 }
 
@@ -123,10 +234,15 @@ func SpecPath(path string) Option {
 	}
 }
 
-// Handle registers a route with the REST server.
-func Handle(route bedrockrest.Route) Option {
+// Handle registers a route with the REST server. The route options declare
+// which callers are authorized to invoke the route, e.g. [RequireScopes].
+func Handle(route bedrockrest.Route, opts ...RouteOption) Option {
 	return func(o *options) {
-		o.routes = append(o.routes, route)
+		r := handledRoute{route: route}
+		for _, opt := range opts {
+			opt(&r.authz)
+		}
+		o.routes = append(o.routes, r)
 	}
 }
 
@@ -177,15 +293,36 @@ func MaxHeaderBytes(r bedrockconfig.Reader[int]) Option {
 	}
 }
 
-// TLSConfig overrides the TLS configuration. By default, cert and key are read
-// from HUMUS_REST_TLS_CERT_FILE and HUMUS_REST_TLS_KEY_FILE. If neither is set,
-// a self-signed certificate is generated automatically.
+// TLSConfig overrides the TLS configuration. By default, a PEM cert and key are read
+// from HUMUS_REST_TLS_CERT_FILE and HUMUS_REST_TLS_KEY_FILE and reloaded whenever the
+// files change, falling back to the PKCS#12 file in HUMUS_REST_TLS_PKCS12_FILE. If
+// neither is set, a self-signed certificate is generated automatically. It is
+// ignored when certificates are provisioned via ACME, see [ACMEDomains].
 func TLSConfig(r bedrockconfig.Reader[*tls.Config]) Option {
 	return func(o *options) {
 		o.tlsConfig = r
 	}
 }
 
+// Insecure disables TLS so the server accepts plain HTTP connections, e.g. when
+// running behind a TLS-terminating proxy or service mesh sidecar. The default is
+// read from HUMUS_REST_TLS_DISABLED (false).
+func Insecure() Option {
+	return func(o *options) {
+		o.tlsDisabled = bedrockconfig.ReaderOf(true)
+	}
+}
+
+// H2C enables HTTP/2 over cleartext (h2c) when TLS is disabled with [Insecure].
+// Both prior knowledge and HTTP/1.1 Upgrade connections are accepted. It has no
+// effect when TLS is enabled, since HTTP/2 is then negotiated via ALPN. The
+// default is read from HUMUS_REST_H2C_ENABLED (false).
+func H2C() Option {
+	return func(o *options) {
+		o.h2c = bedrockconfig.ReaderOf(true)
+	}
+}
+
 // OTLPExporter configures a single OTLP gRPC destination for all three OTel
 // signals (traces, metrics, logs). When set, all signals are exported to the
 // given gRPC target; otherwise traces and metrics are discarded (noop) and
@@ -196,7 +333,7 @@ func OTLPExporter(target bedrockconfig.Reader[string]) Option {
 	}
 }
 
-// Run builds and runs an HTTPS REST server. It blocks until ctx is cancelled
+// Run builds and runs a REST server, serving HTTPS unless [Insecure] is set. It blocks until ctx is cancelled
 // or a termination signal (SIGINT, SIGTERM, SIGKILL) is received.
 func Run(ctx context.Context, opts ...Option) error {
 	o := defaultOptions()
@@ -213,7 +350,7 @@ func Run(ctx context.Context, opts ...Option) error {
 				*sdktrace.TracerProvider,
 				*sdkmetric.MeterProvider,
 				*sdklog.LoggerProvider,
-				bedrockhttp.Runtime,
+				serverRuntime,
 			]](),
 		),
 		os.Interrupt,
@@ -228,7 +365,7 @@ func buildRuntime(o *options) bedrock.Builder[bedrockotel.Runtime[
 	*sdktrace.TracerProvider,
 	*sdkmetric.MeterProvider,
 	*sdklog.LoggerProvider,
-	bedrockhttp.Runtime,
+	serverRuntime,
 ]] {
 	resourceB := bedrock.MemoizeBuilder(
 		bedrock.BuilderFunc[*resource.Resource](func(ctx context.Context) (*resource.Resource, error) {
@@ -238,13 +375,17 @@ func buildRuntime(o *options) bedrock.Builder[bedrockotel.Runtime[
 
 	tracerProviderB, meterProviderB, loggerProviderB := buildOTelProviders(o, resourceB)
 
-	handlerB := buildHandler(o)
+	handlerB := bedrock.MemoizeBuilder(buildHandler(o))
+
+	acmeManagerB := buildACMEManager(o)
 
-	listenerB := buildListener(o)
+	tlsConfigB := buildServerTLSConfig(o, acmeManagerB)
+
+	listenerB := buildListener(o, tlsConfigB)
 
 	httpRuntimeB := bedrockhttp.Build(
 		listenerB,
-		handlerB,
+		withAltSvc(o, handlerB),
 		bedrockhttp.DisableGeneralOptionsHandler(bedrockconfig.ReaderOf(false)),
 		bedrockhttp.ReadTimeout(o.readTimeout),
 		bedrockhttp.ReadHeaderTimeout(o.readHeaderTimeout),
@@ -253,6 +394,13 @@ func buildRuntime(o *options) bedrock.Builder[bedrockotel.Runtime[
 		bedrockhttp.MaxHeaderBytes(o.maxHeaderBytes),
 	)
 
+	serverRuntimeB := buildServerRuntime(
+		httpRuntimeB,
+		buildACMEChallengeRuntime(o, acmeManagerB),
+		buildHTTP3Runtime(o, handlerB, tlsConfigB),
+		buildWebSocketRuntime(o),
+	)
+
 	return bedrockotel.BuildRuntime(
 		bedrock.BuilderOf(otel.ErrorHandlerFunc(func(err error) {})),
 		bedrock.BuilderOf(propagation.NewCompositeTextMapPropagator(
@@ -262,12 +410,131 @@ func buildRuntime(o *options) bedrock.Builder[bedrockotel.Runtime[
 		tracerProviderB,
 		meterProviderB,
 		loggerProviderB,
-		httpRuntimeB,
+		serverRuntimeB,
 	)
 }
 
 // buildHandler constructs the HTTP handler with OTel instrumentation.
 func buildHandler(o *options) bedrock.Builder[http.Handler] {
+	return bedrock.BuilderFunc[http.Handler](func(ctx context.Context) (http.Handler, error) {
+		authenticators, err := buildAuthenticators(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, limit := range []*RateLimit{o.globalRateLimit, o.authRateLimit} {
+			if limit == nil {
+				continue
+			}
+			if err := limit.validate(); err != nil {
+				return nil, err
+			}
+		}
+		limiter := o.rateLimiter
+		if limiter == nil {
+			limiter = NewMemoryRateLimiter()
+		}
+
+		maxBodyBytes, err := bedrockconfig.Read(ctx, o.maxBodyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		a := apiBuilder{
+			authenticators:  authenticators,
+			limiter:         limiter,
+			maxBodyBytes:    maxBodyBytes,
+			globalRateLimit: o.globalRateLimit,
+			authRateLimit:   o.authRateLimit,
+			webSocketConns:  o.webSocketConns,
+		}
+		h, err := a.build(ctx, o, "")
+		if err != nil {
+			return nil, err
+		}
+		h, err = mountVersions(ctx, o, a, h)
+		if err != nil {
+			return nil, err
+		}
+		if o.globalRateLimit != nil {
+			h = rateLimitHandler(limiter, "global", *o.globalRateLimit, h)
+		}
+		h = authHandler(authenticators, append(versionSpecPaths(o), specPathOf(o)), h)
+		if o.authRateLimit != nil {
+			h = rateLimitHandler(limiter, "auth", *o.authRateLimit, h)
+		}
+		h = applyMiddleware(h, o.middleware)
+		h = mountHealth(o, h)
+		h = mountDocs(o, h)
+		h = recoverHandler(h)
+		h = ProblemDetails(h)
+
+		compression, err := bedrockconfig.Read(ctx, o.compression)
+		if err != nil {
+			return nil, err
+		}
+		if compression {
+			h = compressHandler(h)
+		}
+		cors, corsEnabled, err := buildCORSPolicy(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		if corsEnabled {
+			h = corsHandler(cors, h)
+		}
+		accessLog, err := bedrockconfig.Read(ctx, o.accessLog)
+		if err != nil {
+			return nil, err
+		}
+		if accessLog {
+			h = accessLogHandler(h)
+		}
+		requestIDHeader, err := bedrockconfig.Read(ctx, o.requestIDHeader)
+		if err != nil {
+			return nil, err
+		}
+		h = requestIDHandler(requestIDHeader, h)
+		h = peerIdentityHandler(h)
+		h = otelhttp.NewHandler(
+			h,
+			"rest",
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return !isHealthCheck(r)
+			}),
+		)
+
+		tlsDisabled, err := bedrockconfig.Read(ctx, o.tlsDisabled)
+		if err != nil {
+			return nil, err
+		}
+		h2cEnabled, err := bedrockconfig.Read(ctx, o.h2c)
+		if err != nil {
+			return nil, err
+		}
+		if tlsDisabled && h2cEnabled {
+			h = h2c.NewHandler(h, &http2.Server{})
+		}
+		return h, nil
+	})
+}
+
+// apiBuilder builds the handlers of the routes of the API and its versions.
+type apiBuilder struct {
+	authenticators  []authenticator
+	limiter         RateLimiter
+	maxBodyBytes    int64
+	globalRateLimit *RateLimit
+	authRateLimit   *RateLimit
+	webSocketConns  *webSocketConns
+}
+
+// build returns the handler of the routes registered with o, which serves
+// their decorated OpenAPI spec. The prefix is the path prefix of the API
+// version of the routes, if any.
+func (a apiBuilder) build(ctx context.Context, o *options, prefix string, extra ...specDecorator) (http.Handler, error) {
+	routes := o.deprecation.deprecate(o.routes)
+
 	apiOpts := []bedrockrest.Option{
 		bedrockrest.Title(o.title),
 		bedrockrest.Version(o.version),
@@ -278,28 +545,106 @@ func buildHandler(o *options) bedrock.Builder[http.Handler] {
 	if o.specPath != "" {
 		apiOpts = append(apiOpts, bedrockrest.SpecPath(o.specPath))
 	}
-	for _, route := range o.routes {
-		apiOpts = append(apiOpts, route.Route())
+	for _, r := range routes {
+		apiOpts = append(apiOpts, r.route.Route())
+	}
+	h, err := bedrockrest.Build(apiOpts...).Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedRoutes, err := buildAuthorizedRoutes(ctx, routes)
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketRoutes := buildWebSocketRoutes(o.webSockets, a.webSocketConns)
+
+	rateLimited := a.globalRateLimit != nil || a.authRateLimit != nil
+	deprecated := false
+	for _, route := range authorizedRoutes {
+		if route.authz.deprecated || !route.authz.sunset.IsZero() {
+			deprecated = true
+		}
+	}
+	for _, route := range slices.Concat(authorizedRoutes, webSocketRoutes) {
+		if route.authz.rateLimit == nil {
+			continue
+		}
+		if err := route.authz.rateLimit.validate(); err != nil {
+			return nil, fmt.Errorf("%w for route %s %s", err, route.op.method, prefix+route.op.path)
+		}
+		rateLimited = true
+	}
+
+	decorators := []specDecorator{streamResponses, problemContentType, contentTypes(authorizedRoutes, a.maxBodyBytes), validationResponses(authorizedRoutes)}
+	if len(a.authenticators) > 0 {
+		decorators = append(decorators, securitySchemes(a.authenticators))
+	}
+	if len(authorizedRoutes) > 0 {
+		decorators = append(decorators, securityRequirements(authorizedRoutes, a.authenticators))
+	}
+	if rateLimited {
+		decorators = append(decorators, rateLimitResponses(authorizedRoutes, a.authRateLimit, a.globalRateLimit))
+	}
+	decorators = append(decorators, extra...)
+	if deprecated {
+		decorators = append(decorators, deprecations(authorizedRoutes))
+	}
+	h, err = decorateSpec(specPathOf(o), h, decorators...)
+	if err != nil {
+		return nil, err
 	}
 
-	inner := bedrockrest.Build(apiOpts...)
-	return bedrock.Map(inner, func(ctx context.Context, h http.Handler) (http.Handler, error) {
-		return otelhttp.NewHandler(h, "rest"), nil
+	return routesHandler(slices.Concat(authorizedRoutes, webSocketRoutes), a.authenticators, a.limiter, a.maxBodyBytes, prefix, h)
+}
+
+// buildServerTLSConfig returns a memoized builder for the TLS config shared by
+// the HTTPS and HTTP/3 servers. Certificates are served by the ACME manager,
+// if one is configured.
+func buildServerTLSConfig(o *options, acmeManagerB bedrock.Builder[*autocert.Manager]) bedrock.Builder[*tls.Config] {
+	tlsConfig := withClientAuth(withACME(acmeManagerB, o.tlsConfig), o.clientCAFile, o.clientAuth)
+
+	return bedrock.MemoizeBuilder(bedrock.BuilderFunc[*tls.Config](func(ctx context.Context) (*tls.Config, error) {
+		return bedrockconfig.Read(ctx, tlsConfig)
+	}))
+}
+
+// buildListener constructs the TCP listener, wrapped with TLS unless disabled.
+func buildListener(o *options, tlsConfigB bedrock.Builder[*tls.Config]) bedrock.Builder[net.Listener] {
+	tcpListenerB := bedrockhttp.BuildTCPListener(tcpAddr(o.port))
+	tlsListenerB := bedrockhttp.BuildTLSListener(
+		tcpListenerB,
+		bedrockconfig.ReaderFunc[*tls.Config](func(ctx context.Context) (bedrockconfig.Value[*tls.Config], error) {
+			cfg, err := tlsConfigB.Build(ctx)
+			if err != nil {
+				return bedrockconfig.Value[*tls.Config]{}, err
+			}
+			return bedrockconfig.ValueOf(cfg), nil
+		}),
+	)
+
+	return bedrock.BuilderFunc[net.Listener](func(ctx context.Context) (net.Listener, error) {
+		tlsDisabled, err := bedrockconfig.Read(ctx, o.tlsDisabled)
+		if err != nil {
+			return nil, err
+		}
+		if tlsDisabled {
+			return tcpListenerB.Build(ctx)
+		}
+		return tlsListenerB.Build(ctx)
 	})
 }
 
-// buildListener constructs the TLS-wrapped TCP listener.
-func buildListener(o *options) bedrock.Builder[net.Listener] {
-	addrReader := bedrockconfig.Map(o.port, func(_ context.Context, port int) (*net.TCPAddr, error) {
+// tcpAddr maps a port to the TCP address to listen on.
+func tcpAddr(port bedrockconfig.Reader[int]) bedrockconfig.Reader[*net.TCPAddr] {
+	return bedrockconfig.Map(port, func(_ context.Context, port int) (*net.TCPAddr, error) {
 		addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%d", port))
 		if err != nil {
 			return nil, err
 		}
 		return addr, nil
 	})
-
-	tcpListenerB := bedrockhttp.BuildTCPListener(addrReader)
-	return bedrockhttp.BuildTLSListener(tcpListenerB, o.tlsConfig)
 }
 
 // buildOTelProviders builds trace, metric, and log providers using either
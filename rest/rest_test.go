@@ -19,6 +19,7 @@ import (
 	bedrockconfig "github.com/z5labs/bedrock/config"
 	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
 )
 
 type testResponse struct {
@@ -140,6 +141,83 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRun_Insecure(t *testing.T) {
+	t.Run("serves plain HTTP", func(t *testing.T) {
+		port := freePort(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Run(
+				ctx,
+				Port(bedrockconfig.ReaderOf(port)),
+				Insecure(),
+			)
+		}()
+
+		url := fmt.Sprintf("http://localhost:%d/openapi.json", port)
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			var err error
+			resp, err = http.Get(url)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Nil(t, resp.TLS)
+		require.Equal(t, 1, resp.ProtoMajor)
+
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	t.Run("serves HTTP/2 over cleartext when h2c is enabled", func(t *testing.T) {
+		port := freePort(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Run(
+				ctx,
+				Port(bedrockconfig.ReaderOf(port)),
+				Insecure(),
+				H2C(),
+			)
+		}()
+
+		client := &http.Client{
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, network, addr)
+				},
+			},
+		}
+		url := fmt.Sprintf("http://localhost:%d/openapi.json", port)
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			var err error
+			resp, err = client.Get(url)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 2, resp.ProtoMajor)
+
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+}
+
 func TestBuildComponents(t *testing.T) {
 	t.Run("buildHandler", func(t *testing.T) {
 		o := defaultOptions()
@@ -150,10 +228,22 @@ func TestBuildComponents(t *testing.T) {
 	t.Run("buildListener", func(t *testing.T) {
 		o := defaultOptions()
 		o.port = bedrockconfig.ReaderOf(freePort(t))
-		_, err := buildListener(o).Build(context.Background())
+		_, err := buildListener(o, buildServerTLSConfig(o, buildACMEManager(o))).Build(context.Background())
 		require.NoError(t, err)
 	})
 
+	t.Run("buildListener without TLS", func(t *testing.T) {
+		t.Setenv("HUMUS_REST_TLS_DISABLED", "true")
+
+		o := defaultOptions()
+		o.port = bedrockconfig.ReaderOf(freePort(t))
+		ls, err := buildListener(o, buildServerTLSConfig(o, buildACMEManager(o))).Build(context.Background())
+		require.NoError(t, err)
+		defer ls.Close()
+
+		require.IsType(t, &net.TCPListener{}, ls)
+	})
+
 	t.Run("buildRuntime", func(t *testing.T) {
 		o := defaultOptions()
 		o.port = bedrockconfig.ReaderOf(freePort(t))
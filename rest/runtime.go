@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+
+	"github.com/sourcegraph/conc/pool"
+	"github.com/z5labs/bedrock"
+	bedrockhttp "github.com/z5labs/bedrock/runtime/http"
+)
+
+// serverRuntime runs the REST server along with any auxiliary servers, e.g.
+// the ACME HTTP-01 challenge server. If one of them fails, the others are
+// shut down.
+type serverRuntime struct {
+	runtimes []bedrock.Runtime
+}
+
+// Run runs every server until ctx is cancelled or one of them fails.
+func (s serverRuntime) Run(ctx context.Context) error {
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	for _, rt := range s.runtimes {
+		p.Go(rt.Run)
+	}
+	return p.Wait()
+}
+
+// buildServerRuntime combines the main HTTP runtime with the auxiliary
+// runtimes. Auxiliary builders may build a nil runtime if they are not
+// configured, in which case they are skipped.
+func buildServerRuntime(httpRuntimeB bedrock.Builder[bedrockhttp.Runtime], auxiliary ...bedrock.Builder[bedrock.Runtime]) bedrock.Builder[serverRuntime] {
+	return bedrock.BuilderFunc[serverRuntime](func(ctx context.Context) (serverRuntime, error) {
+		httpRuntime, err := httpRuntimeB.Build(ctx)
+		if err != nil {
+			return serverRuntime{}, err
+		}
+
+		s := serverRuntime{runtimes: []bedrock.Runtime{httpRuntime}}
+		for _, b := range auxiliary {
+			rt, err := b.Build(ctx)
+			if err != nil {
+				return serverRuntime{}, err
+			}
+			if rt != nil {
+				s.runtimes = append(s.runtimes, rt)
+			}
+		}
+		return s, nil
+	})
+}
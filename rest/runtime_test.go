@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/z5labs/bedrock"
+)
+
+func TestServerRuntime(t *testing.T) {
+	t.Run("stops every server when one fails", func(t *testing.T) {
+		failure := errors.New("listener closed")
+
+		stopped := make(chan struct{})
+		s := serverRuntime{runtimes: []bedrock.Runtime{
+			bedrock.RuntimeFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				close(stopped)
+				return nil
+			}),
+			bedrock.RuntimeFunc(func(ctx context.Context) error {
+				return failure
+			}),
+		}}
+
+		err := s.Run(context.Background())
+		require.ErrorIs(t, err, failure)
+		require.Eventually(t, func() bool {
+			select {
+			case <-stopped:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("returns nil once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s := serverRuntime{runtimes: []bedrock.Runtime{
+			bedrock.RuntimeFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			}),
+		}}
+		require.NoError(t, s.Run(ctx))
+	})
+}
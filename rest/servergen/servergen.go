@@ -0,0 +1,555 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package servergen generates server stubs of a REST API from its OpenAPI
+// spec, for teams which write the spec first.
+//
+// Every operation becomes a [github.com/z5labs/bedrock/runtime/http/rest.Route]
+// constructor in the style of hand-written humus endpoints, along with a
+// request struct holding its parameters and JSON body and a handler
+// interface with a single method implementing the operation:
+//
+//	type GetCartHandler interface {
+//	    GetCart(ctx context.Context, req GetCartRequest) (Cart, error)
+//	}
+//
+//	func GetCart(h GetCartHandler, reg rest.ErrorRegistry) bedrockrest.Route
+//
+// Parameters are declared once as bedrock params along with their
+// constraints, and schemas are generated as Go types along with the
+// validation tags of humus, so the served spec matches the source spec.
+// The Handler interface embeds the handler interfaces of all operations and
+// Options returns the options of a REST API serving every operation.
+// Regenerating the stubs after the spec changes turns any drift between the
+// spec and the implementation into a compile error.
+//
+// The restservergen command wraps [Generate] and is meant to be run by go
+// generate:
+//
+//	//go:generate go run github.com/z5labs/humus/cmd/restservergen -spec api.yaml -package cartapi -o api.go
+package servergen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/z5labs/humus/internal/openapi"
+)
+
+type options struct {
+	pkg string
+}
+
+// Option configures [Generate].
+type Option func(*options)
+
+// Package sets the name of the package of the generated code. It defaults to
+// "api".
+func Package(name string) Option {
+	return func(o *options) {
+		o.pkg = name
+	}
+}
+
+// Generate returns the formatted source of the server stubs of the API
+// described by the OpenAPI 3.x spec, in JSON or YAML. Operations which
+// cannot be served by bedrock, e.g. HEAD operations, file uploads or
+// operations with cookie parameters, are skipped.
+func Generate(spec []byte, opts ...Option) ([]byte, error) {
+	o := &options{pkg: "api"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !token.IsIdentifier(o.pkg) {
+		return nil, fmt.Errorf("servergen: invalid package name %q", o.pkg)
+	}
+
+	doc, err := openapi.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := []string{"Handler", "Options"}
+	var ops []operation
+	var skipped []string
+	for _, e := range doc.Endpoints() {
+		name := operationName(e)
+		if !supported(e) {
+			skipped = append(skipped, name)
+			continue
+		}
+		ops = append(ops, operation{Endpoint: e, name: name})
+		reserved = append(reserved, name, name+"Request", name+"Handler")
+	}
+
+	g := &generator{
+		doc:       doc,
+		types:     openapi.NewGoTypes(doc, reserved...),
+		imports:   make(map[string]bool),
+		paramVars: make(map[string]string),
+		varExprs:  make(map[string]string),
+	}
+	for _, op := range ops {
+		g.operation(op)
+	}
+	return g.source(o.pkg, ops, skipped)
+}
+
+// operation is a supported operation along with its Go name.
+type operation struct {
+	openapi.Endpoint
+	name string
+}
+
+type generator struct {
+	doc     *openapi.Document
+	types   *openapi.GoTypes
+	imports map[string]bool
+	ops     strings.Builder
+
+	paramVars map[string]string // param declaration to var name
+	varExprs  map[string]string // var name to param declaration
+}
+
+func (g *generator) source(pkg string, ops []operation, skipped []string) ([]byte, error) {
+	var b strings.Builder
+	title := g.doc.Info.Title
+	if title == "" {
+		title = "REST"
+	}
+	if !strings.HasSuffix(title, "API") {
+		title += " API"
+	}
+	fmt.Fprintf(&b, "// Code generated by servergen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "// Package %s implements the routes of the %s.\n", pkg, title)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	imports := slices.Concat(slices.Collect(maps.Keys(g.imports)), g.types.Imports())
+	slices.Sort(imports)
+	imports = slices.Compact(imports)
+	b.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString("\n\tbedrockrest \"github.com/z5labs/bedrock/runtime/http/rest\"\n")
+	b.WriteString("\t\"github.com/z5labs/humus/rest\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// Handler handles every operation of the %s.\n", title)
+	if len(skipped) > 0 {
+		b.WriteString("//\n" + openapi.Comment("The following operations are not supported and lack a handler: "+strings.Join(skipped, ", ")+"."))
+	}
+	b.WriteString("type Handler interface {\n")
+	for _, op := range ops {
+		fmt.Fprintf(&b, "\t%sHandler\n", op.name)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "// Options returns the options of a REST API serving every operation of the\n// %s with h. Errors returned by h are mapped to problems with reg.\n", title)
+	b.WriteString("func Options(h Handler, reg rest.ErrorRegistry) []rest.Option {\n\treturn []rest.Option{\n")
+	if g.doc.Info.Title != "" {
+		fmt.Fprintf(&b, "\t\trest.Title(%q),\n", g.doc.Info.Title)
+	}
+	if g.doc.Info.Version != "" {
+		fmt.Fprintf(&b, "\t\trest.Version(%q),\n", g.doc.Info.Version)
+	}
+	if desc := strings.TrimSpace(g.doc.Info.Description); desc != "" {
+		fmt.Fprintf(&b, "\t\trest.APIDescription(%q),\n", desc)
+	}
+	for _, op := range ops {
+		fmt.Fprintf(&b, "\t\trest.Handle(%s(h, reg)),\n", op.name)
+	}
+	b.WriteString("\t}\n}\n\n")
+
+	b.WriteString(g.ops.String())
+
+	if len(g.varExprs) > 0 {
+		b.WriteString("var (\n")
+		for _, name := range slices.Sorted(maps.Keys(g.varExprs)) {
+			fmt.Fprintf(&b, "\t%s = %s\n", name, g.varExprs[name])
+		}
+		b.WriteString(")\n\n")
+	}
+	for _, decl := range g.types.Decls() {
+		b.WriteString(decl + "\n")
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("servergen: failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// param is a parameter of an operation along with its field and var.
+type param struct {
+	*openapi.Parameter
+	field string
+	typ   string
+	ident string
+}
+
+func (g *generator) operation(op operation) {
+	fields := map[string]bool{"Body": true}
+	var params []param
+	for _, p := range op.Parameters {
+		field := openapi.GoName(p.Name)
+		for i := 2; fields[field]; i++ {
+			field = openapi.GoName(p.Name) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		typ := paramType(g.schema(p.Schema))
+		params = append(params, param{Parameter: p, field: field, typ: typ, ident: g.paramVar(p, typ)})
+	}
+
+	bodyType := ""
+	if s := op.JSONRequestBody(); s != nil {
+		bodyType = g.types.GoType(s, op.name+"Body")
+	}
+	code, schema := op.SuccessResponse()
+	respType := ""
+	if code != "" && len(op.Responses[code].Content) > 0 {
+		respType = g.types.GoType(schema, op.name+"Response")
+	}
+
+	g.imports["context"] = true
+	reqType := op.name + "Request"
+	handlerType := op.name + "Handler"
+	b := &g.ops
+
+	fmt.Fprintf(b, "// %s is the request of %s.\ntype %s struct {\n", reqType, op.name, reqType)
+	for _, p := range params {
+		if p.Description != "" {
+			b.WriteString(indent(openapi.Comment(p.Description)))
+		}
+		fmt.Fprintf(b, "\t%s %s\n", p.field, p.typ)
+	}
+	if bodyType != "" {
+		fmt.Fprintf(b, "\tBody %s\n", bodyType)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "// %s handles %s %s.\ntype %s interface {\n", handlerType, op.Method, op.Path, handlerType)
+	fmt.Fprintf(b, "\t// %s handles %s %s.\n", op.name, op.Method, op.Path)
+	if op.Summary != "" {
+		b.WriteString("\t//\n" + indent(openapi.Comment(op.Summary)))
+	}
+	if op.Description != "" && op.Description != op.Summary {
+		b.WriteString("\t//\n" + indent(openapi.Comment(op.Description)))
+	}
+	results := "error"
+	if respType != "" {
+		results = "(" + respType + ", error)"
+	}
+	fmt.Fprintf(b, "\t%s(ctx context.Context, req %s) %s\n}\n\n", op.name, reqType, results)
+
+	fmt.Fprintf(b, "// %s returns a Route for %s %s, which is handled by h.\n", op.name, op.Method, op.Path)
+	b.WriteString("// Errors returned by h are mapped to problems with reg.\n")
+	fmt.Fprintf(b, "func %s(h %s, reg rest.ErrorRegistry) bedrockrest.Route {\n", op.name, handlerType)
+
+	reqBody := "bedrockrest.EmptyBody"
+	if bodyType != "" {
+		reqBody = bodyType
+	}
+	handlerResp := respType
+	if respType == "" {
+		g.imports["io"] = true
+		handlerResp = "io.Reader"
+	}
+	fmt.Fprintf(b, "\tep := bedrockrest.%s(%q, func(ctx context.Context, req bedrockrest.Request[%s]) (%s, error) {\n", op.Method, op.Path, reqBody, handlerResp)
+	call := fmt.Sprintf("h.%s(ctx, %s{", op.name, reqType)
+	if len(params) > 0 || bodyType != "" {
+		call += "\n"
+		for _, p := range params {
+			call += fmt.Sprintf("\t\t\t%s: bedrockrest.ParamFrom(req, %s),\n", p.field, p.ident)
+		}
+		if bodyType != "" {
+			call += "\t\t\tBody: req.Body(),\n"
+		}
+		call += "\t\t"
+	}
+	call += "})"
+	if respType == "" {
+		g.imports["net/http"] = true
+		fmt.Fprintf(b, "\t\treturn http.NoBody, %s\n\t})\n", call)
+	} else {
+		fmt.Fprintf(b, "\t\treturn %s\n\t})\n", call)
+	}
+
+	for _, p := range params {
+		fmt.Fprintf(b, "\tep = %s.Read(ep)\n", p.ident)
+	}
+	if bodyType != "" {
+		fmt.Fprintf(b, "\tep = bedrockrest.ReadJSON[%s](ep)\n", bodyType)
+	}
+	if op.OperationID != "" {
+		fmt.Fprintf(b, "\tep = bedrockrest.OperationID(%q, ep)\n", op.OperationID)
+	}
+	if op.Summary != "" {
+		fmt.Fprintf(b, "\tep = bedrockrest.Summary(%q, ep)\n", strings.TrimSpace(op.Summary))
+	}
+	if desc := strings.TrimSpace(op.Description); desc != "" {
+		fmt.Fprintf(b, "\tep = bedrockrest.EndpointDescription(%q, ep)\n", desc)
+	}
+	if len(op.Tags) > 0 {
+		tags := make([]string, len(op.Tags))
+		for i, tag := range op.Tags {
+			tags[i] = strconv.Quote(tag)
+		}
+		fmt.Fprintf(b, "\tep = bedrockrest.Tags([]string{%s}, ep)\n", strings.Join(tags, ", "))
+	}
+	if op.Deprecated {
+		b.WriteString("\tep = bedrockrest.MarkDeprecated(ep)\n")
+	}
+	status := g.statusConst(successStatus(code))
+	if respType == "" {
+		fmt.Fprintf(b, "\tep = bedrockrest.WriteBinary(%s, \"\", ep)\n", status)
+	} else {
+		fmt.Fprintf(b, "\tep = bedrockrest.WriteJSON[%s](%s, ep)\n", respType, status)
+	}
+	for _, status := range errorStatuses(op.Endpoint) {
+		fmt.Fprintf(b, "\tep = bedrockrest.ErrorJSON[rest.Problem](%s, ep)\n", g.statusConst(status))
+	}
+	b.WriteString("\treturn rest.CatchProblems(reg, ep)\n}\n\n")
+}
+
+// paramVar declares the bedrock param of a parameter and returns the name
+// of its var. Parameters shared by operations are declared once.
+func (g *generator) paramVar(p *openapi.Parameter, typ string) string {
+	expr := g.paramExpr(p, typ)
+	if name, ok := g.paramVars[expr]; ok {
+		return name
+	}
+	base := varName(openapi.GoName(p.Name)) + "Param"
+	name := base
+	for i := 2; g.varExprs[name] != ""; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.paramVars[expr] = name
+	g.varExprs[name] = expr
+	return name
+}
+
+// paramExpr returns the declaration of the bedrock param of a parameter.
+func (g *generator) paramExpr(p *openapi.Parameter, typ string) string {
+	var opts []string
+	if p.Required && p.In != "path" {
+		opts = append(opts, "bedrockrest.Required()")
+	}
+	if desc := strings.Join(strings.Fields(p.Description), " "); desc != "" {
+		opts = append(opts, fmt.Sprintf("bedrockrest.ParamDescription(%q)", desc))
+	}
+	if s := g.schema(p.Schema); s != nil {
+		number := func(opt string, v *float64) {
+			if v != nil {
+				opts = append(opts, fmt.Sprintf("bedrockrest.%s(%s)", opt, strconv.FormatFloat(*v, 'g', -1, 64)))
+			}
+		}
+		number("Minimum", s.Minimum)
+		number("Maximum", s.Maximum)
+		if s.MinLength != nil {
+			opts = append(opts, fmt.Sprintf("bedrockrest.MinLength(%d)", *s.MinLength))
+		}
+		if s.MaxLength != nil {
+			opts = append(opts, fmt.Sprintf("bedrockrest.MaxLength(%d)", *s.MaxLength))
+		}
+		if s.Pattern != "" {
+			opts = append(opts, fmt.Sprintf("bedrockrest.Pattern(%q)", s.Pattern))
+		}
+		var enum []string
+		for _, v := range s.Enum {
+			if lit, ok := literal(typ, v); ok {
+				enum = append(enum, lit)
+			}
+		}
+		if len(enum) > 0 {
+			opts = append(opts, fmt.Sprintf("bedrockrest.Enum[%s](%s)", typ, strings.Join(enum, ", ")))
+		}
+		if lit, ok := literal(typ, s.Default); ok {
+			opts = append(opts, fmt.Sprintf("bedrockrest.DefaultValue[%s](%s)", typ, lit))
+		}
+	}
+
+	kind := map[string]string{"path": "PathParam", "query": "QueryParam", "header": "HeaderParam"}[p.In]
+	if len(opts) == 0 {
+		return fmt.Sprintf("bedrockrest.%s[%s](%q)", kind, typ, p.Name)
+	}
+	return fmt.Sprintf("bedrockrest.%s[%s](%q,\n\t\t%s,\n\t)", kind, typ, p.Name, strings.Join(opts, ",\n\t\t"))
+}
+
+// schema resolves a reference to a component schema.
+func (g *generator) schema(s *openapi.Schema) *openapi.Schema {
+	if s == nil {
+		return nil
+	}
+	if ref, ok := openapi.SchemaRef(s.Ref); ok {
+		return g.doc.Components.Schemas[ref]
+	}
+	return s
+}
+
+// supported reports whether bedrock can serve the operation.
+func supported(e openapi.Endpoint) bool {
+	switch e.Method {
+	case http.MethodGet, http.MethodDelete:
+		if e.RequestBody != nil {
+			return false
+		}
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		if e.RequestBody != nil && e.JSONRequestBody() == nil {
+			return false
+		}
+	default:
+		return false
+	}
+	for _, p := range e.Parameters {
+		if p.In != "path" && p.In != "query" && p.In != "header" {
+			return false
+		}
+	}
+	if code, s := e.SuccessResponse(); code != "" && s == nil && len(e.Responses[code].Content) > 0 {
+		if _, ok := e.Responses[code].Content["application/json"]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// paramType returns the Go type of a parameter, limited to the types
+// supported by bedrock params.
+func paramType(s *openapi.Schema) string {
+	switch {
+	case s == nil:
+		return "string"
+	case s.Type.Has("integer"):
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case s.Type.Has("number"):
+		return "float64"
+	case s.Type.Has("boolean"):
+		return "bool"
+	}
+	return "string"
+}
+
+// literal returns the Go literal of a value of a parameter of type typ.
+func literal(typ string, v any) (string, bool) {
+	switch typ {
+	case "string":
+		s, ok := v.(string)
+		return strconv.Quote(s), ok
+	case "bool":
+		b, ok := v.(bool)
+		return strconv.FormatBool(b), ok
+	case "int", "int64":
+		f, ok := v.(float64)
+		if !ok || f != math.Trunc(f) {
+			return "", false
+		}
+		return strconv.FormatInt(int64(f), 10), true
+	case "float64":
+		f, ok := v.(float64)
+		return strconv.FormatFloat(f, 'g', -1, 64), ok
+	}
+	return "", false
+}
+
+// successStatus returns the status code of the successful response.
+func successStatus(code string) int {
+	status, err := strconv.Atoi(code)
+	if err != nil {
+		return http.StatusOK
+	}
+	return status
+}
+
+// errorStatuses returns the status codes of the error responses of an
+// operation, which are documented as problems.
+func errorStatuses(e openapi.Endpoint) []int {
+	var statuses []int
+	for code := range e.Responses {
+		status, err := strconv.Atoi(code)
+		if err == nil && status >= http.StatusBadRequest {
+			statuses = append(statuses, status)
+		}
+	}
+	slices.Sort(statuses)
+	return statuses
+}
+
+var statusConsts = map[int]string{
+	http.StatusOK:                  "http.StatusOK",
+	http.StatusCreated:             "http.StatusCreated",
+	http.StatusAccepted:            "http.StatusAccepted",
+	http.StatusNoContent:           "http.StatusNoContent",
+	http.StatusBadRequest:          "http.StatusBadRequest",
+	http.StatusUnauthorized:        "http.StatusUnauthorized",
+	http.StatusForbidden:           "http.StatusForbidden",
+	http.StatusNotFound:            "http.StatusNotFound",
+	http.StatusMethodNotAllowed:    "http.StatusMethodNotAllowed",
+	http.StatusConflict:            "http.StatusConflict",
+	http.StatusGone:                "http.StatusGone",
+	http.StatusPreconditionFailed:  "http.StatusPreconditionFailed",
+	http.StatusUnprocessableEntity: "http.StatusUnprocessableEntity",
+	http.StatusTooManyRequests:     "http.StatusTooManyRequests",
+	http.StatusInternalServerError: "http.StatusInternalServerError",
+	http.StatusNotImplemented:      "http.StatusNotImplemented",
+	http.StatusBadGateway:          "http.StatusBadGateway",
+	http.StatusServiceUnavailable:  "http.StatusServiceUnavailable",
+	http.StatusGatewayTimeout:      "http.StatusGatewayTimeout",
+}
+
+func (g *generator) statusConst(status int) string {
+	if c, ok := statusConsts[status]; ok {
+		g.imports["net/http"] = true
+		return c
+	}
+	return strconv.Itoa(status)
+}
+
+// operationName returns the Go name of an operation, which is derived from
+// the method and path of operations without an operation ID.
+func operationName(e openapi.Endpoint) string {
+	if e.OperationID != "" {
+		return openapi.GoName(e.OperationID)
+	}
+	return openapi.GoName(strings.ToLower(e.Method) + " " + e.Path)
+}
+
+// varName returns the unexported form of an exported Go name, lowering a
+// leading initialism as a whole, e.g. "cartID" and "urlPath".
+func varName(name string) string {
+	r := []rune(name)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) && unicode.IsLower(r[n]) {
+		n--
+	}
+	for i := range n {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// indent indents a comment for a member of a struct or interface.
+func indent(comment string) string {
+	var b strings.Builder
+	for line := range strings.SplitSeq(strings.TrimSuffix(comment, "\n"), "\n") {
+		b.WriteString("\t" + line + "\n")
+	}
+	return b.String()
+}
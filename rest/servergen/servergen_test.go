@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package servergen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const petSpec = `
+openapi: 3.0.3
+info:
+  title: Pet Store
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          description: Maximum number of pets
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+            default: 20
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [available, sold]
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      deprecated: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '409':
+          description: Conflict
+    head:
+      operationId: checkPets
+      responses:
+        '200':
+          description: OK
+  /pets/{petId}/photo:
+    put:
+      operationId: uploadPhoto
+      requestBody:
+        content:
+          image/png: {}
+      responses:
+        '204':
+          description: No Content
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          format: int64
+    delete:
+      parameters:
+        - name: session
+          in: cookie
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No Content
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '404':
+          description: Not Found
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+`
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(petSpec), Package("petapi"))
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "api.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		"package petapi",
+		"type Handler interface {\n\tListPetsHandler\n\tCreatePetHandler\n\tGetPetsPetIDHandler\n}",
+		"The following operations are not supported and lack a handler: CheckPets, DeletePetsPetID, UploadPhoto.",
+		`rest.Title("Pet Store"),`,
+		"rest.Handle(ListPets(h, reg)),",
+		"ListPets(ctx context.Context, req ListPetsRequest) ([]Pet, error)",
+		"\t// Maximum number of pets\n\tLimit   int\n\tStatus  string\n\tXTenant string\n}",
+		"func ListPets(h ListPetsHandler, reg rest.ErrorRegistry) bedrockrest.Route {",
+		`ep := bedrockrest.GET("/pets", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) ([]Pet, error) {`,
+		"Limit:   bedrockrest.ParamFrom(req, limitParam),",
+		`ep = bedrockrest.Tags([]string{"pets"}, ep)`,
+		"ep = bedrockrest.WriteJSON[[]Pet](http.StatusOK, ep)",
+		"CreatePet(ctx context.Context, req CreatePetRequest) (Pet, error)",
+		"ep = bedrockrest.ReadJSON[Pet](ep)",
+		"ep = bedrockrest.MarkDeprecated(ep)",
+		"ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusConflict, ep)",
+		"GetPetsPetID(ctx context.Context, req GetPetsPetIDRequest) (Pet, error)",
+		"PetID int64",
+		"ep = bedrockrest.ErrorJSON[rest.Problem](http.StatusNotFound, ep)",
+		"return rest.CatchProblems(reg, ep)",
+		"limitParam = bedrockrest.QueryParam[int](\"limit\",\n\t\tbedrockrest.ParamDescription(\"Maximum number of pets\"),\n\t\tbedrockrest.Minimum(1),\n\t\tbedrockrest.Maximum(100),\n\t\tbedrockrest.DefaultValue[int](20),\n\t)",
+		`petIDParam  = bedrockrest.PathParam[int64]("petId")`,
+		"bedrockrest.Enum[string](\"available\", \"sold\"),",
+		"xTenantParam = bedrockrest.HeaderParam[string](\"X-Tenant\",\n\t\tbedrockrest.Required(),\n\t)",
+		"Name string `json:\"name\" required:\"true\" minLength:\"1\"`",
+	} {
+		require.Contains(t, string(src), want)
+	}
+}
+
+func TestGenerate_NoContent(t *testing.T) {
+	src, err := Generate([]byte(`
+openapi: 3.1.0
+info:
+  title: Pet Store API
+paths:
+  /pets/{petId}:
+    delete:
+      operationId: deletePet
+      responses:
+        '204':
+          description: No Content
+`))
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		"package api",
+		"// Handler handles every operation of the Pet Store API.",
+		"DeletePet(ctx context.Context, req DeletePetRequest) error",
+		"return http.NoBody, h.DeletePet(ctx, DeletePetRequest{",
+		`ep = bedrockrest.WriteBinary(http.StatusNoContent, "", ep)`,
+	} {
+		require.Contains(t, string(src), want)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := Generate([]byte(petSpec), Package("pet-api"))
+	require.Error(t, err)
+
+	_, err = Generate([]byte(`swagger: "2.0"`))
+	require.Error(t, err)
+
+	_, err = Generate([]byte(`{`))
+	require.Error(t, err)
+}
+
+func TestVarName(t *testing.T) {
+	require.Equal(t, "cartID", varName("CartID"))
+	require.Equal(t, "id", varName("ID"))
+	require.Equal(t, "urlPath", varName("URLPath"))
+	require.Equal(t, "xTenant", varName("XTenant"))
+}
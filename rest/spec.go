@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	bedrockconfig "github.com/z5labs/bedrock/config"
+)
+
+const defaultSpecPath = "/openapi.json"
+
+// specDecorator amends the generated OpenAPI spec, e.g. with details bedrock
+// has no notion of such as security schemes.
+type specDecorator func(spec map[string]any) error
+
+// Spec returns the OpenAPI spec of the API configured by opts, as served by
+// [Run], without running a server. It allows tools to generate code or
+// documentation from the routes, e.g. a typed client with clientgen.
+func Spec(ctx context.Context, opts ...Option) ([]byte, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.accessLog = bedrockconfig.ReaderOf(false)
+
+	h, err := buildHandler(o).Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, specPathOf(o), nil))
+	if rec.Code != http.StatusOK {
+		return nil, fmt.Errorf("rest: failed to read OpenAPI spec: status %d", rec.Code)
+	}
+	return rec.Body.Bytes(), nil
+}
+
+// specPathOf returns the path the OpenAPI spec is served at.
+func specPathOf(o *options) string {
+	if o.specPath != "" {
+		return o.specPath
+	}
+	return defaultSpecPath
+}
+
+// decorateSpec reads the OpenAPI spec served by next, applies the decorators
+// and serves the result in place of the original spec. It returns next
+// unchanged if there are no decorators.
+func decorateSpec(specPath string, next http.Handler, decorators ...specDecorator) (http.Handler, error) {
+	if len(decorators) == 0 {
+		return next, nil
+	}
+
+	spec, err := readSpec(specPath, next)
+	if err != nil {
+		return nil, err
+	}
+	for _, decorate := range decorators {
+		if err := decorate(spec); err != nil {
+			return nil, err
+		}
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("rest: failed to encode OpenAPI spec: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != specPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(specJSON) //nolint:errcheck
+	}), nil
+}
+
+// readSpec returns the OpenAPI spec served by h at specPath.
+func readSpec(specPath string, h http.Handler) (map[string]any, error) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, specPath, nil))
+	if rec.Code != http.StatusOK {
+		return nil, fmt.Errorf("rest: failed to read OpenAPI spec: status %d", rec.Code)
+	}
+
+	var spec map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		return nil, fmt.Errorf("rest: failed to decode OpenAPI spec: %w", err)
+	}
+	return spec, nil
+}
+
+// specObject returns the object stored under key in m, creating it if missing.
+func specObject(m map[string]any, key string) map[string]any {
+	obj, ok := m[key].(map[string]any)
+	if !ok {
+		obj = make(map[string]any)
+		m[key] = obj
+	}
+	return obj
+}
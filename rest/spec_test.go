@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func TestSpec(t *testing.T) {
+	route := newOrderRoute(func(ctx context.Context, req bedrockrest.Request[testOrder]) (testResponse, error) {
+		return testResponse{}, nil
+	})
+
+	data, err := Spec(context.Background(), Title("Orders"), SpecPath("/spec.json"), Handle(route, Consumes(CBOR())))
+	require.NoError(t, err)
+
+	var spec struct {
+		Info struct {
+			Title string `json:"title"`
+		} `json:"info"`
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]any `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]any `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	require.Equal(t, "Orders", spec.Info.Title)
+
+	op := spec.Paths["/orders"]["post"]
+	require.Contains(t, op.RequestBody.Content, CBORContentType)
+	require.Contains(t, op.Responses, "400")
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+	"github.com/z5labs/humus"
+)
+
+// SSEContentType is the media type of Server-Sent Events streams.
+const SSEContentType = "text/event-stream"
+
+// NDJSONContentType is the media type of newline-delimited JSON streams.
+const NDJSONContentType = "application/x-ndjson"
+
+// streamItemStatus is the status stream endpoints register the schema of
+// their items under, since bedrock only documents streams as binary
+// responses. The schema is moved to the stream response by streamResponses.
+const streamItemStatus = 599
+
+// Event is a Server-Sent Event whose data is the JSON encoding of T.
+type Event[T any] struct {
+	// ID identifies the event. Clients send the ID of the last event they
+	// received in the Last-Event-ID header when they reconnect. Optional.
+	ID string
+
+	// Type is the type of the event, e.g. "price". Events without a type
+	// are dispatched as "message" events by clients.
+	Type string
+
+	// Data is the payload of the event.
+	Data T
+
+	// Retry tells clients how long to wait before reconnecting. Optional.
+	Retry time.Duration
+}
+
+// LastEventID is the Last-Event-ID header clients send when they reconnect to
+// an [SSE] endpoint. Handlers read it with bedrockrest.ParamFrom to resume the
+// stream after the last event the client received.
+var LastEventID = bedrockrest.HeaderParam[string](
+	"Last-Event-ID",
+	bedrockrest.ParamDescription("ID of the last event received before reconnecting."),
+)
+
+// StreamOption configures an [SSE] or [NDJSON] endpoint.
+type StreamOption func(*streamOptions)
+
+type streamOptions struct {
+	heartbeat    time.Duration
+	writeTimeout time.Duration
+}
+
+// Heartbeat sets how long an SSE stream may be idle before a comment is sent
+// to keep proxies and clients from closing the connection. Zero disables
+// heartbeats. The default is 5s, which is below the default
+// [StreamWriteTimeout].
+func Heartbeat(interval time.Duration) StreamOption {
+	return func(so *streamOptions) {
+		so.heartbeat = interval
+	}
+}
+
+// StreamWriteTimeout sets the maximum duration for writing every event or
+// value of a stream. Streams are exempt from the write timeout of the server,
+// which would otherwise close them once it expires, so clients which stop
+// reading are disconnected by this timeout instead. The timeout only applies
+// while writing, so streams may be idle for longer. The default is 10s.
+func StreamWriteTimeout(d time.Duration) StreamOption {
+	return func(so *streamOptions) {
+		so.writeTimeout = d
+	}
+}
+
+func newStreamOptions(opts []StreamOption) streamOptions {
+	so := streamOptions{
+		heartbeat:    5 * time.Second,
+		writeTimeout: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&so)
+	}
+	return so
+}
+
+// SSE creates a GET endpoint which streams Server-Sent Events with data of
+// type T. The handler returns the events to send, or an error which is
+// handled like the error of any other endpoint. The stream ends once the
+// events are exhausted or the client disconnects, which cancels ctx.
+//
+// If the events yield an error, it is logged and the connection is aborted,
+// so clients reconnect. Clients reconnecting after a disconnect send the
+// [LastEventID] header, which the endpoint reads. The events are documented
+// in the OpenAPI spec as text/event-stream responses.
+//
+// Like any other endpoint, the endpoint is completed with error handling,
+// e.g. [CatchProblems]:
+//
+//	ep := rest.SSE("/prices", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[rest.Event[Price], error], error) {
+//	    return prices.Subscribe(ctx, bedrockrest.ParamFrom(req, rest.LastEventID)), nil
+//	})
+//	route := rest.CatchProblems(errs, ep)
+func SSE[T any](pattern string, handler func(context.Context, bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[Event[T], error], error), opts ...StreamOption) bedrockrest.Endpoint {
+	so := newStreamOptions(opts)
+	ep := bedrockrest.GET(pattern, func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (*stream[Event[T]], error) {
+		events, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &stream[Event[T]]{
+			ctx:       ctx,
+			items:     events,
+			encode:    encodeEvent[T],
+			heartbeat: so.heartbeat,
+			timeout:   so.writeTimeout,
+		}, nil
+	})
+	ep = LastEventID.Read(ep)
+	ep = bedrockrest.WriteJSON[T](streamItemStatus, ep)
+	return bedrockrest.WriteBinary(http.StatusOK, SSEContentType, ep)
+}
+
+// NDJSON creates a GET endpoint which streams values of type T as
+// newline-delimited JSON. It behaves like [SSE], except that heartbeats are
+// not sent since NDJSON has no notion of comments.
+func NDJSON[T any](pattern string, handler func(context.Context, bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[T, error], error), opts ...StreamOption) bedrockrest.Endpoint {
+	so := newStreamOptions(opts)
+	ep := bedrockrest.GET(pattern, func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (*stream[T], error) {
+		values, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &stream[T]{
+			ctx:     ctx,
+			items:   values,
+			encode:  encodeLine[T],
+			timeout: so.writeTimeout,
+		}, nil
+	})
+	ep = bedrockrest.WriteJSON[T](streamItemStatus, ep)
+	return bedrockrest.WriteBinary(http.StatusOK, NDJSONContentType, ep)
+}
+
+// encodeEvent encodes an event in the text/event-stream format.
+func encodeEvent[T any](e Event[T]) ([]byte, error) {
+	if strings.ContainsAny(e.ID, "\r\n") || strings.ContainsAny(e.Type, "\r\n") {
+		return nil, errors.New("rest: event ID and type must not contain line breaks")
+	}
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	if e.ID != "" {
+		b = append(b, "id: "+e.ID+"\n"...)
+	}
+	if e.Type != "" {
+		b = append(b, "event: "+e.Type+"\n"...)
+	}
+	if e.Retry > 0 {
+		b = append(b, "retry: "+strconv.FormatInt(e.Retry.Milliseconds(), 10)+"\n"...)
+	}
+	b = append(b, "data: "...)
+	b = append(b, data...)
+	return append(b, "\n\n"...), nil
+}
+
+// encodeLine encodes a value as a line of newline-delimited JSON.
+func encodeLine[T any](v T) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// streamItem is an item yielded by the iterator of a stream.
+type streamItem[T any] struct {
+	v   T
+	err error
+}
+
+// stream is the response of a stream endpoint. It implements [io.WriterTo],
+// so it is written by bedrock's binary response encoder.
+type stream[T any] struct {
+	ctx       context.Context
+	items     iter.Seq2[T, error]
+	encode    func(T) ([]byte, error)
+	heartbeat time.Duration
+	timeout   time.Duration
+}
+
+// Read implements [io.Reader], which bedrock requires of binary responses.
+// Streams are only ever written with WriteTo.
+func (s *stream[T]) Read(p []byte) (int, error) {
+	return 0, errors.ErrUnsupported
+}
+
+// WriteTo writes and flushes every item as soon as it is yielded, sending
+// heartbeats while the stream is idle.
+func (s *stream[T]) WriteTo(w io.Writer) (int64, error) {
+	rw, ok := w.(http.ResponseWriter)
+	if !ok {
+		return 0, errors.New("rest: streams can only be written to a http.ResponseWriter")
+	}
+	rc := http.NewResponseController(rw)
+
+	// The items are produced concurrently, so heartbeats can be sent while
+	// waiting for the next item.
+	items := make(chan streamItem[T])
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(items)
+		for v, err := range s.items {
+			select {
+			case items <- streamItem[T]{v: v, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var heartbeat <-chan time.Time
+	if s.heartbeat > 0 {
+		t := time.NewTicker(s.heartbeat)
+		defer t.Stop()
+		heartbeat = t.C
+	}
+
+	// The deadline is cleared after every write, since HTTP/2 resets the
+	// stream once it expires, even if nothing is being written.
+	var n int64
+	write := func(b []byte) error {
+		rc.SetWriteDeadline(time.Now().Add(s.timeout)) //nolint:errcheck
+		m, err := rw.Write(b)
+		n += int64(m)
+		if err != nil {
+			return err
+		}
+		err = rc.Flush()
+		rc.SetWriteDeadline(time.Time{}) //nolint:errcheck
+		return err
+	}
+
+	// Flush the headers, so clients know the stream has started.
+	if err := write(nil); err != nil {
+		return n, err
+	}
+	for {
+		select {
+		case <-s.ctx.Done():
+			return n, nil
+		case <-heartbeat:
+			if err := write([]byte(": heartbeat\n\n")); err != nil {
+				return n, err
+			}
+		case item, ok := <-items:
+			if !ok {
+				return n, nil
+			}
+			b, err := s.encodeItem(item)
+			if err != nil {
+				log := humus.Logger("github.com/z5labs/humus/rest")
+				log.ErrorContext(s.ctx, "failed to stream response", slog.Any("error", err))
+				panic(http.ErrAbortHandler)
+			}
+			if err := write(b); err != nil {
+				return n, err
+			}
+		}
+	}
+}
+
+func (s *stream[T]) encodeItem(item streamItem[T]) ([]byte, error) {
+	if item.err != nil {
+		return nil, item.err
+	}
+	b, err := s.encode(item.v)
+	if err != nil {
+		return nil, fmt.Errorf("rest: failed to encode stream item: %w", err)
+	}
+	return b, nil
+}
+
+// streamHandler keeps the responses of stream endpoints from being cached,
+// e.g. by proxies, which would hold back the items.
+func streamHandler(op routeOperation, next http.Handler) http.Handler {
+	if !isStream(op.produces) {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isStream reports whether the media types are those of a stream endpoint.
+func isStream(mediaTypes []string) bool {
+	return len(mediaTypes) == 1 && (mediaTypes[0] == SSEContentType || mediaTypes[0] == NDJSONContentType)
+}
+
+// streamResponses documents the items of stream endpoints in the OpenAPI
+// spec. Server-Sent Events are documented as objects with the fields of an
+// event and NDJSON streams with the schema of their values.
+func streamResponses(spec map[string]any) error {
+	code := strconv.Itoa(streamItemStatus)
+	for _, item := range specObject(spec, "paths") {
+		item, _ := item.(map[string]any)
+		for method, op := range item {
+			op, ok := op.(map[string]any)
+			if !ok || !isHTTPMethod(method) {
+				continue
+			}
+			responses, _ := op["responses"].(map[string]any)
+			marker, ok := responses[code].(map[string]any)
+			if !ok {
+				continue
+			}
+			delete(responses, code)
+			content, _ := marker["content"].(map[string]any)
+			jsonMediaType, _ := content["application/json"].(map[string]any)
+			schema := jsonMediaType["schema"]
+
+			for _, resp := range responses {
+				resp, _ := resp.(map[string]any)
+				content, _ := resp["content"].(map[string]any)
+				if _, ok := content[SSEContentType]; ok {
+					resp["description"] = "Stream of Server-Sent Events."
+					content[SSEContentType] = map[string]any{"schema": eventSchema(schema)}
+				}
+				if _, ok := content[NDJSONContentType]; ok {
+					resp["description"] = "Stream of newline-delimited JSON values."
+					content[NDJSONContentType] = map[string]any{"schema": schema}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eventSchema returns the schema of a Server-Sent Event with the given data.
+func eventSchema(data any) map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"data"},
+		"properties": map[string]any{
+			"id":    map[string]any{"type": "string"},
+			"event": map[string]any{"type": "string"},
+			"data":  data,
+			"retry": map[string]any{"type": "integer", "description": "Reconnection time in milliseconds."},
+		},
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func newPriceStream(opts ...StreamOption) bedrockrest.Route {
+	ep := SSE("/prices", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[Event[testResponse], error], error) {
+		start := 0
+		if id := bedrockrest.ParamFrom(req, LastEventID); id != "" {
+			n, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, BadRequest("The Last-Event-ID header must be a number.")
+			}
+			start = n + 1
+		}
+		return func(yield func(Event[testResponse], error) bool) {
+			for i := start; i < 3; i++ {
+				e := Event[testResponse]{ID: strconv.Itoa(i), Type: "price", Data: testResponse{Message: "price " + strconv.Itoa(i)}}
+				if !yield(e, nil) {
+					return
+				}
+			}
+			<-ctx.Done()
+		}, nil
+	}, opts...)
+	return CatchProblems(nil, ep)
+}
+
+func TestSSE(t *testing.T) {
+	srv := httptest.NewServer(newVersionedHandler(t, Handle(newPriceStream(Heartbeat(50*time.Millisecond)))))
+	t.Cleanup(srv.Close)
+
+	readEvents := func(t *testing.T, header http.Header, n int) (*http.Response, []string) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/prices", nil)
+		require.NoError(t, err)
+		req.Header = header
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var frames []string
+		var frame strings.Builder
+		sc := bufio.NewScanner(resp.Body)
+		for len(frames) < n && sc.Scan() {
+			if sc.Text() != "" {
+				frame.WriteString(sc.Text() + "\n")
+				continue
+			}
+			frames = append(frames, frame.String())
+			frame.Reset()
+		}
+		require.NoError(t, sc.Err())
+		return resp, frames
+	}
+
+	t.Run("streams events and heartbeats", func(t *testing.T) {
+		resp, frames := readEvents(t, http.Header{}, 4)
+		require.Equal(t, SSEContentType, resp.Header.Get("Content-Type"))
+		require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+		require.Equal(t, []string{
+			"id: 0\nevent: price\ndata: {\"message\":\"price 0\"}\n",
+			"id: 1\nevent: price\ndata: {\"message\":\"price 1\"}\n",
+			"id: 2\nevent: price\ndata: {\"message\":\"price 2\"}\n",
+			": heartbeat\n",
+		}, frames)
+	})
+
+	t.Run("resumes after the last event ID", func(t *testing.T) {
+		_, frames := readEvents(t, http.Header{"Last-Event-Id": {"1"}}, 1)
+		require.Equal(t, []string{"id: 2\nevent: price\ndata: {\"message\":\"price 2\"}\n"}, frames)
+	})
+
+	t.Run("handles errors before streaming", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/prices", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "abc")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, ProblemContentType, resp.Header.Get("Content-Type"))
+	})
+}
+
+func TestSSE_WriteTimeout(t *testing.T) {
+	h := newVersionedHandler(t, Handle(newPriceStream(Heartbeat(20*time.Millisecond))))
+	srv := httptest.NewUnstartedServer(h)
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/prices")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The stream outlives the write timeout of the server.
+	deadline := time.Now().Add(300 * time.Millisecond)
+	sc := bufio.NewScanner(resp.Body)
+	for time.Now().Before(deadline) {
+		require.True(t, sc.Scan(), "stream closed early: %v", sc.Err())
+	}
+}
+
+func TestStream_HTTP2(t *testing.T) {
+	route := CatchProblems(nil, NDJSON("/export", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[testResponse, error], error) {
+		return func(yield func(testResponse, error) bool) {
+			if !yield(testResponse{Message: "a"}, nil) {
+				return
+			}
+			// Stay idle for longer than the write timeout.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(300 * time.Millisecond):
+			}
+			yield(testResponse{Message: "b"}, nil)
+		}, nil
+	}, StreamWriteTimeout(100*time.Millisecond)))
+	srv := httptest.NewUnstartedServer(newVersionedHandler(t, Handle(route)))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	resp, err := srv.Client().Get(srv.URL + "/export")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 2, resp.ProtoMajor)
+
+	dec := json.NewDecoder(resp.Body)
+	for _, want := range []string{"a", "b"} {
+		var v testResponse
+		require.NoError(t, dec.Decode(&v))
+		require.Equal(t, want, v.Message)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	fail := errors.New("database unavailable")
+	route := CatchProblems(nil, NDJSON("/export", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[testResponse, error], error) {
+		return func(yield func(testResponse, error) bool) {
+			for _, msg := range []string{"a", "b"} {
+				if !yield(testResponse{Message: msg}, nil) {
+					return
+				}
+			}
+			yield(testResponse{}, fail)
+		}, nil
+	}))
+	srv := httptest.NewServer(newVersionedHandler(t, Handle(route)))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/export")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, NDJSONContentType, resp.Header.Get("Content-Type"))
+
+	dec := json.NewDecoder(resp.Body)
+	for _, want := range []string{"a", "b"} {
+		var v testResponse
+		require.NoError(t, dec.Decode(&v))
+		require.Equal(t, want, v.Message)
+	}
+
+	// The connection is aborted on errors, so the stream is incomplete.
+	_, err = io.ReadAll(dec.Buffered())
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.Error(t, err)
+}
+
+func TestStreamResponses(t *testing.T) {
+	export := NDJSON("/export", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (iter.Seq2[testResponse, error], error) {
+		return nil, nil
+	})
+	spec, err := Spec(context.Background(),
+		Handle(newPriceStream()),
+		Handle(CatchProblems(nil, export)),
+	)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(spec, &doc))
+	paths := doc["paths"].(map[string]any)
+
+	op := paths["/prices"].(map[string]any)["get"].(map[string]any)
+	responses := op["responses"].(map[string]any)
+	require.NotContains(t, responses, strconv.Itoa(streamItemStatus))
+	schema := responses["200"].(map[string]any)["content"].(map[string]any)[SSEContentType].(map[string]any)["schema"].(map[string]any)
+	require.Equal(t, "object", schema["type"])
+	require.Contains(t, schema["properties"].(map[string]any)["data"], "$ref")
+	require.Contains(t, op["parameters"], map[string]any{
+		"name":        "Last-Event-ID",
+		"in":          "header",
+		"required":    false,
+		"description": "ID of the last event received before reconnecting.",
+		"schema":      map[string]any{"type": "string"},
+	})
+
+	op = paths["/export"].(map[string]any)["get"].(map[string]any)
+	responses = op["responses"].(map[string]any)
+	require.NotContains(t, responses, strconv.Itoa(streamItemStatus))
+	schema = responses["200"].(map[string]any)["content"].(map[string]any)[NDJSONContentType].(map[string]any)["schema"].(map[string]any)
+	require.Contains(t, schema, "$ref")
+}
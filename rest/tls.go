@@ -13,22 +13,64 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
 	"math/big"
 	"net"
 	"os"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/z5labs/humus"
+
 	"github.com/z5labs/bedrock/config"
 	"software.sslmate.com/src/go-pkcs12"
 )
 
 // buildTLSConfig returns a config.Reader[*tls.Config] that loads a TLS config
-// from a PKCS#12 file when available, or falls back to generating a self-signed
-// certificate.
-func buildTLSConfig(pkcs12File, pkcs12Password config.Reader[string]) config.Reader[*tls.Config] {
-	fileBased := buildPKCS12TLSConfig(pkcs12File, pkcs12Password)
+// from PEM files or a PKCS#12 file when available, or falls back to generating
+// a self-signed certificate.
+func buildTLSConfig(certFile, keyFile, caFile, pkcs12File, pkcs12Password config.Reader[string]) config.Reader[*tls.Config] {
+	pemBased := buildPEMTLSConfig(certFile, keyFile, caFile)
+	pkcs12Based := buildPKCS12TLSConfig(pkcs12File, pkcs12Password)
 	selfSigned := buildSelfSignedTLSConfig()
-	return config.Or(fileBased, selfSigned)
+	return config.Or(pemBased, pkcs12Based, selfSigned)
+}
+
+// buildPEMTLSConfig returns a config.Reader[*tls.Config] that serves the PEM
+// encoded certificate and private key files, optionally appending the CA
+// certificates from caFile to the served chain. The files are watched for
+// changes so rotated certificates are picked up by new connections without
+// a restart. It returns no value if the cert file path is unset.
+func buildPEMTLSConfig(certFile, keyFile, caFile config.Reader[string]) config.Reader[*tls.Config] {
+	return config.ReaderFunc[*tls.Config](func(ctx context.Context) (config.Value[*tls.Config], error) {
+		certPath, err := config.Read(ctx, certFile)
+		if err != nil {
+			return config.Value[*tls.Config]{}, nil
+		}
+
+		keyPath, err := config.Read(ctx, keyFile)
+		if err != nil {
+			return config.Value[*tls.Config]{}, errors.New("rest: a TLS key file must be set along with the TLS cert file")
+		}
+
+		caPath, err := config.Read(ctx, caFile)
+		if err != nil {
+			caPath = ""
+		}
+
+		reloader, err := newCertReloader(certPath, keyPath, caPath)
+		if err != nil {
+			return config.Value[*tls.Config]{}, err
+		}
+
+		return config.ValueOf(&tls.Config{
+			GetCertificate: reloader.GetCertificate,
+		}), nil
+	})
 }
 
 // buildPKCS12TLSConfig returns a config.Reader[*tls.Config] that reads a PKCS#12
@@ -118,3 +160,148 @@ func buildSelfSignedTLSConfig() config.Reader[*tls.Config] {
 		}), nil
 	})
 }
+
+// certReloadInterval is the minimum time between checks of the PEM files for changes.
+const certReloadInterval = 10 * time.Second
+
+// certReloader serves a certificate loaded from PEM files and reloads it
+// whenever the files change. Changes are detected lazily during handshakes,
+// at most once per interval, by comparing file modification times and sizes.
+type certReloader struct {
+	log      *slog.Logger
+	paths    []string
+	interval time.Duration
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	stats     []fileStat
+	lastCheck time.Time
+}
+
+type fileStat struct {
+	modTime time.Time
+	size    int64
+}
+
+func newCertReloader(certFile, keyFile, caFile string) (*certReloader, error) {
+	paths := []string{certFile, keyFile}
+	if caFile != "" {
+		paths = append(paths, caFile)
+	}
+
+	r := &certReloader{
+		log:      humus.Logger("github.com/z5labs/humus/rest"),
+		paths:    paths,
+		interval: certReloadInterval,
+	}
+
+	stats, err := r.statFiles()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := loadPEMCertificate(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cert = cert
+	r.stats = stats
+	r.lastCheck = time.Now()
+	return r, nil
+}
+
+// GetCertificate implements the tls.Config.GetCertificate callback.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.maybeReload()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) maybeReload() {
+	r.mu.RLock()
+	due := time.Since(r.lastCheck) >= r.interval
+	r.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Another handshake may have already checked while waiting for the lock.
+	if time.Since(r.lastCheck) < r.interval {
+		return
+	}
+	r.lastCheck = time.Now()
+
+	stats, err := r.statFiles()
+	if err != nil {
+		r.log.Warn("failed to check TLS certificate files for changes", slog.Any("error", err))
+		return
+	}
+	if slices.Equal(stats, r.stats) {
+		return
+	}
+
+	caFile := ""
+	if len(r.paths) > 2 {
+		caFile = r.paths[2]
+	}
+	cert, err := loadPEMCertificate(r.paths[0], r.paths[1], caFile)
+	if err != nil {
+		// The files may be mid-rotation, e.g. the cert has been replaced but the
+		// key has not yet. Keep serving the current certificate and retry later.
+		r.log.Warn("failed to reload TLS certificate", slog.Any("error", err))
+		return
+	}
+
+	r.cert = cert
+	r.stats = stats
+	r.log.Info("reloaded TLS certificate")
+}
+
+func (r *certReloader) statFiles() ([]fileStat, error) {
+	stats := make([]fileStat, 0, len(r.paths))
+	for _, path := range r.paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, fileStat{modTime: fi.ModTime(), size: fi.Size()})
+	}
+	return stats, nil
+}
+
+// loadPEMCertificate loads a PEM encoded certificate and private key, appending
+// any CA certificates from caFile to the certificate chain.
+func loadPEMCertificate(certFile, keyFile, caFile string) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if caFile == "" {
+		return &cert, nil
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var block *pem.Block
+		block, caPEM = pem.Decode(caPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert.Certificate = append(cert.Certificate, block.Bytes)
+	}
+	if len(cert.Certificate) == 1 {
+		return nil, fmt.Errorf("rest: no CA certificates found in %s", caFile)
+	}
+	return &cert, nil
+}
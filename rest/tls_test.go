@@ -9,11 +9,14 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	bedrockconfig "github.com/z5labs/bedrock/config"
 	"github.com/stretchr/testify/require"
@@ -109,6 +112,9 @@ func TestBuildTLSConfig(t *testing.T) {
 		val, err := buildTLSConfig(
 			bedrockconfig.EmptyReader[string](),
 			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
 		).Read(context.Background())
 		require.NoError(t, err)
 
@@ -123,6 +129,9 @@ func TestBuildTLSConfig(t *testing.T) {
 		pkcs12File := writeTempPKCS12(t, password)
 
 		val, err := buildTLSConfig(
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
 			bedrockconfig.ReaderOf(pkcs12File),
 			bedrockconfig.ReaderOf(password),
 		).Read(context.Background())
@@ -133,6 +142,157 @@ func TestBuildTLSConfig(t *testing.T) {
 		require.NotNil(t, cfg)
 		require.Len(t, cfg.Certificates, 1)
 	})
+
+	t.Run("prefers PEM files over PKCS12", func(t *testing.T) {
+		certFile, keyFile := writeTempPEM(t, t.TempDir())
+		pkcs12File := writeTempPKCS12(t, "")
+
+		val, err := buildTLSConfig(
+			bedrockconfig.ReaderOf(certFile),
+			bedrockconfig.ReaderOf(keyFile),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.ReaderOf(pkcs12File),
+			bedrockconfig.EmptyReader[string](),
+		).Read(context.Background())
+		require.NoError(t, err)
+
+		cfg, ok := val.Value()
+		require.True(t, ok)
+		require.Empty(t, cfg.Certificates)
+		require.NotNil(t, cfg.GetCertificate)
+	})
+}
+
+func TestBuildPEMTLSConfig(t *testing.T) {
+	t.Run("loads cert and key from PEM files", func(t *testing.T) {
+		certFile, keyFile := writeTempPEM(t, t.TempDir())
+
+		val, err := buildPEMTLSConfig(
+			bedrockconfig.ReaderOf(certFile),
+			bedrockconfig.ReaderOf(keyFile),
+			bedrockconfig.EmptyReader[string](),
+		).Read(context.Background())
+		require.NoError(t, err)
+
+		cfg, ok := val.Value()
+		require.True(t, ok)
+
+		cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		require.Len(t, cert.Certificate, 1)
+	})
+
+	t.Run("appends CA certificates to the chain", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTempPEM(t, dir)
+		caFile, _ := writeTempPEM(t, t.TempDir())
+
+		val, err := buildPEMTLSConfig(
+			bedrockconfig.ReaderOf(certFile),
+			bedrockconfig.ReaderOf(keyFile),
+			bedrockconfig.ReaderOf(caFile),
+		).Read(context.Background())
+		require.NoError(t, err)
+
+		cfg, ok := val.Value()
+		require.True(t, ok)
+
+		cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		require.Len(t, cert.Certificate, 2)
+	})
+
+	t.Run("returns no value when the cert file is not set", func(t *testing.T) {
+		val, err := buildPEMTLSConfig(
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+		).Read(context.Background())
+		require.NoError(t, err)
+
+		_, ok := val.Value()
+		require.False(t, ok)
+	})
+
+	t.Run("returns error when the key file is not set", func(t *testing.T) {
+		certFile, _ := writeTempPEM(t, t.TempDir())
+
+		_, err := buildPEMTLSConfig(
+			bedrockconfig.ReaderOf(certFile),
+			bedrockconfig.EmptyReader[string](),
+			bedrockconfig.EmptyReader[string](),
+		).Read(context.Background())
+		require.Error(t, err)
+	})
+
+	t.Run("returns error when the files do not exist", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, err := buildPEMTLSConfig(
+			bedrockconfig.ReaderOf(filepath.Join(dir, "tls.crt")),
+			bedrockconfig.ReaderOf(filepath.Join(dir, "tls.key")),
+			bedrockconfig.EmptyReader[string](),
+		).Read(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestCertReloader(t *testing.T) {
+	t.Run("reloads the certificate when the files change", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTempPEM(t, dir)
+
+		r, err := newCertReloader(certFile, keyFile, "")
+		require.NoError(t, err)
+		r.interval = 0
+
+		before, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+
+		// Ensure the modification time changes even on coarse grained file systems.
+		writeTempPEM(t, dir)
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, future, future))
+
+		after, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		require.NotEqual(t, before.Certificate[0], after.Certificate[0])
+	})
+
+	t.Run("keeps serving the current certificate if reloading fails", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTempPEM(t, dir)
+
+		r, err := newCertReloader(certFile, keyFile, "")
+		require.NoError(t, err)
+		r.interval = 0
+
+		before, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0600))
+
+		after, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		require.Same(t, before, after)
+	})
+
+	t.Run("does not check the files more often than the interval", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTempPEM(t, dir)
+
+		r, err := newCertReloader(certFile, keyFile, "")
+		require.NoError(t, err)
+
+		before, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+
+		writeTempPEM(t, dir)
+
+		after, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		require.Same(t, before, after)
+	})
 }
 
 // randomPassword generates a cryptographically random password for testing.
@@ -169,3 +329,25 @@ func writeTempPKCS12(t *testing.T, password string) string {
 	require.NoError(t, os.WriteFile(pkcs12File, pfxData, 0600))
 	return pkcs12File
 }
+
+// writeTempPEM generates a self-signed cert and writes the PEM encoded cert
+// and key to tls.crt and tls.key in dir, returning their paths.
+func writeTempPEM(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	val, err := buildSelfSignedTLSConfig().Read(context.Background())
+	require.NoError(t, err)
+	cfg, _ := val.Value()
+	tlsCert := cfg.Certificates[0]
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(tlsCert.PrivateKey)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsCert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+	return certFile, keyFile
+}
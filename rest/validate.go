@@ -0,0 +1,446 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// InvalidParam describes a part of the request which failed validation.
+type InvalidParam struct {
+	// Name is the path of the invalid field, e.g. "items[0].quantity".
+	Name string `json:"name"`
+
+	// Reason explains why the field is invalid.
+	Reason string `json:"reason"`
+}
+
+// bodyPath is the name of violations of the request body as a whole.
+const bodyPath = "body"
+
+// schemaValidator validates JSON documents against a schema of an OpenAPI
+// spec. It supports the keywords reflected from the validation tags of
+// request types, i.e. type, nullable, enum, required, properties,
+// additionalProperties, items, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, multipleOf, minLength, maxLength, pattern, minItems,
+// maxItems, allOf, anyOf and oneOf, along with references to component
+// schemas. Other keywords, such as format, are ignored.
+type schemaValidator struct {
+	schema   map[string]any
+	schemas  map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+// newSchemaValidator returns a validator of the JSON request body of the
+// operation, or nil if the operation has no JSON request body.
+func newSchemaValidator(spec, op map[string]any) (*schemaValidator, error) {
+	reqBody, _ := op["requestBody"].(map[string]any)
+	content, _ := reqBody["content"].(map[string]any)
+	media, _ := content["application/json"].(map[string]any)
+	schema, ok := media["schema"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	components, _ := spec["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+
+	v := &schemaValidator{
+		schema:   schema,
+		schemas:  schemas,
+		patterns: make(map[string]*regexp.Regexp),
+	}
+	if err := v.compilePatterns(schema, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// compilePatterns compiles the patterns of the schema and the schemas it
+// refers to, so requests are validated without compiling them again.
+func (v *schemaValidator) compilePatterns(schema map[string]any, seen map[string]bool) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		if seen[ref] {
+			return nil
+		}
+		seen[ref] = true
+	}
+	schema = v.resolve(schema)
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("rest: invalid pattern %q: %w", pattern, err)
+		}
+		v.patterns[pattern] = re
+	}
+
+	var subschemas []any
+	if props, ok := schema["properties"].(map[string]any); ok {
+		for _, name := range slices.Sorted(maps.Keys(props)) {
+			subschemas = append(subschemas, props[name])
+		}
+	}
+	subschemas = append(subschemas, schema["items"], schema["additionalProperties"])
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		all, _ := schema[keyword].([]any)
+		subschemas = append(subschemas, all...)
+	}
+	for _, sub := range subschemas {
+		sub, ok := sub.(map[string]any)
+		if !ok {
+			continue
+		}
+		if err := v.compilePatterns(sub, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve follows a reference to a component schema.
+func (v *schemaValidator) resolve(schema map[string]any) map[string]any {
+	for range 32 {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		schema, _ = v.schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+	}
+	return schema
+}
+
+// validate returns every violation of the schema by the JSON document.
+func (v *schemaValidator) validate(data []byte) ([]InvalidParam, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+
+	var violations []InvalidParam
+	v.validateValue("", v.schema, doc, &violations)
+	return violations, nil
+}
+
+// countMatches returns the number of subschemas the value is valid against.
+func (v *schemaValidator) countMatches(path string, subs []map[string]any, value any) int {
+	var n int
+	for _, sub := range subs {
+		var errs []InvalidParam
+		v.validateValue(path, sub, value, &errs)
+		if len(errs) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (v *schemaValidator) validateValue(path string, schema map[string]any, value any, violations *[]InvalidParam) {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return
+	}
+	report := func(format string, args ...any) {
+		name := path
+		if name == "" {
+			name = bodyPath
+		}
+		*violations = append(*violations, InvalidParam{Name: name, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	for _, sub := range subschemasOf(schema, "allOf") {
+		v.validateValue(path, sub, value, violations)
+	}
+	if subs := subschemasOf(schema, "anyOf"); len(subs) > 0 && v.countMatches(path, subs, value) == 0 {
+		report("must match one of the allowed schemas")
+	}
+	if subs := subschemasOf(schema, "oneOf"); len(subs) > 0 {
+		switch v.countMatches(path, subs, value) {
+		case 0:
+			report("must match one of the allowed schemas")
+		case 1:
+		default:
+			report("must match exactly one of the allowed schemas")
+		}
+	}
+
+	types := schemaTypes(schema)
+	if value == nil && (schema["nullable"] == true || slices.Contains(types, "null")) {
+		return
+	}
+	if len(types) > 0 && !slices.ContainsFunc(types, func(typ string) bool { return isJSONType(value, typ) }) {
+		report("must be of type %s", strings.Join(types, " or "))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return jsonEqual(e, value) }) {
+		allowed := make([]string, len(enum))
+		for i, e := range enum {
+			allowed[i] = fmt.Sprint(e)
+		}
+		report("must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	switch value := value.(type) {
+	case json.Number:
+		v.validateNumber(schema, value, report)
+	case string:
+		v.validateString(schema, value, report)
+	case []any:
+		if n, ok := schemaNumber(schema, "minItems"); ok && float64(len(value)) < n {
+			report("must have at least %s items", formatNumber(n))
+		}
+		if n, ok := schemaNumber(schema, "maxItems"); ok && float64(len(value)) > n {
+			report("must have at most %s items", formatNumber(n))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value {
+				v.validateValue(fmt.Sprintf("%s[%d]", path, i), items, item, violations)
+			}
+		}
+	case map[string]any:
+		v.validateObject(path, schema, value, violations)
+	}
+}
+
+func (v *schemaValidator) validateNumber(schema map[string]any, value json.Number, report func(string, ...any)) {
+	n, err := value.Float64()
+	if err != nil {
+		return
+	}
+	if minimum, ok := schemaNumber(schema, "minimum"); ok {
+		if schema["exclusiveMinimum"] == true && n <= minimum {
+			report("must be greater than %s", formatNumber(minimum))
+		} else if n < minimum {
+			report("must be at least %s", formatNumber(minimum))
+		}
+	}
+	if minimum, ok := schemaNumber(schema, "exclusiveMinimum"); ok && n <= minimum {
+		report("must be greater than %s", formatNumber(minimum))
+	}
+	if maximum, ok := schemaNumber(schema, "maximum"); ok {
+		if schema["exclusiveMaximum"] == true && n >= maximum {
+			report("must be less than %s", formatNumber(maximum))
+		} else if n > maximum {
+			report("must be at most %s", formatNumber(maximum))
+		}
+	}
+	if maximum, ok := schemaNumber(schema, "exclusiveMaximum"); ok && n >= maximum {
+		report("must be less than %s", formatNumber(maximum))
+	}
+	if m, ok := schemaNumber(schema, "multipleOf"); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			report("must be a multiple of %s", formatNumber(m))
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(schema map[string]any, value string, report func(string, ...any)) {
+	length := float64(utf8.RuneCountInString(value))
+	if n, ok := schemaNumber(schema, "minLength"); ok && length < n {
+		report("must have at least %s characters", formatNumber(n))
+	}
+	if n, ok := schemaNumber(schema, "maxLength"); ok && length > n {
+		report("must have at most %s characters", formatNumber(n))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re := v.patterns[pattern]; re != nil && !re.MatchString(value) {
+			report("must match the pattern %s", pattern)
+		}
+	}
+}
+
+// validateObject validates the properties of an object. A null property is
+// treated as absent, since it decodes to the zero value of the field.
+func (v *schemaValidator) validateObject(path string, schema map[string]any, value map[string]any, violations *[]InvalidParam) {
+	child := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		name, _ := name.(string)
+		if value[name] == nil {
+			*violations = append(*violations, InvalidParam{Name: child(name), Reason: "is required"})
+		}
+	}
+
+	props, _ := schema["properties"].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(value)) {
+		prop := value[name]
+		if prop == nil {
+			continue
+		}
+		if sub, ok := props[name].(map[string]any); ok {
+			v.validateValue(child(name), sub, prop, violations)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*violations = append(*violations, InvalidParam{Name: child(name), Reason: "is not allowed"})
+			}
+		case map[string]any:
+			v.validateValue(child(name), additional, prop, violations)
+		}
+	}
+}
+
+func subschemasOf(schema map[string]any, keyword string) []map[string]any {
+	all, _ := schema[keyword].([]any)
+	var subs []map[string]any
+	for _, sub := range all {
+		if sub, ok := sub.(map[string]any); ok {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+func schemaTypes(schema map[string]any) []string {
+	switch typ := schema["type"].(type) {
+	case string:
+		return []string{typ}
+	case []any:
+		var types []string
+		for _, t := range typ {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func schemaNumber(schema map[string]any, keyword string) (float64, bool) {
+	n, ok := schema[keyword].(float64)
+	return n, ok
+}
+
+func isJSONType(value any, typ string) bool {
+	switch value := value.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case string:
+		return typ == "string"
+	case []any:
+		return typ == "array"
+	case map[string]any:
+		return typ == "object"
+	case json.Number:
+		if typ == "number" {
+			return true
+		}
+		if typ != "integer" {
+			return false
+		}
+		if _, err := value.Int64(); err == nil {
+			return true
+		}
+		n, err := value.Float64()
+		return err == nil && n == math.Trunc(n)
+	}
+	return false
+}
+
+// jsonEqual reports whether a value of the spec equals a value of the request,
+// whose numbers are decoded as [json.Number].
+func jsonEqual(specValue, value any) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return err == nil && specValue == f
+	}
+	return reflect.DeepEqual(specValue, value)
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// validateHandler validates JSON request bodies against the schema of the
+// operation before calling next. Bodies which are not valid JSON or violate
+// the schema are rejected with a 400 problem listing every violation.
+func validateHandler(op routeOperation, next http.Handler) http.Handler {
+	if op.body == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "" && !supportsMediaType([]string{"application/json"}, mediaTypeOf(ct)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		data, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		violations, err := op.body.validate(data)
+		if err != nil {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("The request body is not a valid JSON document: %v", err))
+			return
+		}
+		if len(violations) > 0 {
+			writeInvalidParams(w, violations)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		r.ContentLength = int64(len(data))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeInvalidParams(w http.ResponseWriter, violations []InvalidParam) {
+	p := BadRequest("The request body is invalid.")
+	p.InvalidParams = violations
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p) //nolint:errcheck
+}
+
+// validationResponses documents the 400 response of routes whose request
+// bodies are validated in the OpenAPI spec.
+func validationResponses(routes []authorizedRoute) specDecorator {
+	return func(spec map[string]any) error {
+		paths := specObject(spec, "paths")
+		for _, route := range routes {
+			if route.op.body == nil {
+				continue
+			}
+			op := specObject(specObject(paths, route.op.path), strings.ToLower(route.op.method))
+			addResponse(specObject(op, "responses"), http.StatusBadRequest)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+type testItem struct {
+	SKU      string  `json:"sku" required:"true" pattern:"^[A-Z]{3}-[0-9]+$"`
+	Quantity int     `json:"quantity" required:"true" minimum:"1" maximum:"10"`
+	Price    float64 `json:"price" minimum:"0" exclusiveMinimum:"0"`
+}
+
+type testOrder struct {
+	Customer string     `json:"customer" required:"true" minLength:"1" maxLength:"8"`
+	Priority string     `json:"priority,omitempty" enum:"low,high"`
+	Items    []testItem `json:"items" required:"true" minItems:"1" maxItems:"3"`
+	Note     *testItem  `json:"note,omitempty"`
+}
+
+func newOrderRoute(handler func(context.Context, bedrockrest.Request[testOrder]) (testResponse, error)) bedrockrest.Route {
+	ep := bedrockrest.POST("/orders", handler)
+	ep = bedrockrest.ReadJSON[testOrder](ep)
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusCreated, ep)
+	return bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+}
+
+func TestSchemaValidator(t *testing.T) {
+	route := newOrderRoute(func(ctx context.Context, req bedrockrest.Request[testOrder]) (testResponse, error) {
+		return testResponse{}, nil
+	})
+	op, err := discoverOperation(context.Background(), route)
+	require.NoError(t, err)
+	require.NotNil(t, op.body)
+
+	testCases := []struct {
+		name string
+		body string
+		want []InvalidParam
+	}{
+		{
+			name: "valid",
+			body: `{"customer":"alice","priority":"low","items":[{"sku":"ABC-1","quantity":2,"price":1.5}],"note":null}`,
+		},
+		{
+			name: "missing required fields",
+			body: `{}`,
+			want: []InvalidParam{
+				{Name: "customer", Reason: "is required"},
+				{Name: "items", Reason: "is required"},
+			},
+		},
+		{
+			name: "every violation",
+			body: `{"customer":"","priority":"urgent","items":[{"sku":"abc","quantity":0,"price":0},{"quantity":11}]}`,
+			want: []InvalidParam{
+				{Name: "customer", Reason: "must have at least 1 characters"},
+				{Name: "items[0].price", Reason: "must be greater than 0"},
+				{Name: "items[0].quantity", Reason: "must be at least 1"},
+				{Name: "items[0].sku", Reason: "must match the pattern ^[A-Z]{3}-[0-9]+$"},
+				{Name: "items[1].sku", Reason: "is required"},
+				{Name: "items[1].quantity", Reason: "must be at most 10"},
+				{Name: "priority", Reason: "must be one of low, high"},
+			},
+		},
+		{
+			name: "wrong types",
+			body: `{"customer":"alice","items":[{"sku":"ABC-1","quantity":1.5}],"note":"none"}`,
+			want: []InvalidParam{
+				{Name: "items[0].quantity", Reason: "must be of type integer"},
+				{Name: "note", Reason: "must be of type object"},
+			},
+		},
+		{
+			name: "too many items",
+			body: `{"customer":"alice","items":[{"sku":"ABC-1","quantity":1},{"sku":"ABC-2","quantity":1},{"sku":"ABC-3","quantity":1},{"sku":"ABC-4","quantity":1}]}`,
+			want: []InvalidParam{
+				{Name: "items", Reason: "must have at most 3 items"},
+			},
+		},
+		{
+			name: "not an object",
+			body: `[]`,
+			want: []InvalidParam{
+				{Name: "body", Reason: "must be of type object"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := op.body.validate([]byte(tc.body))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		_, err := op.body.validate([]byte(`{"customer":`))
+		require.Error(t, err)
+
+		_, err = op.body.validate([]byte(`{} {}`))
+		require.Error(t, err)
+	})
+}
+
+func TestSchemaValidator_Composition(t *testing.T) {
+	v := &schemaValidator{
+		schema: map[string]any{
+			"properties": map[string]any{
+				"any": map[string]any{
+					"anyOf": []any{
+						map[string]any{"type": "integer"},
+						map[string]any{"type": "number"},
+					},
+				},
+				"one": map[string]any{
+					"oneOf": []any{
+						map[string]any{"type": "integer"},
+						map[string]any{"type": "number"},
+					},
+				},
+			},
+		},
+		patterns: make(map[string]*regexp.Regexp),
+	}
+
+	testCases := []struct {
+		name string
+		body string
+		want []InvalidParam
+	}{
+		{name: "matches a single branch", body: `{"any":1.5,"one":1.5}`},
+		{name: "matches several branches of anyOf", body: `{"any":1}`},
+		{
+			name: "matches several branches of oneOf",
+			body: `{"one":1}`,
+			want: []InvalidParam{{Name: "one", Reason: "must match exactly one of the allowed schemas"}},
+		},
+		{
+			name: "matches no branch",
+			body: `{"any":"a","one":"a"}`,
+			want: []InvalidParam{
+				{Name: "any", Reason: "must match one of the allowed schemas"},
+				{Name: "one", Reason: "must match one of the allowed schemas"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := v.validate([]byte(tc.body))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateHandler(t *testing.T) {
+	route := newOrderRoute(func(ctx context.Context, req bedrockrest.Request[testOrder]) (testResponse, error) {
+		return testResponse{}, nil
+	})
+	op, err := discoverOperation(context.Background(), route)
+	require.NoError(t, err)
+
+	called := false
+	h := validateHandler(op, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	serve := func(contentType, body string) *httptest.ResponseRecorder {
+		called = false
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		w := serve("application/json", `{`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		require.False(t, called)
+	})
+
+	t.Run("skips other media types", func(t *testing.T) {
+		w := serve("text/plain", `{}`)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.True(t, called)
+	})
+
+	t.Run("is disabled without a request body schema", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		require.NotNil(t, validateHandler(routeOperation{}, next))
+	})
+}
+
+func TestRun_Validation(t *testing.T) {
+	port := freePort(t)
+
+	var got testOrder
+	route := newOrderRoute(func(ctx context.Context, req bedrockrest.Request[testOrder]) (testResponse, error) {
+		got = req.Body()
+		return testResponse{Message: "created"}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			ctx,
+			Port(bedrockconfig.ReaderOf(port)),
+			Handle(route, Consumes(CBOR())),
+		)
+	}()
+
+	client := insecureClient()
+	baseURL := fmt.Sprintf("https://localhost:%d", port)
+
+	post := func(t *testing.T, body string) *http.Response {
+		t.Helper()
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			req, err := http.NewRequest(http.MethodPost, baseURL+"/orders", bytes.NewReader([]byte(body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err = client.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("passes valid bodies to the handler", func(t *testing.T) {
+		resp := post(t, `{"customer":"alice","items":[{"sku":"ABC-1","quantity":2}]}`)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, "alice", got.Customer)
+		require.Equal(t, 2, got.Items[0].Quantity)
+	})
+
+	t.Run("lists every violation", func(t *testing.T) {
+		got = testOrder{}
+
+		resp := post(t, `{"customer":"alice","items":[{"sku":"abc","quantity":0}]}`)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, ProblemContentType, resp.Header.Get("Content-Type"))
+		require.Empty(t, got.Customer)
+
+		var p Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&p))
+		require.Equal(t, http.StatusBadRequest, p.Status)
+		require.Equal(t, "/orders", p.Instance)
+		require.Equal(t, []InvalidParam{
+			{Name: "items[0].quantity", Reason: "must be at least 1"},
+			{Name: "items[0].sku", Reason: "must match the pattern ^[A-Z]{3}-[0-9]+$"},
+		}, p.InvalidParams)
+	})
+
+	t.Run("documents the constraints in the spec", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/openapi.json", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]any `json:"responses"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Required   []string                  `json:"required"`
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+		require.Contains(t, spec.Paths["/orders"]["post"].Responses, "400")
+
+		item := spec.Components.Schemas["RestTestItem"]
+		require.ElementsMatch(t, []string{"sku", "quantity"}, item.Required)
+		require.Equal(t, float64(1), item.Properties["quantity"]["minimum"])
+		require.Equal(t, float64(10), item.Properties["quantity"]["maximum"])
+		require.Equal(t, "^[A-Z]{3}-[0-9]+$", item.Properties["sku"]["pattern"])
+	})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
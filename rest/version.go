@@ -0,0 +1,330 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiVersion is a version of the API registered with [APIVersion].
+type apiVersion struct {
+	name string
+	opts *options
+}
+
+// APIVersion groups routes into a version of the API, e.g. "v1", which is
+// served alongside the other versions and the routes registered outside of
+// any version. By default the version is selected by a path prefix, i.e. the
+// routes of version "v1" are served under /v1 and its OpenAPI spec at
+// /v1/openapi.json. [VersionHeader] selects versions by a request header
+// instead.
+//
+// Every version has its own OpenAPI spec, which inherits the title, version
+// and description of the API unless they are set by opts. Only [Handle], the
+// OpenAPI options and [DeprecatedAPI] apply to a version; other options are
+// ignored.
+func APIVersion(name string, opts ...Option) Option {
+	return func(o *options) {
+		v := &options{}
+		for _, opt := range opts {
+			opt(v)
+		}
+		o.versions = append(o.versions, apiVersion{name: name, opts: v})
+	}
+}
+
+// VersionHeader selects the version of the API by the value of the given
+// request header, e.g. "API-Version: v2", instead of by path prefix. Requests
+// without the header are served by the fallback version or, if fallback is
+// empty, by the routes registered outside of any version. Requests for an
+// unknown version are rejected with 400. The spec of every version is also
+// served under its path prefix, e.g. /v2/openapi.json.
+func VersionHeader(header, fallback string) Option {
+	return func(o *options) {
+		o.versionHeader = header
+		o.fallbackVersion = fallback
+	}
+}
+
+// deprecation is the deprecation of a route or an API.
+type deprecation struct {
+	since  time.Time
+	sunset time.Time
+}
+
+// DeprecatedAPI marks every route registered with the same options, e.g. all
+// routes of a version passed to [APIVersion], as deprecated since the given
+// time and to be removed at sunset, as with [Deprecated] and [Sunset].
+// Either time may be zero if it is not known.
+func DeprecatedAPI(since, sunset time.Time) Option {
+	return func(o *options) {
+		o.deprecation = &deprecation{since: since, sunset: sunset}
+	}
+}
+
+// Deprecated marks the route as deprecated since the given time, which may be
+// zero if it is not known. The operation is marked deprecated in the OpenAPI
+// spec and responses carry a Deprecation header (RFC 9745). Routes marked
+// deprecated with bedrock are treated the same.
+func Deprecated(since time.Time) RouteOption {
+	return func(ro *routeOptions) {
+		ro.deprecated = true
+		ro.deprecatedSince = since
+	}
+}
+
+// Sunset announces that the route will be removed at the given time.
+// Responses carry a Sunset header (RFC 8594) and the operation documents the
+// time in the x-sunset extension of the OpenAPI spec.
+func Sunset(at time.Time) RouteOption {
+	return func(ro *routeOptions) {
+		ro.sunset = at
+	}
+}
+
+// deprecate applies the deprecation of an API to the routes which do not
+// declare their own.
+func (d *deprecation) deprecate(routes []handledRoute) []handledRoute {
+	if d == nil {
+		return routes
+	}
+	deprecated := make([]handledRoute, len(routes))
+	for i, r := range routes {
+		if !r.authz.deprecated {
+			r.authz.deprecated = true
+			r.authz.deprecatedSince = d.since
+		}
+		if r.authz.sunset.IsZero() {
+			r.authz.sunset = d.sunset
+		}
+		deprecated[i] = r
+	}
+	return deprecated
+}
+
+// deprecationHandler sets the Deprecation and Sunset headers of the
+// responses of a deprecated route.
+func deprecationHandler(ro routeOptions, next http.Handler) http.Handler {
+	if !ro.deprecated && ro.sunset.IsZero() {
+		return next
+	}
+
+	deprecation := "true"
+	if !ro.deprecatedSince.IsZero() {
+		deprecation = "@" + strconv.FormatInt(ro.deprecatedSince.Unix(), 10)
+	}
+	sunset := ""
+	if !ro.sunset.IsZero() {
+		sunset = ro.sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ro.deprecated {
+			w.Header().Set("Deprecation", deprecation)
+		}
+		if sunset != "" {
+			w.Header().Set("Sunset", sunset)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deprecations marks the operations of deprecated routes as deprecated in
+// the OpenAPI spec and documents their Deprecation and Sunset headers.
+func deprecations(routes []authorizedRoute) specDecorator {
+	return func(spec map[string]any) error {
+		paths := specObject(spec, "paths")
+		for _, route := range routes {
+			if !route.authz.deprecated && route.authz.sunset.IsZero() {
+				continue
+			}
+			op := specObject(specObject(paths, route.op.path), strings.ToLower(route.op.method))
+
+			headers := make(map[string]any)
+			if route.authz.deprecated {
+				op["deprecated"] = true
+				headers["Deprecation"] = map[string]any{
+					"description": "Time since which the operation is deprecated (RFC 9745).",
+					"schema":      map[string]any{"type": "string"},
+				}
+			}
+			if !route.authz.sunset.IsZero() {
+				op["x-sunset"] = route.authz.sunset.UTC().Format(time.RFC3339)
+				headers["Sunset"] = map[string]any{
+					"description": "Time at which the operation will be removed (RFC 8594).",
+					"schema":      map[string]any{"type": "string"},
+				}
+			}
+			for _, resp := range specObject(op, "responses") {
+				resp, ok := resp.(map[string]any)
+				if !ok {
+					continue
+				}
+				respHeaders := specObject(resp, "headers")
+				for name, header := range headers {
+					respHeaders[name] = header
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// inherit returns the options of the version, defaulting the OpenAPI
+// options to those of the API.
+func (v apiVersion) inherit(o *options) *options {
+	vo := *v.opts
+	if vo.title == "" {
+		vo.title = o.title
+	}
+	if vo.version == "" {
+		vo.version = o.version
+	}
+	if vo.description == "" {
+		vo.description = o.description
+	}
+	if vo.specPath == "" {
+		vo.specPath = o.specPath
+	}
+	return &vo
+}
+
+// validVersionName reports whether name can be used as a path segment and
+// header value.
+func validVersionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// versionSpecPaths returns the paths the specs of the versions are served at.
+func versionSpecPaths(o *options) []string {
+	var paths []string
+	for _, v := range o.versions {
+		paths = append(paths, "/"+v.name+specPathOf(v.inherit(o)))
+	}
+	return paths
+}
+
+// mountVersions builds the API of every version and serves them in front of
+// root, which serves the routes registered outside of any version.
+func mountVersions(ctx context.Context, o *options, a apiBuilder, root http.Handler) (http.Handler, error) {
+	if len(o.versions) == 0 {
+		return root, nil
+	}
+
+	handlers := make(map[string]http.Handler)
+	mux := http.NewServeMux()
+	for _, v := range o.versions {
+		if !validVersionName(v.name) {
+			return nil, fmt.Errorf("rest: invalid API version name %q", v.name)
+		}
+		if _, ok := handlers[v.name]; ok {
+			return nil, fmt.Errorf("rest: API version %q is registered more than once", v.name)
+		}
+
+		prefix := "/" + v.name
+		decorator := specServer(prefix)
+		if o.versionHeader != "" {
+			decorator = versionHeaderParam(o.versionHeader, v.name, v.name == o.fallbackVersion)
+		}
+		vo := v.inherit(o)
+		h, err := a.build(ctx, vo, prefix, decorator)
+		if err != nil {
+			return nil, err
+		}
+		handlers[v.name] = h
+
+		if o.versionHeader == "" {
+			mux.Handle(prefix+"/", http.StripPrefix(prefix, h))
+			continue
+		}
+		mux.Handle("GET "+prefix+specPathOf(vo), servePath(specPathOf(vo), h))
+	}
+
+	if o.versionHeader == "" {
+		mux.Handle("/", root)
+		return mux, nil
+	}
+	if o.fallbackVersion != "" && handlers[o.fallbackVersion] == nil {
+		return nil, fmt.Errorf("rest: fallback API version %q is not registered", o.fallbackVersion)
+	}
+
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", o.versionHeader)
+
+		name := r.Header.Get(o.versionHeader)
+		if name == "" {
+			name = o.fallbackVersion
+		}
+		if name == "" {
+			root.ServeHTTP(w, r)
+			return
+		}
+		h, ok := handlers[name]
+		if !ok {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("The API version %q is not supported.", name))
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	return mux, nil
+}
+
+// servePath serves requests with h as if they were made to path.
+func servePath(path string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = path
+		r2.URL.RawPath = ""
+		h.ServeHTTP(w, r2)
+	})
+}
+
+// specServer sets the server URL of the OpenAPI spec of a version selected
+// by its path prefix.
+func specServer(prefix string) specDecorator {
+	return func(spec map[string]any) error {
+		spec["servers"] = []any{map[string]any{"url": prefix}}
+		return nil
+	}
+}
+
+// versionHeaderParam documents the version header as a parameter of every
+// operation of a version selected by header.
+func versionHeaderParam(header, name string, fallback bool) specDecorator {
+	return func(spec map[string]any) error {
+		for _, item := range specObject(spec, "paths") {
+			item, _ := item.(map[string]any)
+			for method, op := range item {
+				op, ok := op.(map[string]any)
+				if !ok || !isHTTPMethod(method) {
+					continue
+				}
+				params, _ := op["parameters"].([]any)
+				op["parameters"] = append(params, map[string]any{
+					"name":        header,
+					"in":          "header",
+					"required":    !fallback,
+					"description": "Version of the API.",
+					"schema":      map[string]any{"type": "string", "enum": []any{name}},
+				})
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	bedrockrest "github.com/z5labs/bedrock/runtime/http/rest"
+)
+
+func newPetsRoute(message string, deprecated bool) bedrockrest.Route {
+	ep := bedrockrest.GET("/pets", func(ctx context.Context, req bedrockrest.Request[bedrockrest.EmptyBody]) (testResponse, error) {
+		return testResponse{Message: message}, nil
+	})
+	ep = bedrockrest.OperationID("listPets", ep)
+	if deprecated {
+		ep = bedrockrest.MarkDeprecated(ep)
+	}
+	ep = bedrockrest.WriteJSON[testResponse](http.StatusOK, ep)
+	return bedrockrest.CatchAll(http.StatusInternalServerError, func(err error) testError {
+		return testError{Message: err.Error()}
+	}, ep)
+}
+
+func newVersionedHandler(t *testing.T, opts ...Option) http.Handler {
+	t.Helper()
+
+	o := defaultOptions()
+	o.accessLog = bedrockconfig.ReaderOf(false)
+	for _, opt := range opts {
+		opt(o)
+	}
+	h, err := buildHandler(o).Build(context.Background())
+	require.NoError(t, err)
+	return h
+}
+
+func serve(h http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func decodeSpec(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	return spec
+}
+
+func TestAPIVersion_Path(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newVersionedHandler(t,
+		Title("Pets"),
+		Version("2.0.0"),
+		DocsUI("/docs"),
+		Handle(newOrderRoute(func(ctx context.Context, req bedrockrest.Request[testOrder]) (testResponse, error) {
+			return testResponse{}, nil
+		})),
+		APIVersion("v1",
+			Version("1.0.0"),
+			Handle(newPetsRoute("v1", false)),
+			DeprecatedAPI(since, sunset),
+		),
+		APIVersion("v2", Handle(newPetsRoute("v2", false))),
+	)
+
+	t.Run("routes requests by path prefix", func(t *testing.T) {
+		w := serve(h, "/v1/pets", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"message":"v1"}`, w.Body.String())
+
+		w = serve(h, "/v2/pets", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"message":"v2"}`, w.Body.String())
+
+		w = serve(h, "/pets", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("sets deprecation headers", func(t *testing.T) {
+		w := serve(h, "/v1/pets", nil)
+		require.Equal(t, fmt.Sprintf("@%d", since.Unix()), w.Header().Get("Deprecation"))
+		require.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+		w = serve(h, "/v2/pets", nil)
+		require.Empty(t, w.Header().Get("Deprecation"))
+		require.Empty(t, w.Header().Get("Sunset"))
+	})
+
+	t.Run("serves a spec per version", func(t *testing.T) {
+		spec := decodeSpec(t, serve(h, "/v1/openapi.json", nil))
+		require.Equal(t, "1.0.0", spec["info"].(map[string]any)["version"])
+		require.Equal(t, "Pets", spec["info"].(map[string]any)["title"])
+		require.Equal(t, []any{map[string]any{"url": "/v1"}}, spec["servers"])
+
+		op := spec["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)
+		require.Equal(t, true, op["deprecated"])
+		require.Equal(t, "2027-01-01T00:00:00Z", op["x-sunset"])
+		headers := op["responses"].(map[string]any)["200"].(map[string]any)["headers"].(map[string]any)
+		require.Contains(t, headers, "Deprecation")
+		require.Contains(t, headers, "Sunset")
+
+		spec = decodeSpec(t, serve(h, "/v2/openapi.json", nil))
+		require.Equal(t, "2.0.0", spec["info"].(map[string]any)["version"])
+		op = spec["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)
+		require.NotContains(t, op, "deprecated")
+
+		spec = decodeSpec(t, serve(h, "/openapi.json", nil))
+		require.Contains(t, spec["paths"], "/orders")
+		require.NotContains(t, spec["paths"], "/pets")
+	})
+
+	t.Run("lists the specs in the docs UI", func(t *testing.T) {
+		w := serve(h, "/docs/", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `{"name":"v1","url":"/v1/openapi.json"}`)
+		require.Contains(t, w.Body.String(), `{"name":"Pets","url":"/openapi.json"}`)
+	})
+}
+
+func TestAPIVersion_Header(t *testing.T) {
+	h := newVersionedHandler(t,
+		APIVersion("v1", Handle(newPetsRoute("v1", true))),
+		APIVersion("v2", Handle(newPetsRoute("v2", false))),
+		VersionHeader("API-Version", "v2"),
+	)
+
+	t.Run("routes requests by header", func(t *testing.T) {
+		w := serve(h, "/pets", http.Header{"Api-Version": {"v1"}})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"message":"v1"}`, w.Body.String())
+		require.Equal(t, "true", w.Header().Get("Deprecation"))
+		require.Contains(t, w.Header().Values("Vary"), "API-Version")
+
+		w = serve(h, "/pets", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"message":"v2"}`, w.Body.String())
+		require.Empty(t, w.Header().Get("Deprecation"))
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		w := serve(h, "/pets", http.Header{"Api-Version": {"v3"}})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("serves a spec per version", func(t *testing.T) {
+		spec := decodeSpec(t, serve(h, "/v1/openapi.json", nil))
+		op := spec["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)
+		require.Equal(t, true, op["deprecated"])
+		require.Contains(t, op["parameters"], map[string]any{
+			"name":        "API-Version",
+			"in":          "header",
+			"required":    true,
+			"description": "Version of the API.",
+			"schema":      map[string]any{"type": "string", "enum": []any{"v1"}},
+		})
+
+		spec = decodeSpec(t, serve(h, "/openapi.json", http.Header{"Api-Version": {"v1"}}))
+		require.Contains(t, spec["paths"], "/pets")
+	})
+}
+
+func TestDeprecated(t *testing.T) {
+	sunset := time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)
+	h := newVersionedHandler(t,
+		Handle(newPetsRoute("pets", false), Deprecated(time.Time{}), Sunset(sunset)),
+	)
+
+	w := serve(h, "/pets", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "true", w.Header().Get("Deprecation"))
+	require.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+	spec := decodeSpec(t, serve(h, "/openapi.json", nil))
+	op := spec["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)
+	require.Equal(t, true, op["deprecated"])
+}
+
+func TestAPIVersion_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "invalid name",
+			opts: []Option{APIVersion("v/1", Handle(newPetsRoute("v1", false)))},
+		},
+		{
+			name: "duplicate name",
+			opts: []Option{
+				APIVersion("v1", Handle(newPetsRoute("v1", false))),
+				APIVersion("v1", Handle(newPetsRoute("v1", false))),
+			},
+		},
+		{
+			name: "unknown fallback",
+			opts: []Option{
+				APIVersion("v1", Handle(newPetsRoute("v1", false))),
+				VersionHeader("API-Version", "v2"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := defaultOptions()
+			for _, opt := range tc.opts {
+				opt(o)
+			}
+			_, err := buildHandler(o).Build(context.Background())
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/z5labs/bedrock"
+	"github.com/z5labs/humus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WebSocketOption configures a [WebSocket] route.
+type WebSocketOption func(*webSocketOptions)
+
+type webSocketOptions struct {
+	accept       websocket.AcceptOptions
+	pingInterval time.Duration
+	readLimit    int64
+}
+
+// PingInterval sets how often the peer is pinged to keep the connection
+// alive. Peers which do not answer a ping before the next one is due are
+// disconnected. Zero disables pings. The default is 30s.
+func PingInterval(d time.Duration) WebSocketOption {
+	return func(wo *webSocketOptions) {
+		wo.pingInterval = d
+	}
+}
+
+// OriginPatterns allows browsers to connect from other origins whose host
+// matches one of the patterns, e.g. "*.example.com". By default only
+// connections from the same origin are accepted, since browsers do not apply
+// CORS to WebSockets.
+func OriginPatterns(patterns ...string) WebSocketOption {
+	return func(wo *webSocketOptions) {
+		wo.accept.OriginPatterns = append(wo.accept.OriginPatterns, patterns...)
+	}
+}
+
+// Subprotocols sets the subprotocols the route supports, in order of
+// preference. The subprotocol negotiated with the client is available via
+// [WebSocketConn.Subprotocol].
+func Subprotocols(protocols ...string) WebSocketOption {
+	return func(wo *webSocketOptions) {
+		wo.accept.Subprotocols = append(wo.accept.Subprotocols, protocols...)
+	}
+}
+
+// ReadLimit sets the maximum size of messages read from the peer in bytes.
+// Larger messages close the connection with status 1009. The default is
+// 32768 bytes.
+func ReadLimit(n int64) WebSocketOption {
+	return func(wo *webSocketOptions) {
+		wo.readLimit = n
+	}
+}
+
+// WebSocketRoute is a route which upgrades requests to WebSocket connections.
+// It is created with [WebSocket] and registered with [HandleWebSocket].
+type WebSocketRoute struct {
+	pattern string
+	serve   func(w http.ResponseWriter, r *http.Request, conns *webSocketConns)
+}
+
+// WebSocket creates a route which upgrades GET requests matching the pattern
+// to WebSocket connections exchanging JSON messages. The handler reads
+// messages of type In from the peer and writes messages of type Out until it
+// returns, which closes the connection. The context passed to the handler is
+// cancelled once the connection is closed by the peer, fails its keepalive
+// pings or the server shuts down, in which case connections are closed with
+// status 1001.
+//
+// Every connection is traced by a span, which is the parent of the spans of
+// the handler. If the handler returns an error, it is recorded and the
+// connection is closed with status 1011. Requests which are not WebSocket
+// upgrades are rejected with 426.
+//
+//	route := rest.WebSocket("/rooms/{room}", func(ctx context.Context, conn *rest.WebSocketConn[ChatMessage, ChatMessage]) error {
+//	    room := conn.Request().PathValue("room")
+//	    for {
+//	        msg, err := conn.Read(ctx)
+//	        if err != nil {
+//	            return err
+//	        }
+//	        ...
+//	    }
+//	})
+func WebSocket[In, Out any](pattern string, handler func(context.Context, *WebSocketConn[In, Out]) error, opts ...WebSocketOption) WebSocketRoute {
+	wo := webSocketOptions{
+		pingInterval: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&wo)
+	}
+
+	return WebSocketRoute{
+		pattern: pattern,
+		serve: func(w http.ResponseWriter, r *http.Request, conns *webSocketConns) {
+			if !isWebSocketUpgrade(r) {
+				w.Header().Set("Upgrade", "websocket")
+				writeProblem(w, http.StatusUpgradeRequired, "The request must be a WebSocket upgrade.")
+				return
+			}
+			conn, err := websocket.Accept(w, r, &wo.accept)
+			if err != nil {
+				// The response has already been written.
+				return
+			}
+			if wo.readLimit > 0 {
+				conn.SetReadLimit(wo.readLimit)
+			}
+			if !conns.add(conn) {
+				conn.Close(websocket.StatusGoingAway, "The server is shutting down.") //nolint:errcheck
+				return
+			}
+			defer conns.remove(conn)
+
+			serveWebSocket(pattern, handler, wo, conn, r)
+		},
+	}
+}
+
+// HandleWebSocket registers a WebSocket route with the REST server. The
+// route options apply as for [Handle], e.g. the upgrade request must be
+// authorized. WebSocket routes are not documented in the OpenAPI spec.
+func HandleWebSocket(route WebSocketRoute, opts ...RouteOption) Option {
+	return func(o *options) {
+		r := handledWebSocket{route: route}
+		for _, opt := range opts {
+			opt(&r.authz)
+		}
+		o.webSockets = append(o.webSockets, r)
+	}
+}
+
+// handledWebSocket is a WebSocket route registered with [HandleWebSocket].
+type handledWebSocket struct {
+	route WebSocketRoute
+	authz routeOptions
+}
+
+// WebSocketConn is a WebSocket connection which reads JSON messages of type
+// In from the peer and writes JSON messages of type Out. Reads and writes may
+// be called concurrently.
+type WebSocketConn[In, Out any] struct {
+	conn *websocket.Conn
+	req  *http.Request
+
+	msgs chan In
+	err  error // set before msgs is closed
+
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+// Read reads the next message from the peer. It returns an error once the
+// connection is closed or if the message is not valid JSON of type In, in
+// which case the connection is closed with status 1007.
+func (c *WebSocketConn[In, Out]) Read(ctx context.Context) (In, error) {
+	select {
+	case <-ctx.Done():
+		var zero In
+		return zero, ctx.Err()
+	case msg, ok := <-c.msgs:
+		if !ok {
+			return msg, c.err
+		}
+		return msg, nil
+	}
+}
+
+// Write writes a message to the peer.
+func (c *WebSocketConn[In, Out]) Write(ctx context.Context, msg Out) error {
+	if err := wsjson.Write(ctx, c.conn, msg); err != nil {
+		return err
+	}
+	c.sent.Add(1)
+	return nil
+}
+
+// Request returns the upgrade request, e.g. to read its path parameters
+// with PathValue.
+func (c *WebSocketConn[In, Out]) Request() *http.Request {
+	return c.req
+}
+
+// Subprotocol returns the subprotocol negotiated with the client, if any.
+func (c *WebSocketConn[In, Out]) Subprotocol() string {
+	return c.conn.Subprotocol()
+}
+
+// readLoop reads messages from the peer until the connection is closed,
+// which cancels the context of the connection. Reading continuously also
+// answers the pings of the peer and reads the pongs of keepalive pings.
+func (c *WebSocketConn[In, Out]) readLoop(ctx context.Context, cancel context.CancelCauseFunc) {
+	defer close(c.msgs)
+	for {
+		_, data, err := c.conn.Read(ctx)
+		if err != nil {
+			c.err = err
+			cancel(err)
+			return
+		}
+
+		var msg In
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.err = fmt.Errorf("rest: received invalid WebSocket message: %w", err)
+			cancel(c.err)
+			c.conn.Close(websocket.StatusInvalidFramePayloadData, "The message is not valid JSON.") //nolint:errcheck
+			return
+		}
+		c.received.Add(1)
+
+		select {
+		case c.msgs <- msg:
+		case <-ctx.Done():
+			c.err = context.Cause(ctx)
+			return
+		}
+	}
+}
+
+// pingLoop pings the peer every interval and closes the connection if it
+// does not answer before the next ping is due.
+func (c *WebSocketConn[In, Out]) pingLoop(ctx context.Context, cancel context.CancelCauseFunc, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		pingCtx, cancelPing := context.WithTimeout(ctx, interval)
+		err := c.conn.Ping(pingCtx)
+		cancelPing()
+		if err != nil && ctx.Err() == nil {
+			cancel(fmt.Errorf("rest: WebSocket peer did not answer ping: %w", err))
+			c.conn.CloseNow() //nolint:errcheck
+			return
+		}
+	}
+}
+
+// serveWebSocket runs the handler of an accepted connection within a span
+// and closes the connection once the handler returns.
+func serveWebSocket[In, Out any](pattern string, handler func(context.Context, *WebSocketConn[In, Out]) error, wo webSocketOptions, conn *websocket.Conn, r *http.Request) {
+	ctx, span := tracer().Start(
+		r.Context(),
+		"websocket "+pattern,
+		trace.WithAttributes(
+			attribute.String("http.route", pattern),
+			attribute.String("websocket.subprotocol", conn.Subprotocol()),
+		),
+	)
+	defer span.End()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	c := &WebSocketConn[In, Out]{
+		conn: conn,
+		req:  r.WithContext(ctx),
+		msgs: make(chan In),
+	}
+	go c.readLoop(ctx, cancel)
+	if wo.pingInterval > 0 {
+		go c.pingLoop(ctx, cancel, wo.pingInterval)
+	}
+
+	err := handler(ctx, c)
+	cause := context.Cause(ctx)
+	switch {
+	case err == nil:
+		conn.Close(websocket.StatusNormalClosure, "") //nolint:errcheck
+	case cause != nil:
+		// The connection has been closed already, so the error is
+		// most likely a consequence thereof.
+		conn.CloseNow() //nolint:errcheck
+	default:
+		log := humus.Logger("github.com/z5labs/humus/rest")
+		log.ErrorContext(ctx, "WebSocket handler failed", slog.String("pattern", pattern), slog.Any("error", err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		conn.Close(websocket.StatusInternalError, "An unexpected error occurred.") //nolint:errcheck
+	}
+
+	span.SetAttributes(
+		attribute.Int64("websocket.messages.received", c.received.Load()),
+		attribute.Int64("websocket.messages.sent", c.sent.Load()),
+	)
+	if status := websocket.CloseStatus(cause); status != -1 {
+		span.SetAttributes(attribute.Int("websocket.close.code", int(status)))
+	}
+}
+
+// isWebSocketUpgrade reports whether the request asks to upgrade the
+// connection to the WebSocket protocol.
+func isWebSocketUpgrade(r *http.Request) bool {
+	for _, v := range r.Header.Values("Upgrade") {
+		for token := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "websocket") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildWebSocketRoutes returns the WebSocket routes along with their
+// operations, so they are served by routesHandler like any other route.
+func buildWebSocketRoutes(routes []handledWebSocket, conns *webSocketConns) []authorizedRoute {
+	var authorized []authorizedRoute
+	for _, r := range routes {
+		serve := r.route.serve
+		authorized = append(authorized, authorizedRoute{
+			op:    routeOperation{method: http.MethodGet, path: r.route.pattern},
+			authz: r.authz,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				serve(w, r, conns)
+			}),
+		})
+	}
+	return authorized
+}
+
+// webSocketConns tracks the open WebSocket connections, which the server
+// does not track once they have been upgraded, so they can be closed when
+// the server shuts down.
+type webSocketConns struct {
+	mu      sync.Mutex
+	conns   map[*websocket.Conn]struct{}
+	closing bool
+	wg      sync.WaitGroup
+}
+
+// add tracks the connection, reporting false if the server is shutting down.
+func (wc *webSocketConns) add(conn *websocket.Conn) bool {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	if wc.closing {
+		return false
+	}
+	if wc.conns == nil {
+		wc.conns = make(map[*websocket.Conn]struct{})
+	}
+	wc.conns[conn] = struct{}{}
+	wc.wg.Add(1)
+	return true
+}
+
+// remove stops tracking the connection once its handler has returned.
+func (wc *webSocketConns) remove(conn *websocket.Conn) {
+	wc.mu.Lock()
+	delete(wc.conns, conn)
+	wc.mu.Unlock()
+	wc.wg.Done()
+}
+
+// shutdown closes every open connection with status 1001 and waits for
+// their handlers to return. Connections accepted afterwards are closed
+// right away.
+func (wc *webSocketConns) shutdown() {
+	wc.mu.Lock()
+	wc.closing = true
+	conns := make([]*websocket.Conn, 0, len(wc.conns))
+	for conn := range wc.conns {
+		conns = append(conns, conn)
+	}
+	wc.mu.Unlock()
+
+	for _, conn := range conns {
+		go conn.Close(websocket.StatusGoingAway, "The server is shutting down.") //nolint:errcheck
+	}
+	wc.wg.Wait()
+}
+
+// webSocketRuntime closes the open WebSocket connections when the server
+// shuts down.
+type webSocketRuntime struct {
+	conns *webSocketConns
+}
+
+// Run waits until ctx is cancelled, then gracefully closes the connections.
+func (r webSocketRuntime) Run(ctx context.Context) error {
+	<-ctx.Done()
+	r.conns.shutdown()
+	return nil
+}
+
+// buildWebSocketRuntime returns a builder for the runtime closing the
+// WebSocket connections, which builds a nil runtime if no WebSocket routes
+// are registered.
+func buildWebSocketRuntime(o *options) bedrock.Builder[bedrock.Runtime] {
+	return bedrock.BuilderFunc[bedrock.Runtime](func(ctx context.Context) (bedrock.Runtime, error) {
+		if !hasWebSockets(o) {
+			return nil, nil
+		}
+		return webSocketRuntime{conns: o.webSocketConns}, nil
+	})
+}
+
+// hasWebSockets reports whether any WebSocket routes are registered with
+// the API or one of its versions.
+func hasWebSockets(o *options) bool {
+	if len(o.webSockets) > 0 {
+		return true
+	}
+	for _, v := range o.versions {
+		if len(v.opts.webSockets) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/require"
+	bedrockconfig "github.com/z5labs/bedrock/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type chatMessage struct {
+	Room string `json:"room"`
+	Text string `json:"text"`
+}
+
+func newChatRoute(opts ...WebSocketOption) WebSocketRoute {
+	return WebSocket("/rooms/{room}", func(ctx context.Context, conn *WebSocketConn[chatMessage, chatMessage]) error {
+		room := conn.Request().PathValue("room")
+		for {
+			msg, err := conn.Read(ctx)
+			if err != nil {
+				return err
+			}
+			if msg.Text == "fail" {
+				return errors.New("failed to handle message")
+			}
+			if err := conn.Write(ctx, chatMessage{Room: room, Text: strings.ToUpper(msg.Text)}); err != nil {
+				return err
+			}
+		}
+	}, opts...)
+}
+
+func newWebSocketServer(t *testing.T, opts ...Option) (*httptest.Server, *options) {
+	t.Helper()
+
+	o := defaultOptions()
+	o.accessLog = bedrockconfig.ReaderOf(false)
+	for _, opt := range opts {
+		opt(o)
+	}
+	h, err := buildHandler(o).Build(context.Background())
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv, o
+}
+
+func dial(t *testing.T, srv *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+path, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func TestWebSocket(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	srv, _ := newWebSocketServer(t, HandleWebSocket(newChatRoute()))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("exchanges JSON messages", func(t *testing.T) {
+		conn := dial(t, srv, "/rooms/lobby")
+		require.NoError(t, wsjson.Write(ctx, conn, chatMessage{Text: "hello"}))
+
+		var msg chatMessage
+		require.NoError(t, wsjson.Read(ctx, conn, &msg))
+		require.Equal(t, chatMessage{Room: "lobby", Text: "HELLO"}, msg)
+		require.NoError(t, conn.Close(websocket.StatusNormalClosure, ""))
+
+		require.Eventually(t, func() bool { return len(sr.Ended()) > 0 }, time.Second, 10*time.Millisecond)
+		var span sdktrace.ReadOnlySpan
+		for _, s := range sr.Ended() {
+			if s.Name() == "websocket /rooms/{room}" {
+				span = s
+			}
+		}
+		require.NotNil(t, span)
+		require.Contains(t, span.Attributes(), attribute.Int64("websocket.messages.received", 1))
+		require.Contains(t, span.Attributes(), attribute.Int64("websocket.messages.sent", 1))
+		require.Contains(t, span.Attributes(), attribute.Int("websocket.close.code", int(websocket.StatusNormalClosure)))
+	})
+
+	t.Run("closes the connection if the handler fails", func(t *testing.T) {
+		conn := dial(t, srv, "/rooms/lobby")
+		require.NoError(t, wsjson.Write(ctx, conn, chatMessage{Text: "fail"}))
+
+		_, _, err := conn.Read(ctx)
+		require.Equal(t, websocket.StatusInternalError, websocket.CloseStatus(err))
+	})
+
+	t.Run("closes the connection on invalid messages", func(t *testing.T) {
+		conn := dial(t, srv, "/rooms/lobby")
+		require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte("not json")))
+
+		_, _, err := conn.Read(ctx)
+		require.Equal(t, websocket.StatusInvalidFramePayloadData, websocket.CloseStatus(err))
+	})
+
+	t.Run("rejects requests which are not upgrades", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/rooms/lobby")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+		require.Equal(t, ProblemContentType, resp.Header.Get("Content-Type"))
+	})
+}
+
+func TestWebSocket_Authorization(t *testing.T) {
+	srv, _ := newWebSocketServer(t,
+		APIKeyAuth(APIKeys{"secret": {Subject: "alice"}}),
+		HandleWebSocket(newChatRoute()),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/rooms/lobby", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/rooms/lobby", &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-Api-Key": {"secret"}},
+	})
+	require.NoError(t, err)
+	conn.CloseNow()
+}
+
+func TestWebSocket_Ping(t *testing.T) {
+	srv, _ := newWebSocketServer(t, HandleWebSocket(newChatRoute(PingInterval(20*time.Millisecond))))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("keeps responsive peers connected", func(t *testing.T) {
+		conn := dial(t, srv, "/rooms/lobby")
+		readCtx := conn.CloseRead(ctx)
+
+		select {
+		case <-readCtx.Done():
+			t.Fatal("connection closed early")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("disconnects unresponsive peers", func(t *testing.T) {
+		// The peer does not read, so pings are never answered.
+		conn := dial(t, srv, "/rooms/lobby")
+
+		time.Sleep(200 * time.Millisecond)
+		_, _, err := conn.Read(ctx)
+		require.Error(t, err)
+		require.Equal(t, websocket.StatusCode(-1), websocket.CloseStatus(err))
+	})
+}
+
+func TestWebSocket_Shutdown(t *testing.T) {
+	srv, o := newWebSocketServer(t, APIVersion("v1", HandleWebSocket(newChatRoute())))
+	conn := dial(t, srv, "/v1/rooms/lobby")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, wsjson.Write(ctx, conn, chatMessage{Text: "hello"}))
+	var msg chatMessage
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+
+	rt, err := buildWebSocketRuntime(o).Build(ctx)
+	require.NoError(t, err)
+	runCtx, stop := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- rt.Run(runCtx) }()
+	stop()
+
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+	require.NoError(t, <-errc)
+
+	// Connections accepted during shutdown are closed right away.
+	conn = dial(t, srv, "/v1/rooms/lobby")
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+}